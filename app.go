@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -17,6 +18,13 @@ const (
 	FormatYAML = "yaml"
 	// FormatText represents plain text output format.
 	FormatText = "text"
+	// FormatTable represents an aligned table with a header row. It accepts
+	// slices of structs or maps.
+	FormatTable = "table"
+	// FormatCSV represents comma-separated values with a header row.
+	FormatCSV = "csv"
+	// FormatTSV represents tab-separated values with a header row.
+	FormatTSV = "tsv"
 )
 
 // App represents a runnable CLI application. It wires together the root
@@ -57,6 +65,16 @@ type App struct {
 	// Use the prompt extension to enable advanced prompts (select, multi-select).
 	Prompter Prompter
 
+	// Interactive controls whether missing required values are prompted for.
+	// The zero value, InteractiveAuto, prompts only when input is a terminal.
+	// Set InteractiveNever in CI so prompts fail fast instead of blocking.
+	Interactive InteractiveMode
+
+	// ArgsFiles makes Run expand @path arguments into the arguments listed in
+	// the file at path before parsing, for command lines too long for CI
+	// configs or the shell. See ExpandArgsFiles for the file format.
+	ArgsFiles bool
+
 	// Out is the writer for standard output (defaults to os.Stdout).
 	Out io.Writer
 
@@ -79,13 +97,37 @@ type App struct {
 	// Use lipgloss-compatible styles or custom TextStyle implementations.
 	Styles Styles
 
-	configLoaded  bool
-	configLoadErr error
-	rootPrepared  bool
+	// HelpFunc replaces the built-in help output. When set, it is called
+	// instead of HelpRenderer for -h/--help and for groups run without a
+	// subcommand, with ctx.Command set to the command whose help is shown.
+	// Call HelpRenderer from it to extend the default output instead, e.g. to
+	// add a footer.
+	HelpFunc func(ctx *Context, w io.Writer) error
+
+	configLoaded   bool
+	configLoadErr  error
+	configPath     string // set by SetConfigPath
+	configFlagPath string // --config given to the current Run
+	loadedFlagPath string // --config the loaded config was read from
+	rootPrepared   bool
+
+	middleware []Middleware
+	rewriters  []ArgsRewriter
+	formats    map[string]FormatFunc
+	output     *os.File
+
+	configBindings map[string]string // flag name -> config key, see BindFlagToConfig
+	boundValues    map[string]string // bound config keys set from flags this Run
 
 	// Extensions for optional batteries-included features
-	extensions     []Extension
-	extensionsOnce sync.Once
+	extensions        []Extension
+	extensionsOnce    sync.Once
+	extensionsApplied bool
+	extensionsErr     error
+	runCommand        *Command // command resolved by the last Run, for Main
+	colorProfile      ColorProfile
+	pluginPrefix      string // set by EnableExternalPlugins
+	timeoutFlag       bool   // set by EnableTimeoutFlag
 }
 
 // AppOption configures an App using the functional options pattern.
@@ -165,17 +207,21 @@ func NewApp(name string, opts ...AppOption) *App {
 		app.Root.Flags = NewFlagSet(app.Root.Name)
 		app.Root.Flags.SetStrict(false)
 	}
+	app.Root.shareRootFlags()
 
 	// Standard flags on root command (accessible via app.Flags()).
-	var help bool
-	app.Flags().BoolVar(BoolVarOptions{
-		FlagOptions: FlagOptions{
-			Name:  "help",
-			Short: "h",
-			Usage: "Show help information",
-		},
-		Value: &help,
-	})
+	// Roots built with NewCommand already have a help flag.
+	if app.Flags().lookup("help") == nil {
+		var help bool
+		app.Flags().BoolVar(BoolVarOptions{
+			FlagOptions: FlagOptions{
+				Name:  "help",
+				Short: "h",
+				Usage: "Show help information",
+			},
+			Value: &help,
+		})
+	}
 
 	return app
 }
@@ -200,6 +246,34 @@ func (a *App) Flags() *FlagSet {
 	return a.Root.Flags
 }
 
+// ShortFlagInUse reports whether short is taken by an app flag or by a flag
+// or persistent flag of any command in the tree. Extensions that add app
+// flags check it before claiming a short name, so that a global -o never
+// competes with the -o of some command.
+func (a *App) ShortFlagInUse(short string) bool {
+	if a.Flags().LookupShort(short) != nil {
+		return true
+	}
+	return shortInTree(a.Root, short)
+}
+
+func shortInTree(cmd *Command, short string) bool {
+	if cmd == nil {
+		return false
+	}
+	for _, fs := range []*FlagSet{cmd.Flags, cmd.PersistentFlags} {
+		if fs != nil && fs.LookupShort(short) != nil {
+			return true
+		}
+	}
+	for _, child := range cmd.Children {
+		if shortInTree(child, short) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddDefaultCommands attaches built-in helper commands to the application.
 //
 // Note: All commands are now extensions:
@@ -288,26 +362,91 @@ type Context struct {
 
 	// Command is the currently executing command.
 	Command *Command
+
+	invoked []string // command names as typed, see CommandPath
+	args    []string // positional arguments as typed, see StdinArg
+
+	mu     sync.Mutex
+	values map[any]any
+}
+
+// Set stores value under key for the rest of this invocation, so hooks,
+// middleware and the handler can share state such as an opened database
+// handle without package globals:
+//
+//	type dbKey struct{}
+//
+//	root.PersistentPreRun = func(ctx *clix.Context) error {
+//		db, err := sql.Open("postgres", dsn)
+//		ctx.Set(dbKey{}, db)
+//		return err
+//	}
+//	cmd.Run = func(ctx *clix.Context) error {
+//		db := ctx.Value(dbKey{}).(*sql.DB)
+//		...
+//	}
+//
+// As with context.WithValue, use an unexported key type defined in your own
+// package so keys from different packages cannot collide. Set is safe for
+// concurrent use.
+func (ctx *Context) Set(key, value any) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.values == nil {
+		ctx.values = make(map[any]any)
+	}
+	ctx.values[key] = value
+}
+
+// Value returns the value stored with Set for key, falling back to the
+// embedded context.Context. Because it overrides context.Context's Value,
+// code that receives ctx as a plain context.Context sees the values too.
+func (ctx *Context) Value(key any) any {
+	ctx.mu.Lock()
+	value, ok := ctx.values[key]
+	ctx.mu.Unlock()
+	if ok {
+		return value
+	}
+	if ctx.Context == nil {
+		return nil
+	}
+	return ctx.Context.Value(key)
 }
 
 // resolveValue retrieves a configuration value following the precedence chain:
-// command flags > app flags > env > config > defaults.
+// command flags > persistent flags > app flags > env > config > defaults.
 // Returns the raw string value, its source, and whether it was found.
 func (ctx *Context) resolveValue(key string) (string, Source, bool) {
-	// First check command-level flags (only if explicitly set)
-	if ctx.Command != nil && ctx.Command.Flags != nil {
-		if flag := ctx.Command.Flags.lookup(key); flag != nil && flag.set {
-			if v, ok := ctx.Command.Flags.String(key); ok {
+	return ctx.resolve(key, ctx.Command.scopedConfig())
+}
+
+// resolve implements resolveValue. When scoped is set, config lookups try
+// the keys under the command path first (see Command.ScopedConfig).
+//
+// A flag that holds a value, whether from the command line, env or config,
+// resolves to that value, so callers see what the flag parsed (the contents
+// of a WithStringFromFile flag, every element of a slice flag). Env and
+// config are consulted directly only for keys without a set flag.
+func (ctx *Context) resolve(key string, scoped bool) (string, Source, bool) {
+	key = ctx.canonicalKey(key)
+
+	// First check command-level and inherited persistent flags (only if set
+	// on the command line)
+	sets := ctx.commandFlagSets()
+	for _, set := range sets {
+		if flag := set.lookup(key); flag != nil && flag.set && flag.source == SourceCommandFlag {
+			if v, ok := set.String(key); ok {
 				return v, SourceCommandFlag, true
 			}
 		}
 	}
 
-	// Then check root flags (only if explicitly set)
+	// Then check root flags (only if set on the command line)
 	if ctx.App != nil {
 		rootFlags := ctx.App.Flags()
 		if rootFlags != nil {
-			if flag := rootFlags.lookup(key); flag != nil && flag.set {
+			if flag := rootFlags.lookup(key); flag != nil && flag.set && flag.source == SourceCommandFlag {
 				if v, ok := rootFlags.String(key); ok {
 					return v, SourceAppFlag, true
 				}
@@ -315,22 +454,40 @@ func (ctx *Context) resolveValue(key string) (string, Source, bool) {
 		}
 	}
 
+	// Then check the nearest flag named key, if it was hydrated from env or
+	// config. A flag hydrated from config under another scope than the one
+	// asked for is looked up again below.
+	hydrated := sets
+	if ctx.App != nil && ctx.App.Flags() != nil {
+		hydrated = append(hydrated[:len(hydrated):len(hydrated)], ctx.App.Flags())
+	}
+	for _, set := range hydrated {
+		flag := set.lookup(key)
+		if flag == nil {
+			continue
+		}
+		if flag.set && (flag.source != SourceConfigFile || scoped == ctx.Command.scopedConfig()) {
+			return flag.Value.String(), flag.source, true
+		}
+		break
+	}
+
 	// Then check environment variables
-	// First check if any flag defines an EnvVar for this key
+	// First check if any flag defines EnvVar/EnvVars for this key
 	if ctx.App != nil {
-		// Check command flags for EnvVar
-		if ctx.Command != nil && ctx.Command.Flags != nil {
-			if flag := ctx.Command.Flags.lookup(key); flag != nil && flag.EnvVar != "" {
-				if val, ok := os.LookupEnv(flag.EnvVar); ok {
+		// Check command and persistent flags for EnvVar/EnvVars
+		for _, set := range sets {
+			if flag := set.lookup(key); flag != nil {
+				if val, _, ok := flag.lookupEnv(); ok {
 					return val, SourceEnvVar, true
 				}
 			}
 		}
-		// Check root flags for EnvVar
+		// Check root flags for EnvVar/EnvVars
 		rootFlags := ctx.App.Flags()
 		if rootFlags != nil {
-			if flag := rootFlags.lookup(key); flag != nil && flag.EnvVar != "" {
-				if val, ok := os.LookupEnv(flag.EnvVar); ok {
+			if flag := rootFlags.lookup(key); flag != nil {
+				if val, _, ok := flag.lookupEnv(); ok {
 					return val, SourceEnvVar, true
 				}
 			}
@@ -344,16 +501,16 @@ func (ctx *Context) resolveValue(key string) (string, Source, bool) {
 
 	// Then check config
 	if ctx.App != nil && ctx.App.Config != nil {
-		if v, ok := ctx.App.Config.Get(key); ok {
+		if v, ok := ctx.App.lookupConfig(ctx.Command, key, scoped); ok {
 			return v, SourceConfigFile, true
 		}
 	}
 
 	// Finally check defaults from flags (only if flag exists but wasn't set)
-	// Check command flag default first
-	if ctx.Command != nil && ctx.Command.Flags != nil {
-		if flag := ctx.Command.Flags.lookup(key); flag != nil && !flag.set && flag.Default != "" {
-			return flag.Default, SourceDefault, true
+	// Check command and persistent flag defaults first
+	for _, set := range sets {
+		if flag := set.lookup(key); flag != nil && !flag.set && flag.Default != "" {
+			return parsedValue(flag, flag.Default), SourceDefault, true
 		}
 	}
 	// Then check root flag default
@@ -361,7 +518,7 @@ func (ctx *Context) resolveValue(key string) (string, Source, bool) {
 		rootFlags := ctx.App.Flags()
 		if rootFlags != nil {
 			if flag := rootFlags.lookup(key); flag != nil && !flag.set && flag.Default != "" {
-				return flag.Default, SourceDefault, true
+				return parsedValue(flag, flag.Default), SourceDefault, true
 			}
 		}
 	}
@@ -369,19 +526,36 @@ func (ctx *Context) resolveValue(key string) (string, Source, bool) {
 	return "", 0, false
 }
 
+// canonicalKey maps a flag alias to the flag's canonical name so that env and
+// config lookups use the same key regardless of which name was requested.
+func (ctx *Context) canonicalKey(key string) string {
+	for _, set := range ctx.commandFlagSets() {
+		if flag := set.lookup(key); flag != nil {
+			return flag.Name
+		}
+	}
+	if ctx.App != nil && ctx.App.Root != nil && ctx.App.Root.Flags != nil {
+		if flag := ctx.App.Root.Flags.lookup(key); flag != nil {
+			return flag.Name
+		}
+	}
+	return key
+}
+
 // String retrieves a string configuration value with the given key, looking at
-// command flags, root flags, environment variables, config file, then defaults.
+// command flags, persistent flags, root flags, environment variables, config
+// file, then defaults.
 // This follows the log/slog naming pattern for type-specific getters.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) String(key string) (string, bool) {
 	value, _, found := ctx.resolveValue(key)
 	return value, found
 }
 
 // Bool retrieves a boolean configuration value using the same precedence as
-// String (command flags, root flags, env, config, defaults).
+// String (command flags, persistent flags, root flags, env, config, defaults).
 // This follows the log/slog naming pattern for type-specific getters.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) Bool(key string) (bool, bool) {
 	value, _, found := ctx.resolveValue(key)
 	if !found {
@@ -396,14 +570,14 @@ func (ctx *Context) Bool(key string) (bool, bool) {
 
 // EffectiveString retrieves a string configuration value and returns both the value
 // and its source. This is useful for debugging and understanding where values come from.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) EffectiveString(key string) (string, Source, bool) {
 	return ctx.resolveValue(key)
 }
 
 // EffectiveBool retrieves a boolean configuration value and returns both the value
 // and its source. This is useful for debugging and understanding where values come from.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) EffectiveBool(key string) (bool, Source, bool) {
 	value, source, found := ctx.resolveValue(key)
 	if !found {
@@ -418,7 +592,7 @@ func (ctx *Context) EffectiveBool(key string) (bool, Source, bool) {
 
 // EffectiveInt retrieves an integer configuration value and returns both the value
 // and its source. This is useful for debugging and understanding where values come from.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) EffectiveInt(key string) (int, Source, bool) {
 	value, source, found := ctx.resolveValue(key)
 	if !found {
@@ -433,7 +607,7 @@ func (ctx *Context) EffectiveInt(key string) (int, Source, bool) {
 
 // EffectiveInt64 retrieves an int64 configuration value and returns both the value
 // and its source. This is useful for debugging and understanding where values come from.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) EffectiveInt64(key string) (int64, Source, bool) {
 	value, source, found := ctx.resolveValue(key)
 	if !found {
@@ -446,9 +620,97 @@ func (ctx *Context) EffectiveInt64(key string) (int64, Source, bool) {
 	return parsed, source, true
 }
 
+// Int retrieves an integer configuration value using the same precedence as
+// String (command flags, persistent flags, root flags, env, config, defaults).
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Int(key string) (int, bool) {
+	value, _, found := ctx.EffectiveInt(key)
+	return value, found
+}
+
+// Int64 retrieves an int64 configuration value using the same precedence as
+// String (command flags, persistent flags, root flags, env, config, defaults).
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Int64(key string) (int64, bool) {
+	value, _, found := ctx.EffectiveInt64(key)
+	return value, found
+}
+
+// Float64 retrieves a float64 configuration value using the same precedence as
+// String (command flags, persistent flags, root flags, env, config, defaults).
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Float64(key string) (float64, bool) {
+	value, _, found := ctx.EffectiveFloat64(key)
+	return value, found
+}
+
+// Duration retrieves a time.Duration configuration value (e.g., "30s") using
+// the same precedence as String (command flags, persistent flags, root flags,
+// env, config, defaults).
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Duration(key string) (time.Duration, bool) {
+	value, _, found := ctx.resolveValue(key)
+	if !found {
+		return 0, false
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// Bytes retrieves a byte-size configuration value (e.g., "10MB" or "2GiB")
+// in bytes using the same precedence as String.
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Bytes(key string) (int64, bool) {
+	value, _, found := ctx.resolveValue(key)
+	if !found {
+		return 0, false
+	}
+	parsed, err := parseBytes(value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// Timestamp retrieves a time.Time configuration value using the same
+// precedence as String. Values are parsed with the layouts of the matching
+// TimestampVar flag, or RFC3339 when the key is not a timestamp flag.
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
+func (ctx *Context) Timestamp(key string) (time.Time, bool) {
+	value, _, found := ctx.resolveValue(key)
+	if !found {
+		return time.Time{}, false
+	}
+	parsed, err := parseTimestamp(value, ctx.timestampLayouts(key))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// timestampLayouts returns the layouts of the TimestampVar flag named key,
+// looking in the same flag sets as resolveValue.
+func (ctx *Context) timestampLayouts(key string) []string {
+	sets := ctx.commandFlagSets()
+	if ctx.App != nil && ctx.App.Root != nil && ctx.App.Root.Flags != nil {
+		sets = append(sets, ctx.App.Root.Flags)
+	}
+	for _, fs := range sets {
+		if flag := fs.lookup(key); flag != nil {
+			if value, ok := flag.Value.(*TimestampValue); ok {
+				return value.layouts
+			}
+		}
+	}
+	return nil
+}
+
 // EffectiveFloat64 retrieves a float64 configuration value and returns both the value
 // and its source. This is useful for debugging and understanding where values come from.
-// Precedence: command flags > app flags > env > config > defaults
+// Precedence: command flags > persistent flags > app flags > env > config > defaults
 func (ctx *Context) EffectiveFloat64(key string) (float64, Source, bool) {
 	value, source, found := ctx.resolveValue(key)
 	if !found {
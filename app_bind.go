@@ -0,0 +1,83 @@
+package clix
+
+// BindFlagToConfig ties the flag flagName to the config key configKey. When
+// the flag is not given on the command line, it falls back to configKey
+// instead of a key named after the flag. When it is given, Run stores the
+// value in App.Config under configKey (normalized by any registered schema)
+// so handlers see it there too; call SaveBoundConfig to persist it.
+//
+// The binding applies to the root flag or to a flag of the running command
+// with that name.
+//
+// Example:
+//
+//	app.BindFlagToConfig("project", "core.project")
+//	cmd.Run = func(ctx *clix.Context) error {
+//		if rememberFlag {
+//			return ctx.App.SaveBoundConfig()
+//		}
+//		return nil
+//	}
+func (a *App) BindFlagToConfig(flagName, configKey string) {
+	if a.configBindings == nil {
+		a.configBindings = make(map[string]string)
+	}
+	a.configBindings[flagName] = configKey
+}
+
+// SaveBoundConfig writes the values that bound flags received on the command
+// line during the current Run to the config file. Only those keys are
+// updated; other values already in the file are preserved, and values from
+// env vars or other sources are not written.
+func (a *App) SaveBoundConfig() error {
+	if len(a.boundValues) == 0 {
+		return nil
+	}
+	path, err := a.ConfigFile()
+	if err != nil {
+		return err
+	}
+	onDisk := NewConfigManager(a.Name)
+	if err := onDisk.Load(path); err != nil {
+		return err
+	}
+	for key, value := range a.boundValues {
+		onDisk.Set(key, value)
+	}
+	return onDisk.Save(path)
+}
+
+// configKey returns the config key a flag reads from: its binding, if any,
+// or the flag name.
+func (a *App) configKey(flagName string) string {
+	if key, ok := a.configBindings[flagName]; ok {
+		return key
+	}
+	return flagName
+}
+
+// storeBoundFlags copies bound flags set on the command line into App.Config.
+func (a *App) storeBoundFlags(sets ...*FlagSet) error {
+	if len(a.configBindings) == 0 || a.Config == nil {
+		return nil
+	}
+	for _, fs := range sets {
+		if fs == nil {
+			continue
+		}
+		for name, key := range a.configBindings {
+			flag := fs.lookup(name)
+			if flag == nil || !flag.set || flag.source != SourceCommandFlag {
+				continue
+			}
+			if err := a.Config.SetNormalized(key, flag.Value.String()); err != nil {
+				return invalidValueError(flag.Name, "--"+flag.Name, err)
+			}
+			if a.boundValues == nil {
+				a.boundValues = make(map[string]string)
+			}
+			a.boundValues[key], _ = a.Config.Get(key)
+		}
+	}
+	return nil
+}
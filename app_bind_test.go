@@ -0,0 +1,106 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBindFlagToConfig(t *testing.T) {
+	t.Run("set flag populates the config", func(t *testing.T) {
+		app := newTestApp(t, "bindtest")
+		cmd := NewCommand("deploy", WithCommandRun(func(*Context) error { return nil }))
+		cmd.Flags.StringVar(WithFlagName("project"))
+		app.Root.AddCommand(cmd)
+		app.BindFlagToConfig("project", "core.project")
+
+		if err := app.Run(context.Background(), []string{"deploy", "--project", "alpha"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if got, _ := app.Config.Get("core.project"); got != "alpha" {
+			t.Errorf("expected core.project=alpha, got %q", got)
+		}
+	})
+
+	t.Run("unset flag reads from the bound key", func(t *testing.T) {
+		var resolved string
+		var project string
+		app := newTestApp(t, "bindtest")
+		cmd := NewCommand("deploy", WithCommandRun(func(ctx *Context) error {
+			resolved, _ = ctx.String("project")
+			return nil
+		}))
+		cmd.Flags.StringVar(WithFlagName("project"), WithStringValue(&project))
+		app.Root.AddCommand(cmd)
+		app.BindFlagToConfig("project", "core.project")
+		app.Config.Set("core.project", "beta")
+
+		if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if project != "beta" {
+			t.Errorf("expected bound variable to hydrate from config, got %q", project)
+		}
+		if resolved != "beta" {
+			t.Errorf("expected ctx.String to resolve from config, got %q", resolved)
+		}
+	})
+
+	t.Run("values are validated by schema", func(t *testing.T) {
+		app := newTestApp(t, "bindtest")
+		cmd := NewCommand("deploy", WithCommandRun(func(*Context) error { return nil }))
+		cmd.Flags.StringVar(WithFlagName("project"))
+		app.Root.AddCommand(cmd)
+		app.BindFlagToConfig("project", "core.project")
+		app.Config.RegisterSchema(ConfigSchema{Key: "core.project", Validate: func(v string) error {
+			if strings.ToLower(v) != v {
+				return errors.New("must be lower case")
+			}
+			return nil
+		}})
+
+		if err := app.Run(context.Background(), []string{"deploy", "--project", "Alpha"}); err == nil {
+			t.Fatal("expected schema validation to reject the value")
+		}
+	})
+
+	t.Run("SaveBoundConfig persists only bound values", func(t *testing.T) {
+		app := newTestApp(t, "bindtest")
+		cmd := NewCommand("deploy", WithCommandRun(func(ctx *Context) error {
+			ctx.App.Config.Set("scratch", "not persisted")
+			return ctx.App.SaveBoundConfig()
+		}))
+		cmd.Flags.StringVar(WithFlagName("project"))
+		app.Root.AddCommand(cmd)
+		app.BindFlagToConfig("project", "core.project")
+
+		path := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "bindtest", "config.yaml")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("region: us-east-1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.Run(context.Background(), []string{"deploy", "--project", "gamma"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+
+		saved := NewConfigManager("bindtest")
+		if err := saved.Load(path); err != nil {
+			t.Fatalf("reload failed: %v", err)
+		}
+		if got, _ := saved.Get("core.project"); got != "gamma" {
+			t.Errorf("expected core.project=gamma on disk, got %q", got)
+		}
+		if got, _ := saved.Get("region"); got != "us-east-1" {
+			t.Errorf("expected existing keys to be kept, got region=%q", got)
+		}
+		if _, ok := saved.Get("scratch"); ok {
+			t.Error("expected unrelated values not to be written")
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package clix
+
+// Clone returns a copy of the application that can be run independently of
+// the original. This is useful for test harnesses and for REPL or script modes
+// that invoke commands repeatedly within one process.
+//
+// What is copied:
+//   - The command tree, including every command's flag set. Cloned flags have
+//     their explicit-set state cleared. Their values are restored to defaults
+//     when the clone's Run starts, not by Clone, so cloning leaves the
+//     original's bound variables as they are.
+//   - The configuration manager's values, their origins and registered schemas.
+//   - The list of registered extensions (and whether they were applied) and
+//     the middleware registered with App.Use and Command.Use, and the
+//     rewriters registered with App.RewriteArgs.
+//   - The output formats registered with App.RegisterFormat and the flag
+//     bindings from App.BindFlagToConfig.
+//
+// What is shared with the original:
+//   - Variables bound to flags (e.g. StringVarOptions.Value). Both apps write
+//     to the same variables, so commands that bind the same variables must not
+//     run concurrently.
+//   - Out, Err, In, Prompter, Styles and the extension values themselves.
+//
+// Example:
+//
+//	for _, args := range scripts {
+//		if err := app.Clone().Run(ctx, args); err != nil {
+//			return err
+//		}
+//	}
+func (a *App) Clone() *App {
+	clone := &App{
+		Name:          a.Name,
+		Version:       a.Version,
+		Description:   a.Description,
+		Prompter:      a.Prompter,
+		Interactive:   a.Interactive,
+		ArgsFiles:     a.ArgsFiles,
+		Out:           a.Out,
+		Err:           a.Err,
+		In:            a.In,
+		EnvPrefix:     a.EnvPrefix,
+		DefaultTheme:  a.DefaultTheme,
+		Styles:        a.Styles,
+		HelpFunc:      a.HelpFunc,
+		configLoaded:  a.configLoaded,
+		configLoadErr: a.configLoadErr,
+		configPath:    a.configPath,
+		rootPrepared:  a.rootPrepared,
+		colorProfile:  a.colorProfile,
+		pluginPrefix:  a.pluginPrefix,
+		timeoutFlag:   a.timeoutFlag,
+		middleware:    append([]Middleware(nil), a.middleware...),
+		rewriters:     append([]ArgsRewriter(nil), a.rewriters...),
+		extensions:    append([]Extension(nil), a.extensions...),
+	}
+
+	clone.loadedFlagPath = a.loadedFlagPath
+
+	if a.Root != nil {
+		clone.Root = a.Root.clone(nil)
+	}
+	if a.Config != nil {
+		clone.Config = a.Config.clone()
+	}
+	for name, fn := range a.formats {
+		clone.RegisterFormat(name, fn)
+	}
+	for flagName, key := range a.configBindings {
+		clone.BindFlagToConfig(flagName, key)
+	}
+
+	// Extensions mutate the command tree when applied. If they already ran,
+	// the clone inherits their commands and must not apply them again.
+	if a.extensionsApplied {
+		clone.extensionsOnce.Do(func() {})
+		clone.extensionsApplied = true
+	}
+
+	return clone
+}
+
+// clone deep-copies the command and its descendants, attaching the copy to parent.
+func (c *Command) clone(parent *Command) *Command {
+	copied := *c
+	copied.parent = parent
+	copied.middleware = append([]Middleware(nil), c.middleware...)
+	if c.Flags != nil {
+		copied.Flags = c.Flags.clone()
+	}
+	if c.PersistentFlags == c.Flags {
+		copied.PersistentFlags = copied.Flags
+	} else if c.PersistentFlags != nil {
+		copied.PersistentFlags = c.PersistentFlags.clone()
+	}
+	if len(c.Children) > 0 {
+		copied.Children = make([]*Command, 0, len(c.Children))
+		for _, child := range c.Children {
+			if child == nil {
+				continue
+			}
+			copied.Children = append(copied.Children, child.clone(&copied))
+		}
+	}
+	return &copied
+}
+
+// clone copies the flag set. Each flag is copied with its explicit-set state
+// cleared; flag values keep pointing at the same bound variables, which are
+// left untouched until the clone's Run resets them.
+func (fs *FlagSet) clone() *FlagSet {
+	copied := &FlagSet{
+		name:         fs.name,
+		index:        make(map[string]*Flag, len(fs.index)),
+		strict:       fs.strict,
+		interspersed: fs.interspersed,
+	}
+	mapping := make(map[*Flag]*Flag, len(fs.flags))
+	for _, flag := range fs.flags {
+		f := *flag
+		f.clearState()
+		copied.flags = append(copied.flags, &f)
+		mapping[flag] = &f
+	}
+	for key, flag := range fs.index {
+		copied.index[key] = mapping[flag]
+	}
+	return copied
+}
+
+// clone copies the stored values, their origins and registered schemas.
+func (m *ConfigManager) clone() *ConfigManager {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	copied := &ConfigManager{
+		values:  make(map[string]string, len(m.values)),
+		schemas: make(map[string]ConfigSchema, len(m.schemas)),
+	}
+	for k, v := range m.values {
+		copied.values[k] = v
+	}
+	for k, v := range m.schemas {
+		copied.schemas[k] = v
+	}
+	if len(m.origins) > 0 {
+		copied.origins = make(map[string]string, len(m.origins))
+		for k, v := range m.origins {
+			copied.origins[k] = v
+		}
+	}
+	return copied
+}
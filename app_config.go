@@ -3,12 +3,16 @@ package clix
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
+// configFlagName is the root flag registered by WithAppConfigFlag.
+const configFlagName = "config"
+
 // ConfigDir returns the absolute path to the application's configuration
 // directory. The directory will be created if it does not already exist.
-// On Unix systems, respects XDG_CONFIG_HOME if set, otherwise uses ~/.config.
-// On Windows, uses %AppData% (or %LocalAppData% if preferred).
+// XDG_CONFIG_HOME is respected when set, on every platform. Otherwise Windows
+// uses %AppData% and other systems use ~/.config.
 func (a *App) ConfigDir() (string, error) {
 	// Check for XDG_CONFIG_HOME on Unix systems
 	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
@@ -19,6 +23,14 @@ func (a *App) ConfigDir() (string, error) {
 		return dir, nil
 	}
 
+	if appData := os.Getenv("APPDATA"); appData != "" && runtime.GOOS == "windows" {
+		dir := filepath.Join(appData, a.Name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+		return dir, nil
+	}
+
 	// Fall back to standard location
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -31,8 +43,16 @@ func (a *App) ConfigDir() (string, error) {
 	return dir, nil
 }
 
-// ConfigFile returns the path to the main configuration file.
+// ConfigFile returns the path to the main configuration file: the --config
+// flag when given (see WithAppConfigFlag), else the path set with
+// SetConfigPath, else config.yaml in ConfigDir.
 func (a *App) ConfigFile() (string, error) {
+	if a.configFlagPath != "" {
+		return a.configFlagPath, nil
+	}
+	if a.configPath != "" {
+		return a.configPath, nil
+	}
 	dir, err := a.ConfigDir()
 	if err != nil {
 		return "", err
@@ -40,6 +60,42 @@ func (a *App) ConfigFile() (string, error) {
 	return filepath.Join(dir, "config.yaml"), nil
 }
 
+// SetConfigPath makes the app load and save its configuration at path instead
+// of the default location. The file is read on the next Run. A --config flag
+// on the command line still takes precedence.
+func (a *App) SetConfigPath(path string) {
+	a.configPath = path
+	a.configLoaded = false
+	a.configLoadErr = nil
+}
+
+// WithAppConfigFlag registers a --config flag on the root command. When it
+// is given, that run loads configuration from, and SaveConfig writes to, the
+// named file:
+//
+//	app := clix.NewApp("myapp", clix.WithAppConfigFlag())
+//	// myapp --config ./staging.yaml deploy
+func WithAppConfigFlag() AppOption {
+	return appConfigFlagOption{}
+}
+
+type appConfigFlagOption struct{}
+
+func (appConfigFlagOption) ApplyApp(app *App) {
+	flags := app.Flags()
+	if flags.lookup(configFlagName) != nil {
+		return
+	}
+	var path string
+	flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{
+			Name:  configFlagName,
+			Usage: "Path to the config file",
+		},
+		Value: &path,
+	})
+}
+
 // SaveConfig persists the configuration manager's values to disk.
 func (a *App) SaveConfig() error {
 	path, err := a.ConfigFile()
@@ -0,0 +1,88 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFileFollowsXDGConfigHome(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	app := NewApp("tool")
+	path, err := app.ConfigFile()
+	if err != nil {
+		t.Fatalf("ConfigFile: %v", err)
+	}
+	if want := filepath.Join(xdg, "tool", "config.yaml"); path != want {
+		t.Errorf("ConfigFile = %q, want %q", path, want)
+	}
+
+	app.SetConfigPath("/etc/tool.yaml")
+	if path, _ := app.ConfigFile(); path != "/etc/tool.yaml" {
+		t.Errorf("ConfigFile after SetConfigPath = %q", path)
+	}
+}
+
+func TestConfigFlagOverridesDefaultPath(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	os.MkdirAll(filepath.Join(xdg, "tool"), 0o755)
+	os.WriteFile(filepath.Join(xdg, "tool", "config.yaml"), []byte("region: default\n"), 0o644)
+	custom := filepath.Join(t.TempDir(), "x.yaml")
+	os.WriteFile(custom, []byte("region: custom\n"), 0o644)
+
+	app := NewApp("tool", WithAppConfigFlag())
+	app.Out = &bytes.Buffer{}
+	var region string
+	app.Flags().StringVar(WithFlagName("region"), WithStringValue(&region))
+	cmd := NewCommand("show")
+	cmd.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"--config", custom, "show"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if region != "custom" {
+		t.Errorf("region with --config = %q, want custom", region)
+	}
+	if path, _ := app.ConfigFile(); path != custom {
+		t.Errorf("ConfigFile during --config run = %q, want %q", path, custom)
+	}
+
+	// The next run without --config goes back to the default file.
+	if err := app.Run(context.Background(), []string{"show"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if region != "default" {
+		t.Errorf("region without --config = %q, want default", region)
+	}
+}
+
+func TestSaveConfigWritesToConfigFlagPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	custom := filepath.Join(t.TempDir(), "x.yaml")
+
+	app := NewApp("tool", WithAppConfigFlag())
+	app.Out = &bytes.Buffer{}
+	cmd := NewCommand("login")
+	cmd.Run = func(ctx *Context) error {
+		ctx.App.Config.Set("token", "abc")
+		return ctx.App.SaveConfig()
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"--config", custom, "login"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	loaded := NewConfigManager("tool")
+	if err := loaded.Load(custom); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := loaded.Get("token"); v != "abc" {
+		t.Errorf("token in %s = %q, want abc", custom, v)
+	}
+}
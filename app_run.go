@@ -27,103 +27,162 @@ func (a *App) Run(ctx context.Context, args []string) error {
 		args = os.Args[1:]
 	}
 
-	if err := a.ensureConfigLoaded(ctx); err != nil {
-		return err
+	// Shell completion scripts call the hidden __complete command with a
+	// partial command line, which must not go through normal parsing.
+	if len(args) > 0 && args[0] == completeCommandName {
+		a.resetFlags(a.Root)
+		return a.runComplete(ctx, args[1:])
+	}
+
+	if a.ArgsFiles {
+		var err error
+		if args, err = a.ExpandArgsFiles(args); err != nil {
+			return err
+		}
 	}
 
+	// Clear flag state left over from a previous Run of the same App so
+	// precedence resolution only sees values from this invocation.
+	a.resetFlags(a.Root)
+	a.boundValues = nil
+
 	// Use Flags() to get root command's flags (symmetric with cmd.Flags)
 	flags := a.Flags()
-	// Apply config/env/defaults to root flags before parsing
-	// This sets defaults, but parsing will override if flags are provided
-	a.applyConfigToFlags(a.Root.Flags, true)
-	remaining, err := flags.Parse(args)
+	a.runCommand = a.Root
+	// Only the root flags in front of the command name are parsed here, before
+	// config is loaded so --config can pick the file. Root flags after the
+	// name are parsed together with the command's flags below, so a command
+	// that stops at its first positional (see FlagSet.SetInterspersed) and
+	// external plugins receive their arguments untouched.
+	remaining, err := flags.parseLeading(args)
 	if err != nil {
+		return usageError(a.Root, err)
+	}
+
+	if err := a.loadConfig(ctx, flags); err != nil {
+		return err
+	}
+	if remaining, err = a.rewriteArgs(remaining); err != nil {
 		return err
 	}
+	cmd, remaining, rest, resolveErr := a.resolveCommandLine(remaining)
 
 	// Check if global --version flag was set
 	if version, _ := flags.Bool("version"); version {
-		// Show version info - same format as "version" command but simpler (no commit/date)
-		// The version extension sets app.Version, so if it's enabled, we show it
-		if a.Version != "" {
-			fmt.Fprintf(a.Out, "%s version %s\n", a.Name, a.Version)
-		} else {
-			fmt.Fprintf(a.Out, "%s\n", a.Name)
+		if err := a.applyConfigToFlags(flags); err != nil {
+			return err
 		}
-		return nil
+		return a.printVersion()
 	}
 
-	// Check if global --help flag was set (when --help appears before any command)
+	// Check if global --help flag was set (when --help appears before the
+	// command name); show help for the command named after it, if any
 	if help, _ := flags.Bool("help"); help {
-		// If there are remaining args, they might be a command - match it first
-		// so we show help for that command instead of root
-		if len(remaining) > 0 {
-			if cmd, _ := a.matchCommand(remaining); cmd != nil {
-				return a.printCommandHelp(cmd)
-			}
+		if cmd != nil {
+			return a.printCommandHelp(ctx, cmd)
 		}
-		return a.printCommandHelp(a.Root)
+		return a.printCommandHelp(ctx, a.Root)
 	}
 
-	cmd, rest := a.matchCommand(remaining)
+	if resolveErr != nil {
+		return resolveErr
+	}
 	if cmd == nil {
 		if len(remaining) == 0 {
-			return a.printCommandHelp(a.Root)
+			return a.printCommandHelp(ctx, a.Root)
 		}
 		// Unknown command - show help for parent or error
 		// Try to find the parent command to show its help
 		if len(remaining) > 1 {
 			// Try to match parent command
 			if parentCmd, _ := a.matchCommand(remaining[:len(remaining)-1]); parentCmd != nil {
-				return a.printCommandHelp(parentCmd)
+				return a.printCommandHelp(ctx, parentCmd)
 			}
 		}
-		return fmt.Errorf("unknown command: %s", strings.Join(remaining, " "))
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, strings.Join(remaining, " "))
+	}
+
+	// Unknown top-level names may be external plugins (see EnableExternalPlugins)
+	if plugin, ok := a.lookupPlugin(cmd, rest); ok {
+		return a.runPlugin(ctx, plugin, rest[1:])
 	}
 
 	// Check if we tried to match a child but it doesn't exist
 	// (i.e., we have remaining args that look like a command name but didn't match)
 	// Only show error if the command has no Run handler (it's a pure group)
-	if len(rest) > 0 && len(cmd.Children) > 0 && cmd.Run == nil {
-		// Check if the first remaining arg looks like it could be a child command
-		// (not a flag and not already matched)
-		firstArg := rest[0]
-		if !strings.HasPrefix(firstArg, "-") {
-			// This looks like a command name but didn't match - show error
-			parentPath := cmd.Path()
-			return fmt.Errorf("unknown command: %s %s", parentPath, firstArg)
-		}
+	if err := unknownSubcommand(cmd, rest); err != nil {
+		return err
 	}
 	// If the command has a Run handler, we'll let it handle the args (even if they don't match a child)
 
-	// Ensure defaults and env/config values are applied prior to parsing.
-	// This sets defaults, but parsing will override if flags are provided
-	// Use reset=false to avoid resetting flags that were already set on root
-	a.applyConfigToFlags(cmd.Flags, false)
-
 	// Parse flags first - flags consume arguments starting with -
 	// This handles: --flag=value, --flag value, -f=value, -f value
-	resultArgs, err := cmd.Flags.Parse(rest)
+	a.runCommand = cmd
+	cmdFlags := cmd.parseFlags()
+	resultArgs, err := cmdFlags.inherit(flags).Parse(rest)
 	if err != nil {
+		return usageError(cmd, err)
+	}
+
+	// Root flags may also follow the command name, so --config is read again
+	// and the root flags are filled only now.
+	if err := a.loadConfig(ctx, flags); err != nil {
 		return err
 	}
+	// Fill root flags not given on the command line from env/config/defaults
+	if err := a.applyConfigToFlags(flags); err != nil {
+		return err
+	}
+	if version, _ := flags.Bool("version"); version {
+		return a.printVersion()
+	}
+
+	if err := cmd.checkArgCount(len(resultArgs)); err != nil {
+		return usageError(cmd, err)
+	}
 
 	// Map leftover positional args to flags marked Positional: true
 	if len(resultArgs) > 0 {
 		excess, err := cmd.Flags.MapPositionals(resultArgs)
 		if err != nil {
-			return err
+			return usageError(cmd, err)
 		}
 		if len(excess) > 0 {
-			return fmt.Errorf("unexpected arguments: %s", strings.Join(excess, " "))
+			return usageError(cmd, fmt.Errorf("unexpected arguments: %s", strings.Join(excess, " ")))
 		}
 	}
 
+	// Fill command flags not given on the command line from env/config/defaults
+	if err := a.applyConfigToFlags(cmdFlags); err != nil {
+		return err
+	}
+	// Copy bound flags given on the command line into the config
+	if err := a.storeBoundFlags(flags, cmdFlags); err != nil {
+		return err
+	}
+
 	// Check for --help/-h flag at command level (automatic for all commands)
 	// Help flags are automatically added to every command in NewCommand/prepare
 	// This takes precedence over everything else - no need to implement per command
 	if help, _ := cmd.Flags.Bool("help"); help {
-		return a.printCommandHelp(cmd)
+		return a.printCommandHelp(ctx, cmd)
+	}
+
+	// Warn about deprecated flags used on the command line
+	if err := a.warnDeprecatedFlags(flags); err != nil {
+		return err
+	}
+	if cmdFlags != flags {
+		if err := a.warnDeprecatedFlags(cmdFlags); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Deprecated != "" {
+		fmt.Fprintf(a.Err, "Command %q is deprecated: %s\n", cmd.Name, cmd.Deprecated)
+		if cmd.DeprecatedNoRun {
+			return nil
+		}
 	}
 
 	// Count user-defined children (groups or commands, excluding default commands like help, config, autocomplete)
@@ -133,22 +192,22 @@ func (a *App) Run(ctx context.Context, args []string) error {
 	// - If it has a Run handler, execute it (command with children can have default behavior)
 	// - If it has no Run handler, show help (group behavior)
 	if userChildren > 0 && cmd.Run == nil {
-		return a.printCommandHelp(cmd)
+		return a.printCommandHelp(ctx, cmd)
 	}
 
 	// Three-way mode detection for required flags:
 	// 1. No CLI flags passed + required missing → interactive prompting
 	// 2. All required satisfied (from any source) → run
 	// 3. Some CLI flags passed + required missing → error
-	missing := cmd.Flags.MissingRequired()
+	missing := cmdFlags.MissingRequired()
 	if len(missing) > 0 {
-		if cmd.Flags.AnyCLISet() {
+		if cmdFlags.AnyCLISet() {
 			// Mode 3: some flags provided, required missing → error
 			names := make([]string, len(missing))
 			for i, f := range missing {
 				names[i] = "--" + f.Name
 			}
-			return fmt.Errorf("missing required flags: %s", strings.Join(names, ", "))
+			return usageError(cmd, fmt.Errorf("missing required flags: %s", strings.Join(names, ", ")))
 		}
 		// Mode 1: no CLI flags → interactive prompting
 		if err := a.promptForRequiredFlags(ctx, cmd, missing); err != nil {
@@ -161,6 +220,53 @@ func (a *App) Run(ctx context.Context, args []string) error {
 		Context: ctx,
 		App:     a,
 		Command: cmd,
+		invoked: invokedNames(cmd, remaining, rest),
+		args:    resultArgs,
+	}
+
+	// Cross-field validation needs every value in place, so it runs last.
+	if err := validateFlagsCtx(runCtx, flags); err != nil {
+		return usageError(cmd, err)
+	}
+	if cmdFlags != flags {
+		if err := validateFlagsCtx(runCtx, cmdFlags); err != nil {
+			return usageError(cmd, err)
+		}
+	}
+
+	// Redirect command output to the --output file, closing it once the
+	// command (and its hooks) have finished.
+	if err := a.openOutput(); err != nil {
+		return err
+	}
+	cancel, timed := a.applyTimeout(runCtx)
+	err = a.execute(runCtx, cmd)
+	if timed {
+		err = timeoutError(runCtx, cmd, err)
+	}
+	cancel()
+	if closeErr := a.closeOutput(); err == nil {
+		err = closeErr
+	}
+	// The reader of our output went away (e.g. "| head"); that is not a
+	// failure of the command.
+	if errors.Is(err, ErrBrokenPipe) {
+		err = nil
+	}
+	return a.afterRun(runCtx, err)
+}
+
+// execute runs the command's hooks and handler in order (see
+// Command.PersistentPreRun). The first error stops the chain.
+func (a *App) execute(runCtx *Context, cmd *Command) error {
+	lineage := cmd.lineage()
+
+	for _, c := range lineage {
+		if c.PersistentPreRun != nil {
+			if err := c.PersistentPreRun(runCtx); err != nil {
+				return err
+			}
+		}
 	}
 
 	if cmd.PreRun != nil {
@@ -173,7 +279,7 @@ func (a *App) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("command %s has no run handler (did you intend this to be a group?)", cmd.Path())
 	}
 
-	if err := cmd.Run(runCtx); err != nil {
+	if err := a.wrapHandler(cmd, cmd.Run)(runCtx); err != nil {
 		return err
 	}
 
@@ -183,18 +289,71 @@ func (a *App) Run(ctx context.Context, args []string) error {
 		}
 	}
 
+	for i := len(lineage) - 1; i >= 0; i-- {
+		if c := lineage[i]; c.PersistentPostRun != nil {
+			if err := c.PersistentPostRun(runCtx); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
 // matchCommand matches commands starting from the root, handling the case where
 // the root command name appears in the arguments.
 func (a *App) matchCommand(args []string) (*Command, []string) {
+	cmd, rest, _ := a.resolveCommand(args)
+	return cmd, rest
+}
+
+// resolveCommand is like matchCommand but also reports ambiguous aliases.
+func (a *App) resolveCommand(args []string) (*Command, []string, error) {
 	// If the first argument matches the root command name, skip it
 	// (this happens when the binary is invoked as "app-name root-command child")
 	if len(args) > 0 && strings.EqualFold(args[0], a.Root.Name) {
-		return a.Root.match(args[1:])
+		return a.Root.resolve(args[1:])
+	}
+	return a.Root.resolve(args)
+}
+
+// resolveCommandLine resolves the command named in args like resolveCommand,
+// and parses the root flags found between a group and its subcommand, as in
+// "app remote --verbose add". It returns the command, args without those
+// flags, and the arguments after the command's name.
+func (a *App) resolveCommandLine(args []string) (*Command, []string, []string, error) {
+	cmd, rest, err := a.resolveCommand(args)
+	names := append([]string(nil), args[:len(args)-len(rest)]...)
+	for err == nil && len(cmd.Children) > 0 && len(rest) > 0 && strings.HasPrefix(rest[0], "-") {
+		leftover, parseErr := a.Flags().parseLeading(rest)
+		if parseErr != nil {
+			return cmd, append(names, rest...), rest, usageError(cmd, parseErr)
+		}
+		var next *Command
+		next, rest, err = cmd.resolve(leftover)
+		names = append(names, leftover[:len(leftover)-len(rest)]...)
+		if next == cmd {
+			break
+		}
+		cmd = next
+	}
+	return cmd, append(names, rest...), rest, err
+}
+
+// resetFlags resets the flag sets of cmd and all of its descendants.
+func (a *App) resetFlags(cmd *Command) {
+	if cmd == nil {
+		return
+	}
+	if cmd.Flags != nil {
+		cmd.Flags.Reset()
+	}
+	if cmd.PersistentFlags != nil {
+		cmd.PersistentFlags.Reset()
+	}
+	for _, child := range cmd.Children {
+		a.resetFlags(child)
 	}
-	return a.Root.match(args)
 }
 
 func (a *App) ensureRootPrepared() {
@@ -202,14 +361,20 @@ func (a *App) ensureRootPrepared() {
 		return
 	}
 	a.Root.prepare(nil)
+	a.Root.shareRootFlags()
 	a.rootPrepared = true
 }
 
 func (a *App) ensureConfigLoaded(ctx context.Context) error {
-	if a.configLoaded {
+	if a.configLoaded && a.configFlagPath == a.loadedFlagPath {
 		return a.configLoadErr
 	}
+	if a.configLoaded {
+		// --config names a different file than the last Run loaded.
+		a.Config.Reset()
+	}
 	a.configLoaded = true
+	a.loadedFlagPath = a.configFlagPath
 
 	path, err := a.ConfigFile()
 	if err != nil {
@@ -221,83 +386,160 @@ func (a *App) ensureConfigLoaded(ctx context.Context) error {
 }
 
 // applyConfigToFlags applies env vars, config, and defaults to flags.
-// This should be called BEFORE parsing. After parsing, flags that were set
-// will have flag.set = true and won't be overridden.
-// If reset is false, flags that are already set (flag.set == true) will be skipped.
-func (a *App) applyConfigToFlags(flags *FlagSet, reset bool) {
+// This should be called AFTER parsing: flags that were set on the command line
+// (or by an earlier call) are skipped, which keeps flags > env > config >
+// defaults precedence and lets accumulating values see only one source.
+func (a *App) applyConfigToFlags(flags *FlagSet) error {
 	if flags == nil {
-		return
+		return nil
 	}
 
 	for _, flag := range flags.flags {
-		// If flag was already set (e.g., by parsing) and we're not resetting, skip it
-		// This ensures flags > env > config > defaults precedence
-		if !reset && flag.set {
+		if flag.set {
 			continue
 		}
 
-		// Reset flag state before applying precedence
-		flag.set = false
-
 		// Try each source in order of precedence
-		if a.trySetFromEnv(flag) {
+		found, err := a.trySetFromEnv(flag)
+		if err != nil {
+			return err
+		}
+		if found {
 			continue
 		}
-		if a.trySetFromConfig(flag) {
+		found, err = a.trySetFromConfig(flag)
+		if err != nil {
+			return err
+		}
+		if found {
 			continue
 		}
 		a.trySetFromDefault(flag)
 	}
+	return nil
 }
 
 // trySetFromEnv attempts to set a flag value from environment variables.
+// EnvVar and then EnvVars are consulted in order before the APP_KEY pattern;
+// the first variable that is set wins.
 // Returns true if a value was found and set.
-func (a *App) trySetFromEnv(flag *Flag) bool {
-	// Try explicit EnvVar first
-	if flag.EnvVar != "" {
-		if val, ok := os.LookupEnv(flag.EnvVar); ok {
-			flag.Value.Set(val)
-			flag.set = true
-			return true
-		}
+func (a *App) trySetFromEnv(flag *Flag) (bool, error) {
+	if val, name, ok := flag.lookupEnv(); ok {
+		return true, hydrateFlag(flag, val, SourceEnvVar, "environment variable "+name)
 	}
 
 	// Try default pattern (APP_KEY)
 	upper := fmt.Sprintf("%s_%s", a.EnvPrefix, strings.ToUpper(strings.ReplaceAll(flag.Name, "-", "_")))
 	if val, ok := os.LookupEnv(upper); ok {
-		flag.Value.Set(val)
-		flag.set = true
-		return true
+		return true, hydrateFlag(flag, val, SourceEnvVar, "environment variable "+upper)
 	}
 
-	return false
+	return false, nil
 }
 
 // trySetFromConfig attempts to set a flag value from configuration.
 // Returns true if a value was found and set.
-func (a *App) trySetFromConfig(flag *Flag) bool {
+func (a *App) trySetFromConfig(flag *Flag) (bool, error) {
 	if a.Config == nil {
-		return false
+		return false, nil
 	}
 
-	if val, ok := a.Config.Values()[flag.Name]; ok {
-		flag.Value.Set(val)
-		flag.set = true
-		return true
+	if val, ok := a.lookupConfig(a.runCommand, flag.Name, a.runCommand.scopedConfig()); ok {
+		return true, hydrateFlag(flag, val, SourceConfigFile, "config file")
 	}
 
-	return false
+	return false, nil
 }
 
 // trySetFromDefault sets a flag value from its default if available.
+// Defaults are not validated; they are trusted as part of the flag definition.
 func (a *App) trySetFromDefault(flag *Flag) {
 	if flag.Default != "" {
 		flag.Value.Set(flag.Default)
 	}
 }
 
-// promptForRequiredFlags interactively prompts for each missing required flag.
+// hydrateFlag sets a flag from a non-CLI source such as env or config and
+// records that source. origin names the source in error messages. Slice flags
+// take a comma-separated list, the form config arrays are flattened into.
+func hydrateFlag(flag *Flag, value string, source Source, origin string) error {
+	values := []string{value}
+	if _, ok := flag.Value.(*StringSliceValue); ok {
+		values = strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+	}
+	for _, v := range values {
+		if err := flag.Value.Set(v); err != nil {
+			return invalidValueError(flag.Name, "--"+flag.Name+" from "+origin, err)
+		}
+	}
+	if flag.Validate != nil {
+		if err := flag.Validate(parsedValue(flag, value)); err != nil {
+			return invalidValueError(flag.Name, "--"+flag.Name+" from "+origin, err)
+		}
+	}
+	flag.set = true
+	flag.source = source
+	return nil
+}
+
+// validateFlagsCtx runs the ValidateCtx hook of every flag that has a value.
+// Flags left at their default are skipped, matching Validate.
+func validateFlagsCtx(ctx *Context, flags *FlagSet) error {
+	for _, flag := range flags.flags {
+		if flag.ValidateCtx == nil || !flag.set {
+			continue
+		}
+		if err := flag.ValidateCtx(ctx, flag.Value.String()); err != nil {
+			if flag.Positional {
+				return invalidValueError(flag.Name, "positional argument "+flag.Name, err)
+			}
+			return invalidValueError(flag.Name, "--"+flag.Name, err)
+		}
+	}
+	return nil
+}
+
+// warnDeprecatedFlags prints a warning for each deprecated flag that was set on
+// the command line and forwards its value to the replacement flag, if any.
+// Flags hydrated from env, config or defaults are not reported.
+func (a *App) warnDeprecatedFlags(flags *FlagSet) error {
+	for _, flag := range flags.flags {
+		if !flag.cliSet || (flag.Deprecated == "" && flag.DeprecatedReplacement == "") {
+			continue
+		}
+
+		message := flag.Deprecated
+		if message == "" {
+			message = fmt.Sprintf("use --%s instead", flag.DeprecatedReplacement)
+		}
+		fmt.Fprintf(a.Err, "flag --%s is deprecated: %s\n", flag.Name, message)
+
+		if flag.DeprecatedReplacement == "" {
+			continue
+		}
+		replacement := flags.lookup(flag.DeprecatedReplacement)
+		if replacement == nil || replacement.cliSet {
+			continue
+		}
+		if err := replacement.Value.Set(flag.Value.String()); err != nil {
+			return invalidValueError(replacement.Name, "--"+replacement.Name, err)
+		}
+		replacement.markCLISet()
+	}
+	return nil
+}
+
+// promptForRequiredFlags interactively prompts for each missing required flag,
+// using the flag's Prompt label, Default and Validate. When the app may not
+// prompt (see IsInteractive), it returns an ErrNonInteractive error instead
+// of waiting for input.
 func (a *App) promptForRequiredFlags(ctx context.Context, cmd *Command, missing []*Flag) error {
+	if !a.IsInteractive() {
+		return fmt.Errorf("%w: missing value for %q", ErrNonInteractive, missing[0].Name)
+	}
 	for _, flag := range missing {
 		label := flag.Prompt
 		if label == "" {
@@ -308,8 +550,10 @@ func (a *App) promptForRequiredFlags(ctx context.Context, cmd *Command, missing
 		}
 
 		value, err := a.Prompter.Prompt(ctx, PromptRequest{
-			Label: label,
-			Theme: a.DefaultTheme,
+			Label:    label,
+			Default:  flag.Default,
+			Validate: flag.Validate,
+			Theme:    a.PromptTheme(),
 		})
 		if err != nil {
 			return err
@@ -323,13 +567,33 @@ func (a *App) promptForRequiredFlags(ctx context.Context, cmd *Command, missing
 			}
 		}
 		flag.set = true
+		flag.source = SourceCommandFlag
+	}
+	return nil
+}
+
+// printVersion handles the global --version flag. It prints a simple version
+// line, like the "version" command but without commit/date; the version
+// extension sets app.Version. With --format set to a structured format such
+// as json, the name and version are written with FormatOutput instead.
+func (a *App) printVersion() error {
+	if format, _ := a.Flags().String("format"); format != "" && !strings.EqualFold(format, FormatText) {
+		data := map[string]string{"name": a.Name}
+		if a.Version != "" {
+			data["version"] = a.Version
+		}
+		return a.FormatOutput(data)
+	}
+	if a.Version != "" {
+		fmt.Fprintf(a.Out, "%s version %s\n", a.Name, a.Version)
+	} else {
+		fmt.Fprintf(a.Out, "%s\n", a.Name)
 	}
 	return nil
 }
 
-func (a *App) printCommandHelp(cmd *Command) error {
-	helper := HelpRenderer{App: a, Command: cmd}
-	return helper.Render(a.Out)
+func (a *App) printCommandHelp(ctx context.Context, cmd *Command) error {
+	return a.RenderHelp(ctx, cmd, a.Out)
 }
 
 // countUserChildren returns the count of child commands/groups that are not extension commands.
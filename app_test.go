@@ -1,11 +1,25 @@
 package clix
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
 )
 
+// newTestApp returns an app with buffered output and an empty config
+// directory, ready for commands to be added under its root.
+func newTestApp(t *testing.T, name string, opts ...AppOption) *App {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app := NewApp(name, opts...)
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	return app
+}
+
 func TestAppRunAppliesConfigurationPrecedence(t *testing.T) {
 	app := NewApp("demo")
 
@@ -198,3 +212,196 @@ func TestFlagPrecedenceWithGlobalFlags(t *testing.T) {
 		// but ctx.String() should still return it
 	})
 }
+
+func TestAppCloneIsolatesRunState(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.Config.Set("region", "us")
+
+	var name string
+	cmd := NewCommand("greet")
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{Name: "name"},
+		Default:     "world",
+		Value:       &name,
+	})
+	var seen []string
+	cmd.Run = func(ctx *Context) error {
+		value, source, _ := ctx.EffectiveString("name")
+		seen = append(seen, value+"/"+source.String())
+		ctx.App.Config.Set("region", "eu")
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	first := app.Clone()
+	if first.Root == app.Root || first.Root.Children[0] == cmd {
+		t.Fatalf("expected clone to copy the command tree")
+	}
+	if first.Root.Children[0].Flags == cmd.Flags {
+		t.Fatalf("expected clone to copy flag sets")
+	}
+	if err := first.Run(context.Background(), []string{"greet", "--name", "alice"}); err != nil {
+		t.Fatalf("first run failed: %v", err)
+	}
+
+	if err := app.Clone().Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("second run failed: %v", err)
+	}
+
+	want := []string{"alice/command flag", "world/default"}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+	if v, _ := app.Config.Get("region"); v != "us" {
+		t.Fatalf("expected original config to be untouched, got %q", v)
+	}
+	if v, _ := first.Config.Get("region"); v != "eu" {
+		t.Fatalf("expected clone config to record the change, got %q", v)
+	}
+}
+
+func TestAppCloneLeavesBoundValues(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	var name string
+	cmd := NewCommand("greet")
+	cmd.Flags.StringVar(WithFlagName("name"), WithStringDefault("def"), WithStringValue(&name))
+	cmd.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"greet", "--name", "alice"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	clone := app.Clone()
+	if name != "alice" {
+		t.Fatalf("expected Clone to leave the bound variable alone, got %q", name)
+	}
+
+	if err := clone.Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("clone run failed: %v", err)
+	}
+	if name != "def" {
+		t.Fatalf("expected the clone's run to restore the default, got %q", name)
+	}
+}
+
+func TestAppCloneDoesNotReapplyExtensions(t *testing.T) {
+	app := NewApp("demo")
+	applied := 0
+	app.AddExtension(extensionFunc(func(a *App) error {
+		applied++
+		a.Root.AddCommand(NewCommand("extra"))
+		return nil
+	}))
+	if err := app.ApplyExtensions(); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	clone := app.Clone()
+	if err := clone.ApplyExtensions(); err != nil {
+		t.Fatalf("apply on clone failed: %v", err)
+	}
+	if applied != 1 {
+		t.Fatalf("expected extensions to be applied once, got %d", applied)
+	}
+	if len(clone.Root.Children) != 1 {
+		t.Fatalf("expected clone to inherit extension command, got %d children", len(clone.Root.Children))
+	}
+}
+
+func TestAppRunResetsFlagsBetweenRuns(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+
+	var project string
+	cmd := NewCommand("deploy")
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{Name: "project"},
+		Value:       &project,
+	})
+	var source Source
+	var found bool
+	cmd.Run = func(ctx *Context) error {
+		_, source, found = ctx.EffectiveString("project")
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"deploy", "--project", "x"}); err != nil {
+		t.Fatalf("first run failed: %v", err)
+	}
+	if !found || source != SourceCommandFlag || project != "x" {
+		t.Fatalf("expected first run to see --project x as a command flag, got %q from %v (found=%v)", project, source, found)
+	}
+
+	if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+		t.Fatalf("second run failed: %v", err)
+	}
+	if found {
+		t.Fatalf("expected second run not to see a project value, got source %v", source)
+	}
+	if project != "" {
+		t.Fatalf("expected project to be reset, got %q", project)
+	}
+}
+
+type testStateKey struct{}
+
+func TestContextValuesSharedAcrossHooks(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := NewApp("demo")
+	app.configLoaded = true
+
+	type parentKey string
+	parent := context.WithValue(context.Background(), parentKey("request"), "abc")
+
+	var seen []any
+	app.Root.PersistentPreRun = func(ctx *Context) error {
+		ctx.Set(testStateKey{}, "db-handle")
+		return nil
+	}
+	app.Root.Use(func(next Handler) Handler {
+		return func(ctx *Context) error {
+			seen = append(seen, ctx.Value(testStateKey{}))
+			return next(ctx)
+		}
+	})
+	cmd := NewCommand("run")
+	cmd.Run = func(ctx *Context) error {
+		seen = append(seen, ctx.Value(testStateKey{}))
+		// Values are visible through the plain context.Context interface and
+		// fall back to the parent context.
+		var plain context.Context = ctx
+		seen = append(seen, plain.Value(testStateKey{}), ctx.Value(parentKey("request")))
+		return nil
+	}
+	cmd.PostRun = func(ctx *Context) error {
+		seen = append(seen, ctx.Value(testStateKey{}))
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(parent, []string{"run"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := []any{"db-handle", "db-handle", "db-handle", "abc", "db-handle"}
+	if len(seen) != len(want) {
+		t.Fatalf("seen = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("seen[%d] = %v, want %v", i, seen[i], want[i])
+		}
+	}
+
+	// Each invocation starts with no values.
+	app.Root.PersistentPreRun = nil
+	seen = nil
+	if err := app.Run(context.Background(), []string{"run"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if seen[0] != nil {
+		t.Errorf("value leaked into the next run: %v", seen[0])
+	}
+}
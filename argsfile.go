@@ -0,0 +1,167 @@
+package clix
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ExpandArgsFiles replaces every argument of the form @path with the
+// arguments read from that file. Run does this itself when App.ArgsFiles is
+// set. Arguments after "--" are left alone, and "@@text" stands for a
+// literal "@text". An argument that is the value of the flag before it, such
+// as "@key.pem" in "--key @key.pem" for a flag registered with
+// WithStringFromFile, is passed through untouched; "--region @r.args" is
+// therefore not expanded either when --region takes a value.
+//
+// An args file holds one argument per line. Surrounding whitespace is
+// trimmed, and blank lines and lines starting with "#" are skipped. Wrap a
+// line in double quotes (with Go escapes) or single quotes to keep
+// whitespace or a leading "#". A line may itself be @path to include another
+// file; an include cycle is an error.
+//
+//	# ci.args
+//	--region
+//	eu-west
+//	"--message=release notes"
+//	@common.args
+func (a *App) ExpandArgsFiles(args []string) ([]string, error) {
+	return a.expandArgsFiles(args, nil)
+}
+
+func (a *App) expandArgsFiles(args []string, including []string) ([]string, error) {
+	var expanded []string
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return append(expanded, args[i:]...), nil
+		case len(expanded) > 0 && a.takesValue(expanded[len(expanded)-1]):
+			expanded = append(expanded, arg)
+		case strings.HasPrefix(arg, "@@"):
+			expanded = append(expanded, arg[1:])
+		case strings.HasPrefix(arg, "@") && len(arg) > 1:
+			fileArgs, err := a.readArgsFile(arg[1:], including)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, fileArgs...)
+		default:
+			expanded = append(expanded, arg)
+		}
+	}
+	return expanded, nil
+}
+
+// readArgsFile reads the arguments in path, expanding the files it includes.
+func (a *App) readArgsFile(path string, including []string) ([]string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, seen := range including {
+		if seen == abs {
+			return nil, fmt.Errorf("args file %s includes itself", path)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading args file: %w", err)
+	}
+	defer file.Close()
+
+	var args []string
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		arg, err := unquoteArg(text)
+		if err != nil {
+			return nil, fmt.Errorf("args file %s:%d: %w", path, line, err)
+		}
+		if text[0] != '"' && text[0] != '\'' && strings.HasPrefix(arg, "@") &&
+			(len(args) == 0 || !a.takesValue(args[len(args)-1])) {
+			nested, err := a.expandArgsFiles([]string{arg}, append(including, abs))
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, nested...)
+			continue
+		}
+		args = append(args, arg)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading args file: %w", err)
+	}
+	return args, nil
+}
+
+// takesValue reports whether arg is a flag, given as "--name" or "-n" without
+// an attached value, that some command in the app defines as taking a value.
+// Args files are expanded before the command is resolved, so any command's
+// flags count.
+func (a *App) takesValue(arg string) bool {
+	if !strings.HasPrefix(arg, "-") || arg == "-" || arg == "--" || strings.Contains(arg, "=") {
+		return false
+	}
+	var find func(fs *FlagSet) *Flag
+	if name, ok := strings.CutPrefix(arg, "--"); ok {
+		find = func(fs *FlagSet) *Flag { return fs.Lookup(name) }
+	} else if len(arg) == 2 {
+		find = func(fs *FlagSet) *Flag { return fs.LookupShort(arg[1:]) }
+	} else {
+		return false
+	}
+
+	var walk func(cmd *Command) bool
+	walk = func(cmd *Command) bool {
+		for _, fs := range []*FlagSet{cmd.Flags, cmd.PersistentFlags} {
+			if fs == nil {
+				continue
+			}
+			if flag := find(fs); flag != nil {
+				if _, isBool := flag.Value.(boolFlag); !isBool {
+					return true
+				}
+			}
+		}
+		for _, child := range cmd.Children {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return a.Root != nil && walk(a.Root)
+}
+
+// unquoteArg strips double quotes, honouring Go escapes, or single quotes,
+// taken literally, from a quoted line.
+func unquoteArg(text string) (string, error) {
+	switch text[0] {
+	case '"':
+		return strconv.Unquote(text)
+	case '\'':
+		if len(text) < 2 || text[len(text)-1] != '\'' {
+			return "", fmt.Errorf("unterminated quote in %s", text)
+		}
+		return text[1 : len(text)-1], nil
+	}
+	return text, nil
+}
+
+// WithAppArgsFiles makes Run expand @path arguments (see App.ArgsFiles).
+func WithAppArgsFiles() AppOption {
+	return appArgsFilesOption{}
+}
+
+type appArgsFilesOption struct{}
+
+func (appArgsFilesOption) ApplyApp(app *App) {
+	app.ArgsFiles = true
+}
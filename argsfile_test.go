@@ -0,0 +1,131 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeArgsFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExpandArgsFiles(t *testing.T) {
+	dir := t.TempDir()
+	common := writeArgsFile(t, dir, "common.args", "# shared settings\n--verbose\n")
+	main := writeArgsFile(t, dir, "ci.args", strings.Join([]string{
+		"# deploy settings",
+		"  --region  ",
+		"eu-west",
+		"",
+		`"--message=release notes"`,
+		`'# not a comment'`,
+		`"@literal"`,
+		"@" + common,
+		"    # indented comment",
+	}, "\n"))
+
+	app := NewApp("tool")
+	got, err := app.ExpandArgsFiles([]string{"deploy", "@" + main, "@@user", "--", "@" + main})
+	if err != nil {
+		t.Fatalf("ExpandArgsFiles: %v", err)
+	}
+	want := []string{"deploy", "--region", "eu-west", "--message=release notes", "# not a comment", "@literal", "--verbose", "@user", "--", "@" + main}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expanded = %q\nwant       %q", got, want)
+	}
+}
+
+func TestExpandArgsFilesErrors(t *testing.T) {
+	dir := t.TempDir()
+	app := NewApp("tool")
+
+	_, err := app.ExpandArgsFiles([]string{"@" + filepath.Join(dir, "missing.args")})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file error = %v, want os.ErrNotExist", err)
+	}
+
+	loop := filepath.Join(dir, "loop.args")
+	writeArgsFile(t, dir, "loop.args", "--a\n@"+loop+"\n")
+	if _, err := app.ExpandArgsFiles([]string{"@" + loop}); err == nil || !strings.Contains(err.Error(), "includes itself") {
+		t.Errorf("cycle error = %v", err)
+	}
+
+	bad := writeArgsFile(t, dir, "bad.args", "'unterminated\n")
+	if _, err := app.ExpandArgsFiles([]string{"@" + bad}); err == nil || !strings.Contains(err.Error(), "bad.args:1") {
+		t.Errorf("quote error = %v", err)
+	}
+}
+
+func TestRunExpandsArgsFiles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := writeArgsFile(t, t.TempDir(), "deploy.args", "--region\neu-west\n")
+
+	for _, enabled := range []bool{true, false} {
+		var region string
+		app := NewApp("tool")
+		app.ArgsFiles = enabled
+		app.Out = &bytes.Buffer{}
+		cmd := NewCommand("deploy")
+		cmd.Flags.StringVar(WithFlagName("region"), WithStringValue(&region))
+		cmd.Run = func(*Context) error { return nil }
+		app.Root.AddCommand(cmd)
+
+		err := app.Run(context.Background(), []string{"deploy", "@" + path})
+		if enabled {
+			if err != nil || region != "eu-west" {
+				t.Errorf("ArgsFiles: region = %q, err = %v", region, err)
+			}
+		} else if err == nil {
+			t.Error("expected @path to be an unexpected argument when ArgsFiles is off")
+		}
+	}
+}
+
+func TestArgsFilesLeaveFlagValuesAlone(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	secret := writeArgsFile(t, dir, "secret.pem", "-----BEGIN KEY-----\n")
+	opts := writeArgsFile(t, dir, "deploy.args", "--region\neu-west\n--key\n@"+secret+"\n")
+
+	tests := map[string]struct {
+		args   []string
+		region string
+	}{
+		// -r takes a value, so its @path is not an args file either.
+		"command line": {[]string{"deploy", "--key", "@" + secret, "-r", "@" + opts}, "@" + opts},
+		"args file":    {[]string{"deploy", "@" + opts}, "eu-west"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var key, region string
+			app := NewApp("tool", WithAppArgsFiles())
+			app.Out = &bytes.Buffer{}
+			cmd := NewCommand("deploy")
+			cmd.Flags.StringVar(WithFlagName("key"), WithStringFromFile(), WithStringValue(&key))
+			cmd.Flags.StringVar(WithFlagName("region"), WithFlagShort("r"), WithStringValue(&region))
+			cmd.Run = func(*Context) error { return nil }
+			app.Root.AddCommand(cmd)
+
+			if err := app.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if key != "-----BEGIN KEY-----" {
+				t.Errorf("expected --key to read the PEM file, got %q", key)
+			}
+			if region != tt.region {
+				t.Errorf("region = %q, want %q", region, tt.region)
+			}
+		})
+	}
+}
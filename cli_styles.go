@@ -61,6 +61,10 @@ type Styles struct {
 
 	// Example styles example text in help output.
 	Example TextStyle
+
+	// Deprecated styles the "(deprecated)" marker shown next to deprecated
+	// commands in help output.
+	Deprecated TextStyle
 }
 
 // DefaultStyles leaves all styles unset, producing plain text output.
@@ -141,6 +145,11 @@ func WithExample(style TextStyle) StyleOption {
 	return styleExampleOption{style: style}
 }
 
+// WithDeprecated sets the style of the deprecated-command marker.
+func WithDeprecated(style TextStyle) StyleOption {
+	return styleDeprecatedOption{style: style}
+}
+
 // Internal option types
 
 type styleAppTitleOption struct{ style TextStyle }
@@ -198,3 +207,7 @@ func (o styleChildDescOption) ApplyStyle(s *Styles) { s.ChildDesc = o.style }
 type styleExampleOption struct{ style TextStyle }
 
 func (o styleExampleOption) ApplyStyle(s *Styles) { s.Example = o.style }
+
+type styleDeprecatedOption struct{ style TextStyle }
+
+func (o styleDeprecatedOption) ApplyStyle(s *Styles) { s.Deprecated = o.style }
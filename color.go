@@ -0,0 +1,101 @@
+package clix
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ColorProfile controls whether the app styles its output.
+type ColorProfile int
+
+const (
+	// ColorAuto styles output unless the NO_COLOR environment variable is set
+	// or Out is a file that is not a terminal (a pipe or a redirect to a log).
+	// A non-empty CLICOLOR_FORCE other than "0" forces styling on, and takes
+	// precedence over the terminal check but not over NO_COLOR. Writers that
+	// are not files, such as buffers, are assumed to accept styled text.
+	ColorAuto ColorProfile = iota
+	// ColorAlways styles output regardless of the environment.
+	ColorAlways
+	// ColorNever writes plain text, ignoring App.Styles and theme styles.
+	ColorNever
+)
+
+// SetColorProfile sets when the app styles its output. Use it to wire up a
+// --color flag:
+//
+//	switch color {
+//	case "always":
+//		app.SetColorProfile(clix.ColorAlways)
+//	case "never":
+//		app.SetColorProfile(clix.ColorNever)
+//	}
+func (a *App) SetColorProfile(profile ColorProfile) {
+	a.colorProfile = profile
+}
+
+// ColorEnabled reports whether help output and prompts are styled, according
+// to the profile set with SetColorProfile.
+func (a *App) ColorEnabled() bool {
+	return a.colorEnabledFor(a.Out)
+}
+
+// colorEnabledFor is ColorEnabled for output written to w instead of Out.
+func (a *App) colorEnabledFor(w io.Writer) bool {
+	switch a.colorProfile {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	f, ok := w.(*os.File)
+	return !ok || term.IsTerminal(int(f.Fd()))
+}
+
+// PromptTheme returns App.DefaultTheme, with its styles removed when color is
+// disabled. Pass it to prompts so they follow the app's color settings:
+//
+//	name, err := ctx.App.Prompter.Prompt(ctx, clix.WithLabel("Name"), clix.WithTheme(ctx.App.PromptTheme()))
+func (a *App) PromptTheme() PromptTheme {
+	if a.ColorEnabled() {
+		return a.DefaultTheme
+	}
+	return a.DefaultTheme.unstyled()
+}
+
+// stylesFor returns App.Styles, or no styles at all when color is disabled
+// for output written to w.
+func (a *App) stylesFor(w io.Writer) Styles {
+	if a.colorEnabledFor(w) {
+		return a.Styles
+	}
+	return Styles{}
+}
+
+// unstyled returns a copy of the theme that keeps its text but drops every style.
+func (t PromptTheme) unstyled() PromptTheme {
+	return PromptTheme{
+		Prefix: t.Prefix,
+		Hint:   t.Hint,
+		Error:  t.Error,
+	}
+}
+
+// WithAppColorProfile sets when the app styles its output.
+func WithAppColorProfile(profile ColorProfile) AppOption {
+	return appColorProfileOption(profile)
+}
+
+type appColorProfileOption ColorProfile
+
+func (o appColorProfileOption) ApplyApp(app *App) {
+	app.colorProfile = ColorProfile(o)
+}
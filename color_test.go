@@ -0,0 +1,129 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+var boldStyle = StyleFunc(func(strs ...string) string {
+	return "\x1b[1m" + strings.Join(strs, " ") + "\x1b[0m"
+})
+
+func newStyledApp() *App {
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	app.Styles = Styles{AppTitle: boldStyle, SectionHeading: boldStyle, FlagName: boldStyle}
+	app.DefaultTheme.LabelStyle = boldStyle
+	return app
+}
+
+func TestNoColorDisablesStyles(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("CLICOLOR_FORCE", "1")
+
+	app := newStyledApp()
+	var name string
+	cmd := NewCommand("greet")
+	cmd.Flags.StringVar(WithFlagName("name"), WithStringValue(&name), WithFlagRequired())
+	cmd.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(cmd)
+
+	var theme PromptTheme
+	app.Prompter = prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		cfg := &PromptConfig{}
+		for _, opt := range opts {
+			opt.Apply(cfg)
+		}
+		theme = cfg.Theme
+		return "Ada", nil
+	})
+
+	if err := app.Run(context.Background(), []string{"--help"}); err != nil {
+		t.Fatalf("Run --help: %v", err)
+	}
+	if out := app.Out.(*bytes.Buffer).String(); !strings.Contains(out, "USAGE") || strings.Contains(out, "\x1b") {
+		t.Errorf("help contains ANSI escapes with NO_COLOR set:\n%q", out)
+	}
+
+	if err := app.Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("Run greet: %v", err)
+	}
+	if theme.LabelStyle != nil {
+		t.Error("prompt theme kept its styles with NO_COLOR set")
+	}
+	if theme.Prefix != DefaultPromptTheme.Prefix {
+		t.Errorf("theme prefix = %q, want %q", theme.Prefix, DefaultPromptTheme.Prefix)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	tests := []struct {
+		name     string
+		noColor  string
+		force    string
+		profile  ColorProfile
+		out      *os.File
+		expected bool
+	}{
+		{name: "buffer", expected: true},
+		{name: "pipe", out: w, expected: false},
+		{name: "pipe forced", out: w, force: "1", expected: true},
+		{name: "pipe force zero", out: w, force: "0", expected: false},
+		{name: "no color", noColor: "1", expected: false},
+		{name: "always beats no color", noColor: "1", profile: ColorAlways, expected: true},
+		{name: "never", profile: ColorNever, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("CLICOLOR_FORCE", tt.force)
+
+			app := newStyledApp()
+			if tt.out != nil {
+				app.Out = tt.out
+			}
+			app.SetColorProfile(tt.profile)
+			if got := app.ColorEnabled(); got != tt.expected {
+				t.Errorf("ColorEnabled() = %v, want %v", got, tt.expected)
+			}
+			if got := app.Clone().ColorEnabled(); got != tt.expected {
+				t.Errorf("clone ColorEnabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelpStyledWhenColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	app := newStyledApp()
+	var buf bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[1m") {
+		t.Errorf("expected styled help, got:\n%q", buf.String())
+	}
+
+	buf.Reset()
+	app.SetColorProfile(ColorNever)
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Errorf("ColorNever help contains ANSI escapes:\n%q", buf.String())
+	}
+}
@@ -57,9 +57,26 @@ type Command struct {
 	// Example shows example usage in help output.
 	Example string
 
-	// Hidden hides the command from help output and autocomplete.
+	// Category groups the command under its own heading in its parent's help
+	// output (e.g., "Compute" renders as a COMPUTE section). Categories are
+	// listed alphabetically after the uncategorized GROUPS and COMMANDS.
+	Category string
+
+	// Hidden hides the command from help output and autocomplete. Hidden
+	// commands are still dispatched normally. A group whose children are all
+	// hidden is hidden as well (see IsHidden).
 	Hidden bool
 
+	// Deprecated marks the command as deprecated. When the command is invoked,
+	// App.Run prints `Command "name" is deprecated: <Deprecated>` to app.Err and
+	// then runs it. Help output marks deprecated commands. Empty means the
+	// command is not deprecated.
+	Deprecated string
+
+	// DeprecatedNoRun prints the deprecation notice instead of running the
+	// command. It has no effect unless Deprecated is set.
+	DeprecatedNoRun bool
+
 	// IsExtensionCommand indicates this command was added by an extension.
 	// Extension commands are not counted when determining if a command has user-defined children.
 	IsExtensionCommand bool
@@ -68,6 +85,38 @@ type Command struct {
 	// Use app.Flags() for flags that apply to all commands.
 	Flags *FlagSet
 
+	// PersistentFlags holds flags this command shares with all of its
+	// descendants. Invoking any subcommand accepts them, and ctx.String and
+	// friends consult them after the subcommand's own flags but before app
+	// flags. A subcommand flag of the same name shadows the inherited one.
+	// On the root command PersistentFlags is the same set as Flags, the app
+	// flags (see App.Flags), which already apply to every command.
+	PersistentFlags *FlagSet
+
+	// MinArgs is the minimum number of positional arguments the command
+	// accepts. App.Run rejects fewer with "expects at least N arguments"
+	// before any hook runs.
+	MinArgs int
+
+	// MaxArgs is the maximum number of positional arguments the command
+	// accepts. Zero means no limit beyond what the positional flags consume.
+	MaxArgs int
+
+	// SilenceUsage stops App.Main from printing usage after an input error
+	// (a UsageError) for this command and its descendants.
+	SilenceUsage bool
+
+	// SilenceErrors stops App.Main from printing errors returned while
+	// running this command or its descendants. The exit status is unchanged.
+	SilenceErrors bool
+
+	// ScopedConfig makes config reads for this command and its descendants
+	// look up keys under the command path first: for "app db migrate",
+	// "timeout" resolves from db.migrate.timeout, then db.timeout, then
+	// timeout. This lets teams sharing one config file keep db.timeout and
+	// bq.timeout apart. See Context.ScopedString.
+	ScopedConfig bool
+
 	// Children are the child commands or groups of this command.
 	// Use NewGroup() to create groups, NewCommand() to create executable commands.
 	Children []*Command
@@ -84,7 +133,26 @@ type Command struct {
 	// PostRun is executed after Run. Useful for cleanup or finalization.
 	PostRun Hook
 
-	parent *Command
+	// PersistentPreRun is executed before PreRun for this command and every
+	// descendant. Hooks run from the root down to the invoked command, so
+	// cross-cutting setup (logging, connections) can be configured once.
+	//
+	// The full order for "app parent child" is:
+	//
+	//	root PersistentPreRun → parent PersistentPreRun → child PersistentPreRun →
+	//	child PreRun → child Run → child PostRun →
+	//	child PersistentPostRun → parent PersistentPostRun → root PersistentPostRun
+	//
+	// The first hook or handler that returns an error stops the chain; no
+	// later hooks run, including post-run hooks.
+	PersistentPreRun Hook
+
+	// PersistentPostRun is executed after PostRun for this command and every
+	// descendant, from the invoked command up to the root.
+	PersistentPostRun Hook
+
+	middleware []Middleware
+	parent     *Command
 }
 
 // CommandOption configures a command using the functional options pattern.
@@ -140,8 +208,9 @@ type CommandOption interface {
 func NewCommand(name string, opts ...CommandOption) *Command {
 	var help bool
 	cmd := &Command{
-		Name:  name,
-		Flags: NewFlagSet(name),
+		Name:            name,
+		Flags:           NewFlagSet(name),
+		PersistentFlags: NewFlagSet(name),
 	}
 
 	cmd.Flags.BoolVar(BoolVarOptions{
@@ -181,10 +250,11 @@ func NewCommand(name string, opts ...CommandOption) *Command {
 func NewGroup(name, short string, children ...*Command) *Command {
 	var help bool
 	cmd := &Command{
-		Name:     name,
-		Short:    short,
-		Children: children,
-		Flags:    NewFlagSet(name),
+		Name:            name,
+		Short:           short,
+		Children:        children,
+		Flags:           NewFlagSet(name),
+		PersistentFlags: NewFlagSet(name),
 	}
 
 	cmd.Flags.BoolVar(BoolVarOptions{
@@ -206,6 +276,40 @@ func (c *Command) AddCommand(cmd *Command) {
 	c.Children = append(c.Children, cmd)
 }
 
+// AddCommandErr is like AddCommand but refuses children whose name or alias
+// (compared case-insensitively, as during dispatch) is already used by an
+// existing child. This surfaces conflicts when mounting commands from several
+// packages, where AddCommand would silently register both and leave dispatch
+// to the first match.
+func (c *Command) AddCommandErr(cmd *Command) error {
+	if err := c.checkChildConflict(cmd); err != nil {
+		return err
+	}
+	c.AddCommand(cmd)
+	return nil
+}
+
+// checkChildConflict reports the first name or alias of cmd that collides
+// with the name or an alias of an existing child.
+func (c *Command) checkChildConflict(cmd *Command) error {
+	for _, child := range c.Children {
+		if child == nil || child == cmd {
+			continue
+		}
+		for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if strings.EqualFold(child.Name, name) {
+				return fmt.Errorf("cannot add command %q to %s: name %q is already used by command %q", cmd.Name, c.Path(), name, child.Name)
+			}
+			for _, alias := range child.Aliases {
+				if strings.EqualFold(alias, name) {
+					return fmt.Errorf("cannot add command %q to %s: name %q is already an alias of command %q", cmd.Name, c.Path(), name, child.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // IsGroup returns true if this command is a group (has children but no Run handler).
 // Groups are interior nodes that organize child commands.
 func (c *Command) IsGroup() bool {
@@ -224,6 +328,9 @@ func (c *Command) prepare(parent *Command) {
 	if c.Flags == nil {
 		c.Flags = NewFlagSet(c.Name)
 	}
+	if c.PersistentFlags == nil {
+		c.PersistentFlags = NewFlagSet(c.Name)
+	}
 
 	if c.Flags.lookup("help") == nil {
 		c.Flags.BoolVar(BoolVarOptions{
@@ -242,6 +349,15 @@ func (c *Command) prepare(parent *Command) {
 	}
 }
 
+// lineage returns the command and its ancestors ordered from the root down.
+func (c *Command) lineage() []*Command {
+	var chain []*Command
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		chain = append([]*Command{cmd}, chain...)
+	}
+	return chain
+}
+
 // Path returns the command path from the root.
 func (c *Command) Path() string {
 	if c.parent == nil {
@@ -250,31 +366,81 @@ func (c *Command) Path() string {
 	return fmt.Sprintf("%s %s", c.parent.Path(), c.Name)
 }
 
-// findChild returns the first matching child command or group by name or alias.
+// checkArgCount enforces MinArgs and MaxArgs for n positional arguments.
+func (c *Command) checkArgCount(n int) error {
+	if n < c.MinArgs {
+		return fmt.Errorf("%s expects at least %d %s, got %d", c.Path(), c.MinArgs, pluralArgument(c.MinArgs), n)
+	}
+	if c.MaxArgs > 0 && n > c.MaxArgs {
+		return fmt.Errorf("%s expects at most %d %s, got %d", c.Path(), c.MaxArgs, pluralArgument(c.MaxArgs), n)
+	}
+	return nil
+}
+
+func pluralArgument(n int) string {
+	if n == 1 {
+		return "argument"
+	}
+	return "arguments"
+}
+
+// findChild returns the matching child command or group by name or alias.
+// Ambiguous aliases resolve to nil; use lookupChild to get the error.
 func (c *Command) findChild(name string) *Command {
-	name = strings.ToLower(name)
+	child, _ := c.lookupChild(name)
+	return child
+}
+
+// lookupChild returns the child whose name or alias matches name. An exact
+// name match takes priority over aliases. If the name is not a child's name
+// but is an alias of more than one child, an error is returned.
+func (c *Command) lookupChild(name string) (*Command, error) {
 	for _, child := range c.Children {
 		if strings.EqualFold(child.Name, name) {
-			return child
+			return child, nil
 		}
+	}
+
+	var matches []*Command
+	for _, child := range c.Children {
 		for _, alias := range child.Aliases {
 			if strings.EqualFold(alias, name) {
-				return child
+				matches = append(matches, child)
+				break
 			}
 		}
 	}
-	return nil
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.Name
+	}
+	return nil, fmt.Errorf("ambiguous command alias %q in %s: matches %s", name, c.Path(), strings.Join(names, ", "))
 }
 
 // match walks the command tree and returns the deepest command that matches the
 // provided arguments and the remaining arguments to parse for flags and
 // positionals.
 func (c *Command) match(args []string) (*Command, []string) {
+	cmd, rest, _ := c.resolve(args)
+	return cmd, rest
+}
+
+// resolve is like match but reports ambiguous aliases encountered on the way.
+func (c *Command) resolve(args []string) (*Command, []string, error) {
 	current := c
 	rest := args
 
 	for len(rest) > 0 {
-		next := current.findChild(rest[0])
+		next, err := current.lookupChild(rest[0])
+		if err != nil {
+			return current, rest, err
+		}
 		if next == nil {
 			break
 		}
@@ -282,14 +448,32 @@ func (c *Command) match(args []string) (*Command, []string) {
 		current = next
 	}
 
-	return current, rest
+	return current, rest, nil
+}
+
+// IsHidden reports whether the command should be left out of help and
+// completion. A command is hidden when Hidden is set, or when it is a group
+// whose children are all hidden. Hidden commands can still be run.
+func (c *Command) IsHidden() bool {
+	if c.Hidden {
+		return true
+	}
+	if !c.IsGroup() {
+		return false
+	}
+	for _, child := range c.Children {
+		if child != nil && !child.IsHidden() {
+			return false
+		}
+	}
+	return true
 }
 
 // VisibleChildren returns a sorted slice of child commands and groups that are not hidden.
 func (c *Command) VisibleChildren() []*Command {
 	var cmds []*Command
 	for _, child := range c.Children {
-		if child.Hidden {
+		if child.IsHidden() {
 			continue
 		}
 		cmds = append(cmds, child)
@@ -363,6 +547,33 @@ func WithCommandExample(example string) CommandOption {
 	return commandExampleOption(example)
 }
 
+// WithCommandCategory sets the help category the command is listed under.
+func WithCommandCategory(category string) CommandOption {
+	return commandCategoryOption(category)
+}
+
+// WithCommandArgs sets the minimum and maximum number of positional
+// arguments the command accepts. A max of zero means no upper limit.
+func WithCommandArgs(min, max int) CommandOption {
+	return commandArgsOption{min: min, max: max}
+}
+
+// WithCommandSilenceUsage stops usage from being printed after input errors.
+func WithCommandSilenceUsage() CommandOption {
+	return commandSilenceUsageOption(true)
+}
+
+// WithCommandSilenceErrors stops App.Main from printing the command's errors.
+func WithCommandSilenceErrors() CommandOption {
+	return commandSilenceErrorsOption(true)
+}
+
+// WithCommandScopedConfig scopes config reads to the command path. See
+// Command.ScopedConfig.
+func WithCommandScopedConfig() CommandOption {
+	return commandScopedConfigOption(true)
+}
+
 // WithCommandAliases sets the command aliases.
 func WithCommandAliases(aliases ...string) CommandOption {
 	return commandAliasesOption(aliases)
@@ -388,6 +599,21 @@ func WithCommandPostRun(postRun Hook) CommandOption {
 	return commandPostRunOption{postRun: postRun}
 }
 
+// WithCommandDeprecated marks the command as deprecated with the given message.
+func WithCommandDeprecated(message string) CommandOption {
+	return commandDeprecatedOption(message)
+}
+
+// WithCommandPersistentPreRun sets the pre-run hook inherited by descendants.
+func WithCommandPersistentPreRun(preRun Hook) CommandOption {
+	return commandPersistentPreRunOption{preRun: preRun}
+}
+
+// WithCommandPersistentPostRun sets the post-run hook inherited by descendants.
+func WithCommandPersistentPostRun(postRun Hook) CommandOption {
+	return commandPersistentPostRunOption{postRun: postRun}
+}
+
 // Internal option types
 
 type commandShortOption string
@@ -420,6 +646,39 @@ func (o commandAliasesOption) ApplyCommand(cmd *Command) {
 	cmd.Aliases = []string(o)
 }
 
+type commandCategoryOption string
+
+func (o commandCategoryOption) ApplyCommand(cmd *Command) {
+	cmd.Category = string(o)
+}
+
+type commandArgsOption struct {
+	min, max int
+}
+
+func (o commandArgsOption) ApplyCommand(cmd *Command) {
+	cmd.MinArgs = o.min
+	cmd.MaxArgs = o.max
+}
+
+type commandSilenceUsageOption bool
+
+func (o commandSilenceUsageOption) ApplyCommand(cmd *Command) {
+	cmd.SilenceUsage = bool(o)
+}
+
+type commandSilenceErrorsOption bool
+
+func (o commandSilenceErrorsOption) ApplyCommand(cmd *Command) {
+	cmd.SilenceErrors = bool(o)
+}
+
+type commandScopedConfigOption bool
+
+func (o commandScopedConfigOption) ApplyCommand(cmd *Command) {
+	cmd.ScopedConfig = bool(o)
+}
+
 type commandHiddenOption bool
 
 func (o commandHiddenOption) ApplyCommand(cmd *Command) {
@@ -450,3 +709,24 @@ func (o commandPostRunOption) ApplyCommand(cmd *Command) {
 	cmd.PostRun = o.postRun
 }
 
+type commandDeprecatedOption string
+
+func (o commandDeprecatedOption) ApplyCommand(cmd *Command) {
+	cmd.Deprecated = string(o)
+}
+
+type commandPersistentPreRunOption struct {
+	preRun Hook
+}
+
+func (o commandPersistentPreRunOption) ApplyCommand(cmd *Command) {
+	cmd.PersistentPreRun = o.preRun
+}
+
+type commandPersistentPostRunOption struct {
+	postRun Hook
+}
+
+func (o commandPersistentPostRunOption) ApplyCommand(cmd *Command) {
+	cmd.PersistentPostRun = o.postRun
+}
@@ -0,0 +1,64 @@
+package clix
+
+import "strings"
+
+// CommandPathOption configures Context.CommandPath.
+type CommandPathOption interface {
+	applyCommandPath(*commandPathConfig)
+}
+
+type commandPathConfig struct {
+	invoked bool
+}
+
+// WithInvokedNames makes CommandPath report each command by the name or alias
+// the user typed (e.g. "myapp db mig up") instead of its canonical name.
+func WithInvokedNames() CommandPathOption {
+	return invokedNamesOption{}
+}
+
+type invokedNamesOption struct{}
+
+func (invokedNamesOption) applyCommandPath(cfg *commandPathConfig) {
+	cfg.invoked = true
+}
+
+// CommandPath returns the names of the commands from the root down to the
+// current command, such as ["myapp", "db", "migrate", "up"]. Names are
+// canonical unless WithInvokedNames is given.
+func (ctx *Context) CommandPath(opts ...CommandPathOption) []string {
+	if ctx.Command == nil {
+		return nil
+	}
+	var cfg commandPathConfig
+	for _, opt := range opts {
+		opt.applyCommandPath(&cfg)
+	}
+	lineage := ctx.Command.lineage()
+	path := make([]string, len(lineage))
+	for i, cmd := range lineage {
+		path[i] = cmd.Name
+	}
+	// invoked holds the typed names below the root; it is only set by Run.
+	if cfg.invoked && len(ctx.invoked) == len(path)-1 {
+		copy(path[1:], ctx.invoked)
+	}
+	return path
+}
+
+// CommandPathString returns CommandPath joined by spaces.
+func (ctx *Context) CommandPathString(opts ...CommandPathOption) string {
+	return strings.Join(ctx.CommandPath(opts...), " ")
+}
+
+// invokedNames returns the arguments resolveCommand consumed to reach cmd from
+// the root, without a leading root name, given the arguments before and after
+// resolution.
+func invokedNames(cmd *Command, args, rest []string) []string {
+	consumed := args[:len(args)-len(rest)]
+	depth := len(cmd.lineage()) - 1
+	if depth > len(consumed) {
+		return nil
+	}
+	return append([]string(nil), consumed[len(consumed)-depth:]...)
+}
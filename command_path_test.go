@@ -0,0 +1,53 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestContextCommandPath(t *testing.T) {
+	var canonical, invoked []string
+	var joined string
+	up := NewCommand("up")
+	up.Run = func(ctx *Context) error {
+		canonical = ctx.CommandPath()
+		invoked = ctx.CommandPath(WithInvokedNames())
+		joined = ctx.CommandPathString()
+		return nil
+	}
+	migrate := NewGroup("migrate", "Run migrations", up)
+	migrate.Aliases = []string{"mig"}
+	app := NewApp("myapp")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Root.AddCommand(NewGroup("db", "Manage the database", migrate))
+
+	for _, args := range [][]string{
+		{"db", "mig", "up"},
+		{"myapp", "db", "mig", "up"},
+	} {
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if want := []string{"myapp", "db", "migrate", "up"}; !reflect.DeepEqual(canonical, want) {
+			t.Errorf("Run(%q): CommandPath = %q, want %q", args, canonical, want)
+		}
+		if want := []string{"myapp", "db", "mig", "up"}; !reflect.DeepEqual(invoked, want) {
+			t.Errorf("Run(%q): invoked CommandPath = %q, want %q", args, invoked, want)
+		}
+		if joined != "myapp db migrate up" {
+			t.Errorf("Run(%q): CommandPathString = %q", args, joined)
+		}
+	}
+}
+
+func TestContextCommandPathOutsideRun(t *testing.T) {
+	cmd := NewCommand("child")
+	NewApp("app").Root.AddCommand(cmd)
+	ctx := &Context{Context: context.Background(), Command: cmd}
+	if got := ctx.CommandPathString(WithInvokedNames()); got != "app child" {
+		t.Errorf("CommandPathString = %q, want app child", got)
+	}
+}
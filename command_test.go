@@ -1,6 +1,12 @@
 package clix
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestCommandMatchingAndVisibility(t *testing.T) {
 	root := NewCommand("root")
@@ -94,3 +100,246 @@ func TestPrepareStaticCommandTree(t *testing.T) {
 		t.Fatalf("expected grandchild help flag to be registered")
 	}
 }
+
+func TestCommandAliasDispatch(t *testing.T) {
+	newApp := func(ran *string) *App {
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Out = &bytes.Buffer{}
+
+		list := NewCommand("list")
+		list.Aliases = []string{"ls", "l"}
+		list.Run = func(ctx *Context) error {
+			*ran = ctx.Command.Path()
+			return nil
+		}
+		project := NewGroup("project", "Manage projects", list)
+		project.Aliases = []string{"proj", "p"}
+		app.Root.AddCommand(project)
+		return app
+	}
+
+	for _, args := range [][]string{
+		{"project", "list"},
+		{"project", "ls"},
+		{"proj", "l"},
+		{"P", "LS"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var ran string
+			if err := newApp(&ran).Run(context.Background(), args); err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if ran != "demo project list" {
+				t.Errorf("expected canonical command path, got %q", ran)
+			}
+		})
+	}
+
+	t.Run("ambiguous alias", func(t *testing.T) {
+		app := NewApp("demo")
+		app.configLoaded = true
+		for _, name := range []string{"start", "status"} {
+			cmd := NewCommand(name)
+			cmd.Aliases = []string{"st"}
+			cmd.Run = func(ctx *Context) error { return nil }
+			app.Root.AddCommand(cmd)
+		}
+
+		err := app.Run(context.Background(), []string{"st"})
+		if err == nil {
+			t.Fatal("expected ambiguous alias error")
+		}
+		if want := `ambiguous command alias "st" in demo: matches start, status`; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("name beats alias", func(t *testing.T) {
+		app := NewApp("demo")
+		app.configLoaded = true
+		var ran string
+		for _, name := range []string{"st", "status"} {
+			cmd := NewCommand(name)
+			cmd.Aliases = []string{"st"}
+			cmd.Run = func(ctx *Context) error {
+				ran = ctx.Command.Name
+				return nil
+			}
+			app.Root.AddCommand(cmd)
+		}
+		if err := app.Run(context.Background(), []string{"st"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if ran != "st" {
+			t.Errorf("expected exact name match, got %q", ran)
+		}
+	})
+}
+
+func TestPersistentHooksOrder(t *testing.T) {
+	newApp := func(calls *[]string, failAt string) *App {
+		record := func(name string) Hook {
+			return func(ctx *Context) error {
+				*calls = append(*calls, name)
+				if name == failAt {
+					return errors.New("failed at " + name)
+				}
+				return nil
+			}
+		}
+
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Root.PersistentPreRun = record("root:persistent-pre")
+		app.Root.PersistentPostRun = record("root:persistent-post")
+
+		leaf := NewCommand("leaf",
+			WithCommandPersistentPreRun(record("leaf:persistent-pre")),
+			WithCommandPersistentPostRun(record("leaf:persistent-post")),
+			WithCommandPreRun(record("leaf:pre")),
+			WithCommandRun(Handler(record("leaf:run"))),
+			WithCommandPostRun(record("leaf:post")),
+		)
+		mid := NewGroup("mid", "Middle group", leaf)
+		mid.PersistentPreRun = record("mid:persistent-pre")
+		mid.PersistentPostRun = record("mid:persistent-post")
+		mid.PreRun = record("mid:pre") // not inherited
+		app.Root.AddCommand(mid)
+		return app
+	}
+
+	t.Run("full chain", func(t *testing.T) {
+		var calls []string
+		if err := newApp(&calls, "").Run(context.Background(), []string{"mid", "leaf"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		want := []string{
+			"root:persistent-pre", "mid:persistent-pre", "leaf:persistent-pre",
+			"leaf:pre", "leaf:run", "leaf:post",
+			"leaf:persistent-post", "mid:persistent-post", "root:persistent-post",
+		}
+		if strings.Join(calls, ",") != strings.Join(want, ",") {
+			t.Errorf("unexpected order:\n got  %v\n want %v", calls, want)
+		}
+	})
+
+	t.Run("error short-circuits", func(t *testing.T) {
+		var calls []string
+		err := newApp(&calls, "mid:persistent-pre").Run(context.Background(), []string{"mid", "leaf"})
+		if err == nil || err.Error() != "failed at mid:persistent-pre" {
+			t.Fatalf("expected hook error, got %v", err)
+		}
+		want := []string{"root:persistent-pre", "mid:persistent-pre"}
+		if strings.Join(calls, ",") != strings.Join(want, ",") {
+			t.Errorf("unexpected order:\n got  %v\n want %v", calls, want)
+		}
+	})
+}
+
+func TestDeprecatedCommand(t *testing.T) {
+	newApp := func(noRun bool, ran *int) (*App, *bytes.Buffer) {
+		app := NewApp("demo")
+		app.configLoaded = true
+		var errOut bytes.Buffer
+		app.Err = &errOut
+		app.Out = &bytes.Buffer{}
+
+		old := NewCommand("oldcmd", WithCommandDeprecated(`use "newcmd"`))
+		old.Short = "Old way"
+		old.DeprecatedNoRun = noRun
+		old.Run = func(ctx *Context) error {
+			*ran++
+			return nil
+		}
+		app.Root.AddCommand(old)
+		return app, &errOut
+	}
+
+	t.Run("notice once and still runs", func(t *testing.T) {
+		var ran int
+		app, errOut := newApp(false, &ran)
+		if err := app.Run(context.Background(), []string{"oldcmd"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if ran != 1 {
+			t.Errorf("expected command to run once, ran %d times", ran)
+		}
+		notice := `Command "oldcmd" is deprecated: use "newcmd"`
+		if got := strings.Count(errOut.String(), notice); got != 1 {
+			t.Errorf("expected notice once, got %d in %q", got, errOut.String())
+		}
+	})
+
+	t.Run("no run", func(t *testing.T) {
+		var ran int
+		app, errOut := newApp(true, &ran)
+		if err := app.Run(context.Background(), []string{"oldcmd"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if ran != 0 {
+			t.Errorf("expected command not to run, ran %d times", ran)
+		}
+		if !strings.Contains(errOut.String(), "is deprecated") {
+			t.Errorf("expected notice, got %q", errOut.String())
+		}
+	})
+
+	t.Run("marked in help", func(t *testing.T) {
+		var ran int
+		app, _ := newApp(false, &ran)
+		var out bytes.Buffer
+		if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		if !strings.Contains(out.String(), "Old way (deprecated)") {
+			t.Errorf("expected deprecated marker in help, got:\n%s", out.String())
+		}
+	})
+}
+
+func TestAddCommandErrDetectsConflicts(t *testing.T) {
+	newParent := func() *Command {
+		parent := NewGroup("project", "Manage projects")
+		list := NewCommand("list")
+		list.Aliases = []string{"ls"}
+		parent.AddCommand(list)
+		return parent
+	}
+
+	tests := []struct {
+		name    string
+		child   *Command
+		wantErr string
+	}{
+		{"duplicate name", NewCommand("list"), `cannot add command "list" to project: name "list" is already used by command "list"`},
+		{"duplicate name ignoring case", NewCommand("LIST"), `name "LIST" is already used by command "list"`},
+		{"name matches existing alias", NewCommand("ls"), `name "ls" is already an alias of command "list"`},
+		{"alias matches existing name", &Command{Name: "show", Aliases: []string{"list"}}, `name "list" is already used by command "list"`},
+		{"alias matches existing alias", &Command{Name: "dir", Aliases: []string{"ls"}}, `name "ls" is already an alias of command "list"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := newParent()
+			err := parent.AddCommandErr(tt.child)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("AddCommandErr() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(parent.Children) != 1 {
+				t.Errorf("expected conflicting command not to be added, have %d children", len(parent.Children))
+			}
+		})
+	}
+
+	t.Run("distinct command is added", func(t *testing.T) {
+		parent := newParent()
+		create := &Command{Name: "create", Aliases: []string{"new"}}
+		if err := parent.AddCommandErr(create); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(parent.Children) != 2 || create.parent != parent {
+			t.Errorf("expected create to be registered under project")
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// completeCommandName is the hidden command shell completion scripts invoke
+// to fetch dynamic candidates:
+//
+//	myapp __complete project list --project ""
+//
+// Every argument but the last is a word already on the command line; the last
+// is the word being completed (possibly empty). App.Run handles it before any
+// flag parsing, so half-typed command lines never fail with parse errors, and
+// it never appears in help or completion output.
+const completeCommandName = "__complete"
+
+// runComplete resolves the command path in args, invokes the relevant Flag
+// Complete function and prints the candidates to a.Out, one per line.
+func (a *App) runComplete(ctx context.Context, args []string) error {
+	if err := a.ensureConfigLoaded(ctx); err != nil {
+		return err
+	}
+
+	var toComplete string
+	words := args
+	if len(args) > 0 {
+		words, toComplete = args[:len(args)-1], args[len(args)-1]
+	}
+
+	state := a.completionState(words)
+	runCtx := &Context{Context: ctx, App: a, Command: state.cmd}
+
+	var candidates []string
+	switch {
+	case state.pending != nil:
+		candidates = completeFlag(runCtx, state.pending, toComplete)
+	case strings.HasPrefix(toComplete, "-") && !state.dashdash:
+		if name, value, ok := strings.Cut(toComplete, "="); ok {
+			if flag := state.findFlag(name); flag != nil {
+				for _, c := range completeFlag(runCtx, flag, value) {
+					candidates = append(candidates, name+"="+c)
+				}
+			}
+			break
+		}
+		candidates = state.flagNames(toComplete)
+	default:
+		if state.positionals == 0 && !state.dashdash {
+			for _, child := range state.cmd.VisibleChildren() {
+				for _, name := range append([]string{child.Name}, child.Aliases...) {
+					if strings.HasPrefix(name, toComplete) {
+						candidates = append(candidates, name)
+					}
+				}
+			}
+		}
+		if flag := state.positional(); flag != nil {
+			candidates = append(candidates, completeFlag(runCtx, flag, toComplete)...)
+		}
+	}
+
+	for _, c := range candidates {
+		if _, err := fmt.Fprintln(a.Out, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeFlag calls the flag's Complete function, if any.
+func completeFlag(ctx *Context, flag *Flag, toComplete string) []string {
+	if flag.Complete == nil {
+		return nil
+	}
+	return flag.Complete(ctx, toComplete)
+}
+
+// completion tracks how far a partial command line has been consumed.
+type completion struct {
+	root        *FlagSet
+	cmd         *Command
+	flags       *FlagSet       // cmd's flags plus the persistent flags it inherits
+	pending     *Flag          // flag whose value is being completed
+	named       map[*Flag]bool // positional flags already given by name
+	positionals int
+	dashdash    bool
+}
+
+// completionState walks the words before the one being completed, descending
+// into subcommands and skipping flags and their values.
+func (a *App) completionState(words []string) *completion {
+	state := &completion{root: a.Flags(), cmd: a.Root, flags: a.Root.parseFlags(), named: make(map[*Flag]bool)}
+	for _, word := range words {
+		if state.pending != nil {
+			state.pending = nil
+			continue
+		}
+		if word == "--" && !state.dashdash {
+			state.dashdash = true
+			continue
+		}
+		if strings.HasPrefix(word, "-") && word != "-" && !state.dashdash {
+			name, _, hasValue := strings.Cut(word, "=")
+			flag := state.findFlag(name)
+			if flag == nil {
+				continue
+			}
+			state.named[flag] = true
+			if _, isBool := flag.Value.(boolFlag); !isBool && !hasValue {
+				state.pending = flag
+			}
+			continue
+		}
+		if state.positionals == 0 && !state.dashdash {
+			if child, _ := state.cmd.lookupChild(word); child != nil {
+				state.cmd = child
+				state.flags = child.parseFlags()
+				continue
+			}
+		}
+		state.positionals++
+	}
+	return state
+}
+
+// findFlag looks up a flag token such as "--name" or "-n" on the current
+// command, including the persistent flags it inherits, then on the root.
+func (s *completion) findFlag(name string) *Flag {
+	if s.flags != nil {
+		if flag, ok := s.flags.index[name]; ok {
+			return flag
+		}
+	}
+	if flag, ok := s.root.index[name]; ok {
+		return flag
+	}
+	return nil
+}
+
+// flagNames returns the visible long flag names of the current command, the
+// persistent flags it inherits and the root that start with prefix.
+func (s *completion) flagNames(prefix string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, fs := range []*FlagSet{s.flags, s.root} {
+		if fs == nil {
+			continue
+		}
+		for _, flag := range fs.flags {
+			if flag.Hidden {
+				continue
+			}
+			for _, name := range append([]string{flag.Name}, flag.Aliases...) {
+				long := "--" + name
+				if !seen[long] && strings.HasPrefix(long, prefix) {
+					seen[long] = true
+					names = append(names, long)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// positional returns the positional flag the next argument maps to, following
+// the same rules as MapPositionals.
+func (s *completion) positional() *Flag {
+	if s.cmd.Flags == nil {
+		return nil
+	}
+	index := 0
+	for _, flag := range s.cmd.Flags.PositionalFlags() {
+		if s.named[flag] {
+			continue
+		}
+		if index == s.positionals || flag.Variadic && index < s.positionals {
+			return flag
+		}
+		index++
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func newCompleteTestApp() *App {
+	app := NewApp("demo")
+	app.configLoaded = true
+
+	deploy := NewCommand("deploy")
+	deploy.Aliases = []string{"d"}
+	var project, region, target string
+	var force bool
+	deploy.Flags.StringVar(
+		WithFlagName("project"),
+		WithFlagShort("p"),
+		WithStringValue(&project),
+		WithFlagComplete(func(ctx *Context, toComplete string) []string {
+			var out []string
+			for _, id := range []string{"alpha", "apollo", "beta"} {
+				if strings.HasPrefix(id, toComplete) {
+					out = append(out, id)
+				}
+			}
+			return out
+		}),
+	)
+	deploy.Flags.BoolVar(WithFlagName("force"), WithBoolValue(&force))
+	deploy.Flags.StringVar(
+		WithFlagName("target"),
+		WithFlagPositional(),
+		WithStringValue(&target),
+		WithFlagComplete(func(ctx *Context, toComplete string) []string {
+			return []string{"staging", "production"}
+		}),
+	)
+	deploy.Flags.StringVar(
+		WithFlagName("region"),
+		WithFlagPositional(),
+		WithStringValue(&region),
+		WithFlagComplete(func(ctx *Context, toComplete string) []string {
+			return []string{"us-east-" + ctx.Command.Name}
+		}),
+	)
+	deploy.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(deploy)
+	return app
+}
+
+func runComplete(t *testing.T, app *App, args ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	app.Out = &out
+	if err := app.Run(context.Background(), append([]string{"__complete"}, args...)); err != nil {
+		t.Fatalf("__complete %v failed: %v", args, err)
+	}
+	return strings.Fields(out.String())
+}
+
+func TestCompleteFlagValue(t *testing.T) {
+	app := newCompleteTestApp()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"deploy", "--project", "a"}, "alpha apollo"},
+		{[]string{"d", "-p", ""}, "alpha apollo beta"},
+		{[]string{"deploy", "--project=b"}, "--project=beta"},
+		{[]string{"deploy", "--force", "--project", "ap"}, "apollo"},
+		{[]string{"deploy", "--fo"}, "--force"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(runComplete(t, app, tt.args...), " ")
+		if got != tt.want {
+			t.Errorf("__complete %v = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteArgumentPosition(t *testing.T) {
+	app := newCompleteTestApp()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{""}, "deploy d"},
+		{[]string{"deploy", ""}, "staging production"},
+		{[]string{"deploy", "staging", ""}, "us-east-deploy"},
+		{[]string{"deploy", "--target", "staging", ""}, "us-east-deploy"},
+		{[]string{"deploy", "staging", "us", ""}, ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(runComplete(t, app, tt.args...), " ")
+		if got != tt.want {
+			t.Errorf("__complete %v = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteInheritedPersistentFlag(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+
+	var env string
+	list := NewCommand("list")
+	list.Run = func(ctx *Context) error { return nil }
+	project := NewGroup("project", "Manage projects", list)
+	project.PersistentFlags.StringVar(
+		WithFlagName("env"),
+		WithStringValue(&env),
+		WithFlagComplete(func(ctx *Context, toComplete string) []string {
+			return []string{"dev", "prod"}
+		}),
+	)
+	app.Root.AddCommand(project)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"project", "list", "--e"}, "--env"},
+		{[]string{"project", "list", "--env", ""}, "dev prod"},
+		{[]string{"project", "list", "--env=p"}, "--env=dev --env=prod"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(runComplete(t, app, tt.args...), " ")
+		if got != tt.want {
+			t.Errorf("__complete %v = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
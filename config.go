@@ -1,21 +1,25 @@
 package clix
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
 
-// ConfigManager loads and stores configuration from YAML files and environment variables.
+// ConfigManager loads and stores configuration from YAML, TOML or JSON files and environment variables.
 // Configuration values are automatically loaded when App.Run is called
 // and are accessible via Context getters with precedence: command flags > app flags > env > config > defaults.
-// The config file uses YAML format. Nested structures are flattened using dot notation
-// (e.g., "project.name" for nested "project: name: value").
+// The file format is chosen by extension (see Load). Nested structures are flattened using
+// dot notation (e.g., "project.name" for nested "project: name: value").
 //
 // Example:
 //
@@ -28,9 +32,14 @@ import (
 //		}
 //		return nil
 //	}
+//
+// A ConfigManager is safe for concurrent use, so Watch can reload it while
+// App.Run reads from it.
 type ConfigManager struct {
+	mu      sync.RWMutex // guards values, schemas and origins
 	values  map[string]string
 	schemas map[string]ConfigSchema
+	origins map[string]string // file each loaded value came from
 }
 
 // ConfigType represents the desired type for a configuration value.
@@ -118,30 +127,149 @@ func NewConfigManager(name string) *ConfigManager {
 }
 
 // Load reads configuration from the provided path. Missing files are ignored.
-// The file format is YAML. Nested structures are flattened using dot notation.
+// The format follows the file extension: ".toml" for TOML, ".json" for JSON,
+// and YAML for ".yaml", ".yml" or anything else. Nested structures are
+// flattened using dot notation.
 func (m *ConfigManager) Load(path string) error {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	m.merge(path, values)
+	return nil
+}
+
+// readConfigFile reads and flattens the file at path. A missing file yields
+// no values.
+func readConfigFile(path string) (map[string]string, error) {
+	values := make(map[string]string)
 	file, err := os.Open(path)
 	if errors.Is(err, os.ErrNotExist) {
-		return nil
+		return values, nil
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer file.Close()
 
-	var data map[string]interface{}
-	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&data); err != nil {
-		return fmt.Errorf("failed to parse config file as YAML: %w", err)
+	format := configFormat(path)
+	data, err := decodeConfig(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file as %s: %w", format, err)
 	}
+	flattenYAML("", data, values)
+	return values, nil
+}
+
+// merge stores values loaded from path, recording path as their origin.
+func (m *ConfigManager) merge(path string, values map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store(path, values)
+}
 
+// store is merge for callers that hold m.mu.
+func (m *ConfigManager) store(path string, values map[string]string) {
 	if m.values == nil {
 		m.values = make(map[string]string)
 	}
-	flattenYAML("", data, m.values)
+	if m.origins == nil {
+		m.origins = make(map[string]string)
+	}
+	for k, v := range values {
+		m.values[k] = v
+		m.origins[k] = path
+	}
+}
+
+// LoadLayered loads each path in order, so keys in later files override the
+// same keys from earlier ones. Missing files are ignored. Use Origin to find
+// out which file a value came from.
+//
+// Example:
+//
+//	err := app.Config.LoadLayered(
+//		"/etc/myapp/config.yaml",
+//		filepath.Join(home, ".config", "myapp", "config.yaml"),
+//		".myapp.yaml",
+//	)
+func (m *ConfigManager) LoadLayered(paths ...string) error {
+	for _, path := range paths {
+		if err := m.Load(path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
 	return nil
 }
 
+// Origin reports the path of the file that key was loaded from. It returns
+// false for keys that are unset or were set in code with Set.
+func (m *ConfigManager) Origin(key string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	path, ok := m.origins[key]
+	return path, ok
+}
+
+// Config file formats, selected by file extension.
+const (
+	configYAML = "YAML"
+	configTOML = "TOML"
+	configJSON = "JSON"
+)
+
+// configFormat returns the config format for path, defaulting to YAML.
+func configFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return configTOML
+	case ".json":
+		return configJSON
+	default:
+		return configYAML
+	}
+}
+
+// decodeConfig decodes r into nested maps.
+func decodeConfig(r io.Reader, format string) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	switch format {
+	case configTOML:
+		return decodeTOML(r)
+	case configJSON:
+		decoder := json.NewDecoder(r)
+		// Keep numbers as written instead of float64 (1e+06 for 1000000)
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			return nil, err
+		}
+	default:
+		if err := yaml.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// encodeConfig writes data to w in the given format.
+func encodeConfig(w io.Writer, format string, data map[string]interface{}) error {
+	switch format {
+	case configTOML:
+		return encodeTOML(w, data)
+	case configJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	default:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+}
+
 // flattenYAML recursively flattens a nested YAML structure into dot-notation keys.
 func flattenYAML(prefix string, data map[string]interface{}, result map[string]string) {
 	for key, value := range data {
@@ -168,12 +296,22 @@ func flattenYAML(prefix string, data map[string]interface{}, result map[string]s
 	}
 }
 
-// Save writes the configuration to the provided path in YAML format.
-// Dot-notation keys (e.g., "project.name") are saved as flat key:value pairs.
+// Save writes the configuration to the provided path, in the format that
+// Load would read for its extension. Dot-notation keys are expanded back into
+// nested structures ("project.name" is saved as "project: name: value"), so a
+// file keeps its shape across Load and Save. Save fails without writing if a
+// key is also the prefix of another key (e.g. "project" and "project.name").
 func (m *ConfigManager) Save(path string) error {
+	m.mu.RLock()
 	if m.values == nil {
+		m.mu.RUnlock()
 		return nil
 	}
+	data, err := expandKeys(m.values)
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
 
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -187,15 +325,7 @@ func (m *ConfigManager) Save(path string) error {
 	}
 	defer file.Close()
 
-	// Convert flat map[string]string to map[string]interface{} for YAML encoding
-	data := make(map[string]interface{})
-	for k, v := range m.values {
-		data[k] = v
-	}
-
-	encoder := yaml.NewEncoder(file)
-	encoder.SetIndent(2)
-	if err := encoder.Encode(data); err != nil {
+	if err := encodeConfig(file, configFormat(path), data); err != nil {
 		file.Close()
 		return err
 	}
@@ -207,28 +337,80 @@ func (m *ConfigManager) Save(path string) error {
 	return os.Rename(tmp, path)
 }
 
+// expandKeys turns dot-notation keys into nested maps, the inverse of flattenYAML.
+func expandKeys(values map[string]string) (map[string]interface{}, error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// Sorted, a key always comes before the keys it prefixes ("a" < "a.b"),
+	// so a conflict shows up as a value where a section is needed.
+	data := make(map[string]interface{})
+	for _, key := range keys {
+		parts := strings.Split(key, ".")
+		table := data
+		for i, part := range parts[:len(parts)-1] {
+			prefix := strings.Join(parts[:i+1], ".")
+			switch existing := table[part].(type) {
+			case nil:
+				next := make(map[string]interface{})
+				table[part] = next
+				table = next
+			case map[string]interface{}:
+				table = existing
+			default:
+				return nil, fmt.Errorf("config keys %q and %q conflict: %q cannot be both a value and a section", prefix, key, prefix)
+			}
+		}
+		table[parts[len(parts)-1]] = values[key]
+	}
+	return data, nil
+}
+
 // Get retrieves a value.
 func (m *ConfigManager) Get(key string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	value, ok := m.values[key]
 	return value, ok
 }
 
 // Set stores a value.
 func (m *ConfigManager) Set(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.values == nil {
 		m.values = make(map[string]string)
 	}
 	m.values[key] = value
+	delete(m.origins, key)
+}
+
+// SetNormalized normalizes value with NormalizeValue and stores the result,
+// so values written through it are always canonical for their schema.
+// Nothing is stored if the value is invalid.
+func (m *ConfigManager) SetNormalized(key, value string) error {
+	normalized, err := m.NormalizeValue(key, value)
+	if err != nil {
+		return err
+	}
+	m.Set(key, normalized)
+	return nil
 }
 
 // Delete removes a key from the configuration. It returns true if the key existed.
 // Keys are stored using dot-separated paths (e.g. "project.default").
 func (m *ConfigManager) Delete(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.values == nil {
 		return false
 	}
 	if _, ok := m.values[key]; ok {
 		delete(m.values, key)
+		delete(m.origins, key)
 		return true
 	}
 	return false
@@ -236,11 +418,16 @@ func (m *ConfigManager) Delete(key string) bool {
 
 // Reset removes all values.
 func (m *ConfigManager) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.values = make(map[string]string)
+	m.origins = nil
 }
 
 // Values returns a copy of the stored values.
 func (m *ConfigManager) Values() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	copy := make(map[string]string, len(m.values))
 	for k, v := range m.values {
 		copy[k] = v
@@ -274,6 +461,8 @@ func (m *ConfigManager) Values() map[string]string {
 //		clix.WithConfigType(clix.ConfigInt),
 //	)
 func (m *ConfigManager) RegisterSchema(entries ...ConfigSchemaOption) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.schemas == nil {
 		m.schemas = make(map[string]ConfigSchema)
 	}
@@ -295,7 +484,9 @@ func (m *ConfigManager) RegisterSchema(entries ...ConfigSchemaOption) {
 // NormalizeValue validates and canonicalises a value according to the schema (if present).
 // The returned string is safe to persist. When no schema exists, the original value is returned.
 func (m *ConfigManager) NormalizeValue(key, value string) (string, error) {
+	m.mu.RLock()
 	entry, ok := m.schemas[key]
+	m.mu.RUnlock()
 	if !ok {
 		// No schema registered; still run validator if present (unlikely) but keep as-is.
 		if entry.Validate != nil {
@@ -346,13 +537,12 @@ func (m *ConfigManager) NormalizeValue(key, value string) (string, error) {
 
 // String retrieves a raw string value directly from persisted config.
 func (m *ConfigManager) String(key string) (string, bool) {
-	value, ok := m.values[key]
-	return value, ok
+	return m.Get(key)
 }
 
 // Bool retrieves a boolean value from persisted config.
 func (m *ConfigManager) Bool(key string) (bool, bool) {
-	value, ok := m.values[key]
+	value, ok := m.Get(key)
 	if !ok {
 		return false, false
 	}
@@ -365,7 +555,7 @@ func (m *ConfigManager) Bool(key string) (bool, bool) {
 
 // Int retrieves an int value from persisted config.
 func (m *ConfigManager) Int(key string) (int, bool) {
-	value, ok := m.values[key]
+	value, ok := m.Get(key)
 	if !ok {
 		return 0, false
 	}
@@ -378,7 +568,7 @@ func (m *ConfigManager) Int(key string) (int, bool) {
 
 // Int64 retrieves an int64 value from persisted config.
 func (m *ConfigManager) Int64(key string) (int64, bool) {
-	value, ok := m.values[key]
+	value, ok := m.Get(key)
 	if !ok {
 		return 0, false
 	}
@@ -391,7 +581,7 @@ func (m *ConfigManager) Int64(key string) (int64, bool) {
 
 // Float64 retrieves a float64 value from persisted config.
 func (m *ConfigManager) Float64(key string) (float64, bool) {
-	value, ok := m.values[key]
+	value, ok := m.Get(key)
 	if !ok {
 		return 0, false
 	}
@@ -402,6 +592,40 @@ func (m *ConfigManager) Float64(key string) (float64, bool) {
 	return parsed, true
 }
 
+// Typed retrieves a value converted to the Go type of its registered schema:
+// bool for ConfigBool, int for ConfigInt, int64 for ConfigInt64, float64 for
+// ConfigFloat64 and string otherwise. Keys without a schema return the raw
+// string. It returns false if the key is unset or the stored value does not
+// parse as the schema's type.
+//
+// Example:
+//
+//	app.Config.RegisterSchema(clix.ConfigSchema{Key: "retries", Type: clix.ConfigInt})
+//	if v, ok := app.Config.Typed("retries"); ok {
+//		retries := v.(int)
+//	}
+func (m *ConfigManager) Typed(key string) (any, bool) {
+	value, ok := m.Get(key)
+	if !ok {
+		return nil, false
+	}
+	m.mu.RLock()
+	typ := m.schemas[key].Type
+	m.mu.RUnlock()
+	switch typ {
+	case ConfigBool:
+		return m.Bool(key)
+	case ConfigInt:
+		return m.Int(key)
+	case ConfigInt64:
+		return m.Int64(key)
+	case ConfigFloat64:
+		return m.Float64(key)
+	default:
+		return value, true
+	}
+}
+
 // Functional option helpers for config schemas
 
 // WithConfigKey sets the config schema key.
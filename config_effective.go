@@ -0,0 +1,69 @@
+package clix
+
+import "fmt"
+
+// EffectiveValue is a resolved configuration value and where it came from.
+type EffectiveValue struct {
+	Value  string `json:"value" yaml:"value"`
+	Source Source `json:"source" yaml:"source"`
+}
+
+// String formats the value with its source, e.g. "us-east (environment variable)".
+func (v EffectiveValue) String() string {
+	return fmt.Sprintf("%s (%s)", v.Value, v.Source)
+}
+
+// MarshalText encodes the source by name, so JSON and YAML output read
+// "environment variable" instead of a number.
+func (s Source) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// EffectiveConfig resolves every key the current invocation knows about, the
+// flags of the command and the app plus every key stored in App.Config,
+// using the same precedence as EffectiveString. Keys that resolve to nothing
+// are left out. It is meant for debugging where a value came from:
+//
+//	for key, v := range ctx.EffectiveConfig() {
+//		fmt.Fprintf(ctx.App.Err, "%s = %s\n", key, v)
+//	}
+func (ctx *Context) EffectiveConfig() map[string]EffectiveValue {
+	result := make(map[string]EffectiveValue)
+	bound := make(map[string]bool)
+
+	var sets []*FlagSet
+	if ctx.Command != nil && ctx.Command.Flags != nil {
+		sets = append(sets, ctx.Command.Flags)
+	}
+	if ctx.Command != nil {
+		sets = append(sets, ctx.Command.persistentSets()...)
+	}
+	if ctx.App != nil {
+		sets = append(sets, ctx.App.Flags())
+	}
+	for _, fs := range sets {
+		for _, flag := range fs.flags {
+			if _, done := result[flag.Name]; done {
+				continue
+			}
+			if ctx.App != nil {
+				bound[ctx.App.configKey(flag.Name)] = true
+			}
+			if value, source, ok := ctx.resolveValue(flag.Name); ok {
+				result[flag.Name] = EffectiveValue{Value: value, Source: source}
+			}
+		}
+	}
+
+	if ctx.App != nil && ctx.App.Config != nil {
+		for key := range ctx.App.Config.Values() {
+			if _, done := result[key]; done || bound[key] {
+				continue
+			}
+			if value, source, ok := ctx.resolveValue(key); ok {
+				result[key] = EffectiveValue{Value: value, Source: source}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestEffectiveConfigReportsSources(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("TOOL_REGION", "eu-west")
+
+	app := NewApp("tool")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	var region, zone, project string
+	app.Flags().StringVar(WithFlagName("region"), WithStringValue(&region))
+	app.Flags().StringVar(WithFlagName("zone"), WithStringValue(&zone), WithStringDefault("a"))
+	app.Config.Set("api.timeout", "30")
+
+	var got map[string]EffectiveValue
+	cmd := NewCommand("deploy")
+	cmd.Flags.StringVar(WithFlagName("project"), WithStringValue(&project))
+	cmd.Run = func(ctx *Context) error {
+		got = ctx.EffectiveConfig()
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"deploy", "--project", "demo"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := map[string]EffectiveValue{
+		"project":     {Value: "demo", Source: SourceCommandFlag},
+		"region":      {Value: "eu-west", Source: SourceEnvVar},
+		"zone":        {Value: "a", Source: SourceDefault},
+		"api.timeout": {Value: "30", Source: SourceConfigFile},
+	}
+	for key, w := range want {
+		if got[key] != w {
+			t.Errorf("%s = %+v, want %+v", key, got[key], w)
+		}
+	}
+	if _, ok := got["help"]; ok {
+		t.Errorf("unset help flag reported: %+v", got["help"])
+	}
+}
+
+func TestEffectiveValueJSON(t *testing.T) {
+	data, err := json.Marshal(EffectiveValue{Value: "eu-west", Source: SourceEnvVar})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"value":"eu-west","source":"environment variable"}`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
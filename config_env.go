@@ -0,0 +1,162 @@
+package clix
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadEnvFile reads a .env file of KEY=value lines and stores each value
+// under EnvFileKey(KEY), so DB_HOST=localhost becomes "db.host". Loaded values
+// take the config file's place in the precedence chain, and Origin reports
+// path for them. Missing files are ignored.
+//
+// The format follows common .env conventions:
+//
+//	# comments and blank lines are ignored
+//	export API_URL=https://example.com   # "export" prefixes are allowed
+//	GREETING="hello\nworld"              # double quotes support \n, \t, \" and \\
+//	PATTERN='literal $value'             # single quotes are taken verbatim
+func (m *ConfigManager) LoadEnvFile(path string) error {
+	return m.LoadEnvFileMapped(path, EnvFileKey)
+}
+
+// LoadEnvFileMapped is like LoadEnvFile but stores each variable under
+// key(NAME). Returning "" from key skips the variable.
+//
+// Example:
+//
+//	// Only MYAPP_* variables, without the prefix: MYAPP_DB_HOST -> "db.host"
+//	err := app.Config.LoadEnvFileMapped(".env", func(name string) string {
+//		if rest, ok := strings.CutPrefix(name, "MYAPP_"); ok {
+//			return clix.EnvFileKey(rest)
+//		}
+//		return ""
+//	})
+func (m *ConfigManager) LoadEnvFileMapped(path string, key func(name string) string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		name, value, ok, err := parseEnvLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("failed to parse env file: line %d: %w", line, err)
+		}
+		if !ok {
+			continue
+		}
+		if k := key(name); k != "" {
+			values[k] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	m.merge(path, values)
+	return nil
+}
+
+// EnvFileKey maps an environment variable name to a config key by lowercasing
+// it and turning underscores into dots: DB_HOST becomes "db.host".
+func EnvFileKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", ".")
+}
+
+// parseEnvLine parses one .env line. ok is false for blank and comment lines.
+func parseEnvLine(line string) (name, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+	if rest, found := strings.CutPrefix(line, "export"); found && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		line = strings.TrimSpace(rest)
+	}
+
+	name, raw, found := strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", false, errors.New("expected KEY=value")
+	}
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		value, rest, err := unquoteEnvValue(raw[1:])
+		if err != nil {
+			return "", "", false, err
+		}
+		if err := envTrailer(rest); err != nil {
+			return "", "", false, err
+		}
+		return name, value, true, nil
+	case strings.HasPrefix(raw, "'"):
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", "", false, errors.New("unterminated single-quoted value")
+		}
+		if err := envTrailer(raw[end+2:]); err != nil {
+			return "", "", false, err
+		}
+		return name, raw[1 : end+1], true, nil
+	default:
+		// An unquoted value ends at a comment preceded by whitespace
+		for i := 1; i < len(raw); i++ {
+			if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+				raw = strings.TrimSpace(raw[:i])
+				break
+			}
+		}
+		return name, raw, true, nil
+	}
+}
+
+// unquoteEnvValue reads a double-quoted value up to the closing quote and
+// returns it along with the text after the quote.
+func unquoteEnvValue(s string) (string, string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			if i+1 == len(s) {
+				return "", "", errors.New("unterminated double-quoted value")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				// \" \\ \$ and unknown escapes keep the escaped character
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated double-quoted value")
+}
+
+// envTrailer checks that only whitespace or a comment follows a quoted value.
+func envTrailer(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected %q after quoted value", rest)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeEnvFile(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+	return path
+}
+
+func TestConfigManagerLoadEnvFile(t *testing.T) {
+	path := writeEnvFile(t,
+		"# database settings",
+		"",
+		"DB_HOST=localhost",
+		"DB_PORT = 5432   # inline comment",
+		"export API_URL=https://example.com/#anchor",
+		"export\tREGION=us-east-1",
+		`GREETING="hello\nworld \"quoted\"" # trailing comment`,
+		`PATTERN='literal $HOME \n'`,
+		`EMPTY=`,
+	)
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.LoadEnvFile(path); err != nil {
+		t.Fatalf("LoadEnvFile failed: %v", err)
+	}
+
+	want := map[string]string{
+		"db.host":  "localhost",
+		"db.port":  "5432",
+		"api.url":  "https://example.com/#anchor",
+		"region":   "us-east-1",
+		"greeting": "hello\nworld \"quoted\"",
+		"pattern":  `literal $HOME \n`,
+		"empty":    "",
+	}
+	if got := mgr.Values(); len(got) != len(want) {
+		t.Fatalf("expected %d keys, got %v", len(want), got)
+	}
+	for key, value := range want {
+		if got, ok := mgr.Get(key); !ok || got != value {
+			t.Errorf("value mismatch for %q: want %q, got %q", key, value, got)
+		}
+	}
+	if origin, _ := mgr.Origin("db.host"); origin != path {
+		t.Errorf("expected origin %q, got %q", path, origin)
+	}
+}
+
+func TestConfigManagerLoadEnvFileMapped(t *testing.T) {
+	path := writeEnvFile(t, "MYAPP_DB_HOST=db", "OTHER=ignored")
+
+	mgr := NewConfigManager("demo")
+	err := mgr.LoadEnvFileMapped(path, func(name string) string {
+		if rest, ok := strings.CutPrefix(name, "MYAPP_"); ok {
+			return EnvFileKey(rest)
+		}
+		return ""
+	})
+	if err != nil {
+		t.Fatalf("LoadEnvFileMapped failed: %v", err)
+	}
+	if got := mgr.Values(); len(got) != 1 || got["db.host"] != "db" {
+		t.Fatalf("expected only db.host=db, got %v", got)
+	}
+}
+
+func TestConfigManagerLoadEnvFileErrors(t *testing.T) {
+	for name, line := range map[string]string{
+		"missing equals":   "JUST_A_NAME",
+		"unterminated":     `VALUE="open`,
+		"text after quote": `VALUE="a" b`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeEnvFile(t, "OK=1", line)
+			err := NewConfigManager("demo").LoadEnvFile(path)
+			if err == nil || !strings.Contains(err.Error(), "line 2") {
+				t.Fatalf("expected an error on line 2, got %v", err)
+			}
+		})
+	}
+
+	if err := NewConfigManager("demo").LoadEnvFile(filepath.Join(t.TempDir(), "missing.env")); err != nil {
+		t.Fatalf("expected a missing file to be ignored, got %v", err)
+	}
+}
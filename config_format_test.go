@@ -0,0 +1,234 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigManagerLoadTOML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	content := strings.Join([]string{
+		"# comment should be ignored",
+		`title = "demo"`,
+		"debug = true",
+		"",
+		"[project]",
+		`name = "clix"`,
+		"retries = 3",
+		"ratio = 0.5",
+		"tags = [\"a\", 'b']",
+		"",
+		"[project.owner]",
+		`email = "dev@example.com" # trailing comment`,
+		`"display name" = 'Dev Team'`,
+		"",
+		"[server]",
+		"port = 8_080",
+		`limits = { cpu = 2, memory = "1Gi" }`,
+		`db.host = "localhost"`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	want := map[string]string{
+		"title":                      "demo",
+		"debug":                      "true",
+		"project.name":               "clix",
+		"project.retries":            "3",
+		"project.ratio":              "0.5",
+		"project.tags":               "a,b",
+		"project.owner.email":        "dev@example.com",
+		"project.owner.display name": "Dev Team",
+		"server.port":                "8080",
+		"server.limits.cpu":          "2",
+		"server.limits.memory":       "1Gi",
+		"server.db.host":             "localhost",
+	}
+	if got := mgr.Values(); len(got) != len(want) {
+		t.Fatalf("expected %d keys, got %v", len(want), got)
+	}
+	for key, value := range want {
+		if got, ok := mgr.Get(key); !ok || got != value {
+			t.Errorf("value mismatch for %q: want %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestConfigManagerLoadTOMLDatesAndArraysOfTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := strings.Join([]string{
+		"released = 2024-05-01T10:30:00Z",
+		"day = 2024-05-01",
+		"",
+		"[[servers]]",
+		`name = "a"`,
+		"",
+		"[[servers]]",
+		`name = "b"`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	for key, want := range map[string]string{
+		"released": "2024-05-01T10:30:00Z",
+		"day":      "2024-05-01",
+	} {
+		if got, ok := mgr.Get(key); !ok || got != want {
+			t.Errorf("value mismatch for %q: want %q, got %q", key, want, got)
+		}
+	}
+	if _, ok := mgr.Get("servers"); !ok {
+		t.Errorf("expected the servers array of tables to load, got %v", mgr.Values())
+	}
+}
+
+func TestConfigManagerLoadJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := `{
+  "project": {"name": "clix", "retries": 1000000, "owner": {"email": "dev@example.com"}},
+  "debug": false,
+  "tags": ["a", "b"]
+}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	want := map[string]string{
+		"project.name":        "clix",
+		"project.retries":     "1000000",
+		"project.owner.email": "dev@example.com",
+		"debug":               "false",
+		"tags":                "a,b",
+	}
+	for key, value := range want {
+		if got, ok := mgr.Get(key); !ok || got != value {
+			t.Errorf("value mismatch for %q: want %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestConfigManagerFormatRoundTrip(t *testing.T) {
+	for _, name := range []string{"config.toml", "config.json", "config.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			mgr := NewConfigManager("demo")
+			mgr.Set("project.name", `say "hi"`)
+			mgr.Set("port", "8080")
+
+			if err := mgr.Save(path); err != nil {
+				t.Fatalf("save failed: %v", err)
+			}
+			reload := NewConfigManager("demo")
+			if err := reload.Load(path); err != nil {
+				t.Fatalf("reload failed: %v", err)
+			}
+			for key, want := range mgr.Values() {
+				if got, ok := reload.Get(key); !ok || got != want {
+					t.Errorf("round-trip mismatch for %q: want %q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigManagerLoadTOMLErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"duplicate key":   "a = 1\na = 2\n",
+		"redefined table": "[a]\nx = 1\n[a]\ny = 2\n",
+		"missing value":   "a =\n",
+		"unterminated":    "a = \"open\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			err := NewConfigManager("demo").Load(path)
+			if err == nil || !strings.Contains(err.Error(), "TOML") {
+				t.Fatalf("expected a TOML parse error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigManagerSaveNested(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := strings.Join([]string{
+		"project:",
+		"  name: clix",
+		"  owner:",
+		"    email: dev@example.com",
+		"region: us-east-1",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if err := mgr.Save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	reload := NewConfigManager("demo")
+	if err := reload.Load(path); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if got, want := reload.Values(), mgr.Values(); len(got) != len(want) {
+		t.Fatalf("expected %v after round-trip, got %v", want, got)
+	}
+	for key, want := range mgr.Values() {
+		if got, _ := reload.Get(key); got != want {
+			t.Errorf("round-trip mismatch for %q: want %q, got %q", key, want, got)
+		}
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read saved config: %v", err)
+	}
+	if strings.Contains(string(saved), "project.name") {
+		t.Errorf("expected nested keys on disk, got:\n%s", saved)
+	}
+	if !strings.Contains(string(saved), "project:\n") || !strings.Contains(string(saved), "\n    email: dev@example.com") {
+		t.Errorf("expected nested structure on disk, got:\n%s", saved)
+	}
+}
+
+func TestConfigManagerSaveConflictingKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	mgr := NewConfigManager("demo")
+	mgr.Set("project", "clix")
+	mgr.Set("project.name", "clix")
+
+	err := mgr.Save(path)
+	if err == nil || !strings.Contains(err.Error(), `"project" and "project.name" conflict`) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Errorf("expected no file to be written, got %v", statErr)
+	}
+}
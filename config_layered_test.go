@@ -0,0 +1,48 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigManagerLoadLayered(t *testing.T) {
+	dir := t.TempDir()
+	system := filepath.Join(dir, "system.yaml")
+	project := filepath.Join(dir, "project.toml")
+	if err := os.WriteFile(system, []byte("region: us-east-1\nproject:\n  name: base\n  retries: 3\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(project, []byte("[project]\nname = \"override\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.LoadLayered(system, filepath.Join(dir, "missing.yaml"), project); err != nil {
+		t.Fatalf("LoadLayered failed: %v", err)
+	}
+
+	tests := []struct {
+		key, value, origin string
+	}{
+		{"region", "us-east-1", system},
+		{"project.retries", "3", system},
+		{"project.name", "override", project},
+	}
+	for _, tt := range tests {
+		if got, _ := mgr.Get(tt.key); got != tt.value {
+			t.Errorf("value mismatch for %q: want %q, got %q", tt.key, tt.value, got)
+		}
+		if origin, ok := mgr.Origin(tt.key); !ok || origin != tt.origin {
+			t.Errorf("origin mismatch for %q: want %q, got %q", tt.key, tt.origin, origin)
+		}
+	}
+
+	mgr.Set("region", "eu-west-1")
+	if origin, ok := mgr.Origin("region"); ok {
+		t.Errorf("expected no origin after Set, got %q", origin)
+	}
+	if _, ok := mgr.Origin("unknown"); ok {
+		t.Error("expected no origin for an unknown key")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestConfigurationPrecedence(t *testing.T) {
@@ -81,3 +82,182 @@ func TestConfigurationPrecedence(t *testing.T) {
 		}
 	})
 }
+
+func TestEnvVarsHydrateFlags(t *testing.T) {
+	newApp := func(value *string, source *Source) *App {
+		app := NewApp("test")
+		app.configLoaded = true
+		root := NewCommand("test")
+		root.Flags.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{
+				Name:     "token",
+				EnvVar:   "TEST_TOKEN_PRIMARY",
+				EnvVars:  []string{"TEST_TOKEN_LEGACY", "TEST_TOKEN_OLD"},
+				Required: true,
+			},
+			Value: value,
+		})
+		root.Run = func(ctx *Context) error {
+			_, *source, _ = ctx.EffectiveString("token")
+			return nil
+		}
+		app.Root = root
+		return app
+	}
+
+	t.Run("first set variable wins", func(t *testing.T) {
+		t.Setenv("TEST_TOKEN_LEGACY", "legacy")
+		t.Setenv("TEST_TOKEN_OLD", "old")
+
+		var value string
+		var source Source
+		app := newApp(&value, &source)
+		if err := app.Run(context.Background(), []string{}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if value != "legacy" {
+			t.Errorf("expected 'legacy', got %q", value)
+		}
+		if source != SourceEnvVar {
+			t.Errorf("expected source %v, got %v", SourceEnvVar, source)
+		}
+		if flag := app.Flags().lookup("token"); !flag.set || flag.cliSet {
+			t.Errorf("expected flag set from env only, got set=%v cliSet=%v", flag.set, flag.cliSet)
+		}
+	})
+
+	t.Run("EnvVar takes precedence over EnvVars", func(t *testing.T) {
+		t.Setenv("TEST_TOKEN_PRIMARY", "primary")
+		t.Setenv("TEST_TOKEN_OLD", "old")
+
+		var value string
+		var source Source
+		if err := newApp(&value, &source).Run(context.Background(), []string{}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if value != "primary" {
+			t.Errorf("expected 'primary', got %q", value)
+		}
+	})
+
+	t.Run("command line beats env", func(t *testing.T) {
+		t.Setenv("TEST_TOKEN_LEGACY", "legacy")
+
+		var value string
+		var source Source
+		if err := newApp(&value, &source).Run(context.Background(), []string{"--token", "cli"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if value != "cli" {
+			t.Errorf("expected 'cli', got %q", value)
+		}
+		if source != SourceCommandFlag {
+			t.Errorf("expected source %v, got %v", SourceCommandFlag, source)
+		}
+	})
+
+	t.Run("bool flag hydrated as false", func(t *testing.T) {
+		t.Setenv("TEST_VERBOSE", "false")
+
+		app := NewApp("test")
+		app.configLoaded = true
+		verbose := true
+		root := NewCommand("test")
+		root.Flags.BoolVar(BoolVarOptions{
+			FlagOptions: FlagOptions{Name: "verbose", EnvVars: []string{"TEST_VERBOSE"}},
+			Value:       &verbose,
+		})
+		var got bool
+		root.Run = func(ctx *Context) error {
+			got, _ = ctx.App.Flags().Bool("verbose")
+			return nil
+		}
+		app.Root = root
+
+		if err := app.Run(context.Background(), []string{}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if got {
+			t.Errorf("expected verbose=false from env")
+		}
+	})
+}
+
+func TestContextTypedGettersPrecedence(t *testing.T) {
+	type result struct {
+		port    int
+		count   int64
+		ratio   float64
+		timeout time.Duration
+	}
+
+	run := func(t *testing.T, args []string, config map[string]string) result {
+		t.Helper()
+		app := NewApp("test")
+		app.configLoaded = true
+		for k, v := range config {
+			app.Config.Set(k, v)
+		}
+
+		var port int
+		var count int64
+		var ratio float64
+		var timeout time.Duration
+		cmd := NewCommand("serve")
+		cmd.Flags.IntVar(WithFlagName("port"), WithIntegerValue(&port), WithIntegerDefault("80"))
+		cmd.Flags.Int64Var(WithFlagName("count"), WithInt64Value(&count), WithInt64Default("1"))
+		cmd.Flags.Float64Var(WithFlagName("ratio"), WithFloat64Value(&ratio), WithFloat64Default("0.5"))
+		cmd.Flags.DurationVar(WithFlagName("timeout"), WithDurationValue(&timeout), WithDurationDefault("5s"))
+
+		var got result
+		cmd.Run = func(ctx *Context) error {
+			got.port, _ = ctx.Int("port")
+			got.count, _ = ctx.Int64("count")
+			got.ratio, _ = ctx.Float64("ratio")
+			got.timeout, _ = ctx.Duration("timeout")
+			return nil
+		}
+		app.Root.AddCommand(cmd)
+
+		if err := app.Run(context.Background(), append([]string{"serve"}, args...)); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		return got
+	}
+
+	config := map[string]string{"port": "8000", "count": "2", "ratio": "0.6", "timeout": "10s"}
+
+	t.Run("defaults", func(t *testing.T) {
+		got := run(t, nil, nil)
+		if want := (result{80, 1, 0.5, 5 * time.Second}); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("config over default", func(t *testing.T) {
+		got := run(t, nil, config)
+		if want := (result{8000, 2, 0.6, 10 * time.Second}); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("env over config", func(t *testing.T) {
+		t.Setenv("TEST_PORT", "9000")
+		t.Setenv("TEST_COUNT", "3")
+		t.Setenv("TEST_RATIO", "0.7")
+		t.Setenv("TEST_TIMEOUT", "1m")
+		got := run(t, nil, config)
+		if want := (result{9000, 3, 0.7, time.Minute}); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("command flag over env", func(t *testing.T) {
+		t.Setenv("TEST_PORT", "9000")
+		t.Setenv("TEST_TIMEOUT", "1m")
+		got := run(t, []string{"--port", "9999", "--count", "4", "--ratio", "0.8", "--timeout", "2h"}, config)
+		if want := (result{9999, 4, 0.8, 2 * time.Hour}); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package clix
+
+import "strings"
+
+// ScopedString is like String, but config lookups try the keys under the
+// command path first, whether or not Command.ScopedConfig is set. For
+// "app db migrate" and key "timeout", the config keys db.migrate.timeout,
+// db.timeout and timeout are tried in that order. Flags and environment
+// variables still take precedence over config as usual.
+//
+//	// config.yaml:
+//	//   timeout: 30s
+//	//   db:
+//	//     timeout: 5s
+//	timeout, _ := ctx.ScopedString("timeout") // "5s" under "app db ..."
+func (ctx *Context) ScopedString(key string) (string, bool) {
+	value, _, found := ctx.resolve(key, true)
+	return value, found
+}
+
+// scopedConfig reports whether c or one of its ancestors sets ScopedConfig.
+func (c *Command) scopedConfig() bool {
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if cmd.ScopedConfig {
+			return true
+		}
+	}
+	return false
+}
+
+// configScopes returns the config key prefixes for c, nearest first: the
+// dotted names of c and its ancestors below the root, whose config the app
+// already owns.
+func (c *Command) configScopes() []string {
+	var names []string
+	for cmd := c; cmd != nil && cmd.parent != nil; cmd = cmd.parent {
+		names = append([]string{cmd.Name}, names...)
+	}
+	scopes := make([]string, 0, len(names))
+	for i := len(names); i > 0; i-- {
+		scopes = append(scopes, strings.Join(names[:i], "."))
+	}
+	return scopes
+}
+
+// lookupConfig reads the config value for the flag or key name, honoring
+// BindFlagToConfig. When scoped is set, the keys under cmd's path are tried
+// before the bare key.
+func (a *App) lookupConfig(cmd *Command, name string, scoped bool) (string, bool) {
+	key := a.configKey(name)
+	if scoped && cmd != nil {
+		for _, scope := range cmd.configScopes() {
+			if v, ok := a.Config.Get(scope + "." + key); ok {
+				return v, true
+			}
+		}
+	}
+	return a.Config.Get(key)
+}
@@ -0,0 +1,92 @@
+package clix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestScopedStringPrefersCommandScope(t *testing.T) {
+	app := newTestApp(t, "dev")
+	app.Config.Set("timeout", "30s")
+	app.Config.Set("db.timeout", "5s")
+	app.Config.Set("db.migrate.timeout", "10m")
+	app.Config.Set("bq.timeout", "2m")
+	app.Root.AddCommand(NewGroup("db", "Database tools", NewCommand("migrate"), NewCommand("dump")))
+	app.Root.AddCommand(NewGroup("bq", "BigQuery tools", NewCommand("query")))
+	app.Root.AddCommand(NewCommand("status"))
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"db", "migrate"}, "10m"},
+		{[]string{"db", "dump"}, "5s"},
+		{[]string{"bq", "query"}, "2m"},
+		{[]string{"status"}, "30s"},
+	}
+	for _, tt := range tests {
+		var scoped, plain string
+		cmd := app.Root.ResolvePath(tt.args)
+		cmd.Run = func(ctx *Context) error {
+			scoped, _ = ctx.ScopedString("timeout")
+			plain, _ = ctx.String("timeout")
+			return nil
+		}
+		if err := app.Run(context.Background(), tt.args); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if scoped != tt.want {
+			t.Errorf("%v: ScopedString = %q, want %q", tt.args, scoped, tt.want)
+		}
+		if plain != "30s" {
+			t.Errorf("%v: String without ScopedConfig = %q, want the global 30s", tt.args, plain)
+		}
+	}
+}
+
+func TestScopedConfigAppliesToGettersAndFlags(t *testing.T) {
+	app := newTestApp(t, "dev")
+	app.Config.Set("timeout", "30s")
+	app.Config.Set("db.timeout", "5s")
+	app.Config.Set("db.migrate.timeout", "10m")
+	migrate := NewCommand("migrate")
+	dump := NewCommand("dump")
+	db := NewGroup("db", "Database tools", migrate, dump)
+	WithCommandScopedConfig().ApplyCommand(db)
+	app.Root.AddCommand(db)
+
+	var timeout string
+	dump.Flags.StringVar(WithFlagName("timeout"), WithStringValue(&timeout))
+	var fromCtx string
+	dump.Run = func(ctx *Context) error {
+		fromCtx, _ = ctx.String("timeout")
+		return nil
+	}
+	var migrateTimeout string
+	migrate.Run = func(ctx *Context) error {
+		migrateTimeout, _ = ctx.String("timeout")
+		return nil
+	}
+
+	if err := app.Run(context.Background(), []string{"db", "dump"}); err != nil {
+		t.Fatal(err)
+	}
+	if timeout != "5s" || fromCtx != "5s" {
+		t.Errorf("flag = %q, ctx.String = %q, want the db scope's 5s", timeout, fromCtx)
+	}
+
+	if err := app.Run(context.Background(), []string{"db", "migrate"}); err != nil {
+		t.Fatal(err)
+	}
+	if migrateTimeout != "10m" {
+		t.Errorf("ctx.String = %q, want the nearest scope's 10m", migrateTimeout)
+	}
+
+	// Flags given on the command line still win over scoped config.
+	if err := app.Run(context.Background(), []string{"db", "dump", "--timeout", "1s"}); err != nil {
+		t.Fatal(err)
+	}
+	if timeout != "1s" || fromCtx != "1s" {
+		t.Errorf("flag = %q, ctx.String = %q, want the command-line 1s", timeout, fromCtx)
+	}
+}
@@ -0,0 +1,47 @@
+package clix
+
+import (
+	"io"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// decodeTOML parses a TOML document into nested maps, the same shape the
+// YAML decoder produces, so it can be flattened with flattenYAML. Dates and
+// times are returned as their RFC 3339 text, as YAML keeps them as strings.
+func decodeTOML(r io.Reader) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	if err := toml.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	normalizeTOML(data)
+	return data, nil
+}
+
+// normalizeTOML replaces decoded date-times in data with their text, in place.
+// Local dates and times already print as written.
+func normalizeTOML(data map[string]interface{}) {
+	for k, v := range data {
+		data[k] = normalizeTOMLValue(v)
+	}
+}
+
+func normalizeTOMLValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case map[string]interface{}:
+		normalizeTOML(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeTOMLValue(item)
+		}
+	}
+	return v
+}
+
+// encodeTOML writes data, nested maps of string values, as a TOML document.
+func encodeTOML(w io.Writer, data map[string]interface{}) error {
+	return toml.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,69 @@
+package clix
+
+import "testing"
+
+func TestConfigManagerTyped(t *testing.T) {
+	mgr := NewConfigManager("demo")
+	mgr.RegisterSchema(
+		ConfigSchema{Key: "debug", Type: ConfigBool},
+		ConfigSchema{Key: "retries", Type: ConfigInt},
+		ConfigSchema{Key: "size", Type: ConfigInt64},
+		ConfigSchema{Key: "ratio", Type: ConfigFloat64},
+	)
+	mgr.Set("debug", "true")
+	mgr.Set("retries", " 3 ")
+	mgr.Set("size", "8589934592")
+	mgr.Set("ratio", "0.25")
+	mgr.Set("name", "clix")
+
+	tests := []struct {
+		key  string
+		want any
+	}{
+		{"debug", true},
+		{"retries", 3},
+		{"size", int64(8589934592)},
+		{"ratio", 0.25},
+		{"name", "clix"},
+	}
+	for _, tt := range tests {
+		got, ok := mgr.Typed(tt.key)
+		if !ok {
+			t.Errorf("expected %q to be present", tt.key)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Typed(%q) = %#v (%T), want %#v (%T)", tt.key, got, got, tt.want, tt.want)
+		}
+	}
+
+	mgr.Set("retries", "many")
+	if _, ok := mgr.Typed("retries"); ok {
+		t.Error("expected an unparsable value to report false")
+	}
+	if _, ok := mgr.Typed("missing"); ok {
+		t.Error("expected a missing key to report false")
+	}
+}
+
+func TestConfigManagerSetNormalized(t *testing.T) {
+	mgr := NewConfigManager("demo")
+	mgr.RegisterSchema(
+		ConfigSchema{Key: "debug", Type: ConfigBool},
+		ConfigSchema{Key: "retries", Type: ConfigInt},
+	)
+
+	if err := mgr.SetNormalized("debug", "1"); err != nil {
+		t.Fatalf("SetNormalized returned error: %v", err)
+	}
+	if got, _ := mgr.Get("debug"); got != "true" {
+		t.Errorf("expected canonical %q, got %q", "true", got)
+	}
+
+	if err := mgr.SetNormalized("retries", "abc"); err == nil {
+		t.Fatal("expected an error for a non-integer value")
+	}
+	if _, ok := mgr.Get("retries"); ok {
+		t.Error("expected an invalid value not to be stored")
+	}
+}
@@ -0,0 +1,94 @@
+package clix
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// configWatchInterval is how often Watch checks the file for changes.
+var configWatchInterval = 500 * time.Millisecond
+
+// Watch polls the config file at path and reloads it when it changes, then
+// calls onChange. A change is picked up once the file has stayed the same for
+// one polling interval, so an editor's burst of writes triggers a single
+// reload. Keys previously loaded from path that are no longer in the file are
+// removed; values from other files or Set are kept unless the file overrides
+// them. If the file fails to parse (e.g. mid-save), the previous values are
+// kept and onChange is not called until it parses again.
+//
+// Watch blocks until ctx is canceled and then returns nil. The reload and
+// onChange run on the goroutine that called Watch. Reloads are safe alongside
+// App.Run and other users of the manager, but a command may see values from
+// before and after a reload within one run.
+//
+// Example:
+//
+//	go app.Config.Watch(ctx, path, func() {
+//		reconfigure <- struct{}{}
+//	})
+func (m *ConfigManager) Watch(ctx context.Context, path string, onChange func()) error {
+	ticker := time.NewTicker(configWatchInterval)
+	defer ticker.Stop()
+
+	last := statFile(path)
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current := statFile(path)
+		if current != last {
+			last = current
+			pending = true
+			continue
+		}
+		if !pending {
+			continue
+		}
+		pending = false
+
+		if err := m.reload(path); err != nil {
+			continue
+		}
+		if onChange != nil {
+			onChange()
+		}
+	}
+}
+
+// reload replaces the values that came from path with the file's contents.
+func (m *ConfigManager) reload(path string) error {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, origin := range m.origins {
+		if origin == path {
+			delete(m.values, key)
+			delete(m.origins, key)
+		}
+	}
+	m.store(path, values)
+	return nil
+}
+
+// fileStamp identifies a version of a file for change detection.
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
@@ -0,0 +1,114 @@
+package clix
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigManagerWatch(t *testing.T) {
+	previous := configWatchInterval
+	configWatchInterval = 10 * time.Millisecond
+	defer func() { configWatchInterval = previous }()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("region: us-east-1\nstale: yes\n"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	mgr := NewConfigManager("demo")
+	if err := mgr.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changed := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- mgr.Watch(ctx, path, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	// Let the watcher take its first snapshot before editing the file.
+	time.Sleep(3 * configWatchInterval)
+	if err := os.WriteFile(path, []byte("region: eu-west-1\nretries: 5\n"), 0o644); err != nil {
+		t.Fatalf("failed to rewrite config: %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the change callback")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Watch returned error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not stop after the context was canceled")
+	}
+
+	if got, _ := mgr.Get("region"); got != "eu-west-1" {
+		t.Errorf("expected reloaded region eu-west-1, got %q", got)
+	}
+	if got, _ := mgr.Get("retries"); got != "5" {
+		t.Errorf("expected new key retries=5, got %q", got)
+	}
+	if _, ok := mgr.Get("stale"); ok {
+		t.Error("expected a key removed from the file to be removed")
+	}
+}
+
+func TestConfigManagerWatchDuringRun(t *testing.T) {
+	previous := configWatchInterval
+	configWatchInterval = time.Millisecond
+	defer func() { configWatchInterval = previous }()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("region: us-east-1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp("demo")
+	app.configLoaded = true
+	if err := app.Config.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	var region string
+	cmd := NewCommand("deploy")
+	cmd.Flags.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "region"}, Value: &region})
+	cmd.Run = func(ctx *Context) error {
+		ctx.String("region")
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.Config.Watch(ctx, path, nil) }()
+
+	regions := []string{"eu-west-1", "us-east-1"}
+	for i := 0; i < 50; i++ {
+		if err := os.WriteFile(path, []byte("region: "+regions[i%2]+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+}
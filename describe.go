@@ -0,0 +1,206 @@
+package clix
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CommandSpec is a machine-readable description of a command and its
+// subcommands, as returned by App.Describe.
+type CommandSpec struct {
+	Name       string         `json:"name"`
+	Path       string         `json:"path"`
+	Aliases    []string       `json:"aliases,omitempty"`
+	Short      string         `json:"short,omitempty"`
+	Long       string         `json:"long,omitempty"`
+	Usage      string         `json:"usage,omitempty"`
+	Example    string         `json:"example,omitempty"`
+	Category   string         `json:"category,omitempty"`
+	Deprecated string         `json:"deprecated,omitempty"`
+	Hidden     bool           `json:"hidden,omitempty"`
+	Runnable   bool           `json:"runnable"`
+	Flags      []FlagSpec     `json:"flags,omitempty"`
+	Arguments  []ArgumentSpec `json:"arguments,omitempty"`
+	Commands   []CommandSpec  `json:"commands,omitempty"`
+}
+
+// FlagSpec describes a named flag of a command.
+type FlagSpec struct {
+	Name       string   `json:"name"`
+	Short      string   `json:"short,omitempty"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Type       string   `json:"type"`
+	Default    string   `json:"default,omitempty"`
+	Usage      string   `json:"usage,omitempty"`
+	EnvVars    []string `json:"env,omitempty"`
+	Required   bool     `json:"required,omitempty"`
+	Category   string   `json:"category,omitempty"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	Hidden     bool     `json:"hidden,omitempty"`
+	Persistent bool     `json:"persistent,omitempty"`
+}
+
+// ArgumentSpec describes a positional argument of a command.
+type ArgumentSpec struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Default  string `json:"default,omitempty"`
+	Usage    string `json:"usage,omitempty"`
+	Required bool   `json:"required,omitempty"`
+	Variadic bool   `json:"variadic,omitempty"`
+}
+
+// DescribeOption configures App.Describe.
+type DescribeOption interface {
+	applyDescribe(*describeConfig)
+}
+
+type describeConfig struct {
+	hidden bool
+}
+
+// WithDescribeHidden includes hidden commands and flags in the description,
+// marked with Hidden. They are left out by default, as in help output.
+func WithDescribeHidden() DescribeOption {
+	return describeHiddenOption{}
+}
+
+type describeHiddenOption struct{}
+
+func (describeHiddenOption) applyDescribe(cfg *describeConfig) {
+	cfg.hidden = true
+}
+
+// Describe returns a description of the whole command tree, starting at the
+// root command, for documentation generators, editors and test harnesses.
+// Extensions are applied first so their commands are included; DescribeJSON
+// reports an extension that fails.
+func (a *App) Describe(opts ...DescribeOption) CommandSpec {
+	var cfg describeConfig
+	for _, opt := range opts {
+		opt.applyDescribe(&cfg)
+	}
+	if a.Root == nil {
+		return CommandSpec{Name: a.Name, Path: a.Name}
+	}
+	a.ensureRootPrepared()
+	_ = a.ApplyExtensions()
+	return describeCommand(a.Root, cfg)
+}
+
+// DescribeJSON writes Describe's result to w as indented JSON.
+func (a *App) DescribeJSON(w io.Writer, opts ...DescribeOption) error {
+	spec := a.Describe(opts...)
+	if err := a.ApplyExtensions(); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return checkBrokenPipe(enc.Encode(spec))
+}
+
+func describeCommand(cmd *Command, cfg describeConfig) CommandSpec {
+	spec := CommandSpec{
+		Name:       cmd.Name,
+		Path:       cmd.Path(),
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Usage:      cmd.Usage,
+		Example:    cmd.Example,
+		Category:   cmd.Category,
+		Deprecated: cmd.Deprecated,
+		Hidden:     cmd.IsHidden(),
+		Runnable:   cmd.Run != nil,
+	}
+	if cmd.Flags != nil {
+		for _, flag := range cmd.Flags.flags {
+			if flag.Positional {
+				spec.Arguments = append(spec.Arguments, ArgumentSpec{
+					Name:     flag.Name,
+					Type:     valueType(flag.Value),
+					Default:  flag.Default,
+					Usage:    flag.Usage,
+					Required: flag.Required,
+					Variadic: flag.Variadic,
+				})
+				continue
+			}
+			if flag.Hidden && !cfg.hidden {
+				continue
+			}
+			spec.Flags = append(spec.Flags, describeFlag(flag))
+		}
+	}
+	if cmd.PersistentFlags != nil && cmd.parent != nil {
+		for _, flag := range cmd.PersistentFlags.flags {
+			if flag.Hidden && !cfg.hidden {
+				continue
+			}
+			inherited := describeFlag(flag)
+			inherited.Persistent = true
+			spec.Flags = append(spec.Flags, inherited)
+		}
+	}
+	for _, child := range cmd.Children {
+		if child == nil || (child.IsHidden() && !cfg.hidden) {
+			continue
+		}
+		spec.Commands = append(spec.Commands, describeCommand(child, cfg))
+	}
+	return spec
+}
+
+// describeFlag builds the FlagSpec for a named flag.
+func describeFlag(flag *Flag) FlagSpec {
+	var env []string
+	if flag.EnvVar != "" {
+		env = append(env, flag.EnvVar)
+	}
+	return FlagSpec{
+		Name:       flag.Name,
+		Short:      flag.Short,
+		Aliases:    flag.Aliases,
+		Type:       valueType(flag.Value),
+		Default:    flag.Default,
+		Usage:      flag.Usage,
+		EnvVars:    append(env, flag.EnvVars...),
+		Required:   flag.Required,
+		Category:   flag.Category,
+		Deprecated: flag.Deprecated,
+		Hidden:     flag.Hidden,
+	}
+}
+
+// valueType names the type of a flag value. Custom values can report their
+// own name with a Type() string method, as pflag values do.
+func valueType(value Value) string {
+	switch v := value.(type) {
+	case interface{ Type() string }:
+		return v.Type()
+	case *StringValue:
+		return "string"
+	case *BoolValue:
+		return "bool"
+	case *DurationValue:
+		return "duration"
+	case *IntValue:
+		return "int"
+	case *Int64Value:
+		return "int64"
+	case *UintValue:
+		return "uint"
+	case *Float32Value:
+		return "float32"
+	case *Float64Value:
+		return "float64"
+	case *BytesValue:
+		return "bytes"
+	case *TimestampValue:
+		return "timestamp"
+	case *StringSliceValue:
+		return "[]string"
+	default:
+		return "value"
+	}
+}
@@ -0,0 +1,99 @@
+package clix
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newDescribeApp() *App {
+	app := NewApp("tool")
+	var verbose bool
+	app.Flags().BoolVar(WithFlagName("verbose"), WithFlagShort("v"), WithBoolValue(&verbose))
+
+	var replicas int
+	var timeout time.Duration
+	var files []string
+	deploy := NewCommand("deploy")
+	deploy.Short = "Deploy the service"
+	deploy.Aliases = []string{"ship"}
+	deploy.Flags.IntVar(WithFlagName("replicas"), WithIntegerValue(&replicas), WithIntegerDefault("2"), WithFlagUsage("Replica count"))
+	deploy.Flags.DurationVar(WithFlagName("timeout"), WithDurationValue(&timeout), WithFlagHidden())
+	deploy.Flags.StringSliceVar(WithFlagName("files"), WithStringSliceValue(&files), WithFlagPositional(), WithFlagVariadic(), WithFlagRequired())
+	deploy.Run = func(*Context) error { return nil }
+
+	debug := NewCommand("debug")
+	debug.Hidden = true
+	debug.Run = func(*Context) error { return nil }
+
+	app.Root.AddCommand(NewGroup("service", "Manage services", deploy, debug))
+	return app
+}
+
+func TestDescribeTwoLevelApp(t *testing.T) {
+	spec := newDescribeApp().Describe()
+
+	if spec.Name != "tool" || len(spec.Commands) != 1 {
+		t.Fatalf("root spec = %+v", spec)
+	}
+	if got := spec.Flags[len(spec.Flags)-1]; got.Name != "verbose" || got.Short != "v" || got.Type != "bool" {
+		t.Errorf("root flag = %+v", got)
+	}
+
+	service := spec.Commands[0]
+	if service.Path != "tool service" || service.Runnable || len(service.Commands) != 1 {
+		t.Fatalf("service spec = %+v", service)
+	}
+
+	deploy := service.Commands[0]
+	if deploy.Path != "tool service deploy" || !deploy.Runnable || !reflect.DeepEqual(deploy.Aliases, []string{"ship"}) {
+		t.Errorf("deploy spec = %+v", deploy)
+	}
+	flags := map[string]FlagSpec{}
+	for _, f := range deploy.Flags {
+		flags[f.Name] = f
+	}
+	if want := (FlagSpec{Name: "replicas", Type: "int", Default: "2", Usage: "Replica count"}); !reflect.DeepEqual(flags["replicas"], want) {
+		t.Errorf("replicas = %+v, want %+v", flags["replicas"], want)
+	}
+	if _, ok := flags["timeout"]; ok {
+		t.Error("hidden flag described without WithDescribeHidden")
+	}
+	if want := []ArgumentSpec{{Name: "files", Type: "[]string", Required: true, Variadic: true}}; !reflect.DeepEqual(deploy.Arguments, want) {
+		t.Errorf("arguments = %+v, want %+v", deploy.Arguments, want)
+	}
+}
+
+func TestDescribeHidden(t *testing.T) {
+	spec := newDescribeApp().Describe(WithDescribeHidden())
+
+	service := spec.Commands[0]
+	if len(service.Commands) != 2 || !service.Commands[1].Hidden {
+		t.Fatalf("expected hidden debug command flagged, got %+v", service.Commands)
+	}
+	var timeout *FlagSpec
+	for i, f := range service.Commands[0].Flags {
+		if f.Name == "timeout" {
+			timeout = &service.Commands[0].Flags[i]
+		}
+	}
+	if timeout == nil || !timeout.Hidden || timeout.Type != "duration" {
+		t.Errorf("timeout flag = %+v", timeout)
+	}
+}
+
+func TestDescribeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newDescribeApp().DescribeJSON(&buf); err != nil {
+		t.Fatalf("DescribeJSON: %v", err)
+	}
+	var decoded CommandSpec
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(decoded, newDescribeApp().Describe()) {
+		t.Errorf("JSON round trip differs:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,42 @@
+package clix
+
+// dryRunFlagName is the root flag registered by EnableDryRun.
+const dryRunFlagName = "dry-run"
+
+// EnableDryRun registers a global --dry-run flag on the root command, so every
+// command accepts it. Handlers and middleware check ctx.DryRun() to report what
+// they would do instead of doing it. Like any app flag, the value can also come
+// from the environment (e.g. MYAPP_DRY_RUN=true) or the config file.
+func (a *App) EnableDryRun() {
+	flags := a.Flags()
+	if flags.lookup(dryRunFlagName) != nil {
+		return
+	}
+	var dryRun bool
+	flags.BoolVar(BoolVarOptions{
+		FlagOptions: FlagOptions{
+			Name:  dryRunFlagName,
+			Usage: "Show what would be done without making changes",
+		},
+		Value: &dryRun,
+	})
+}
+
+// WithAppDryRun calls EnableDryRun on the app.
+func WithAppDryRun() AppOption {
+	return appDryRunOption{}
+}
+
+type appDryRunOption struct{}
+
+func (appDryRunOption) ApplyApp(app *App) {
+	app.EnableDryRun()
+}
+
+// DryRun reports whether the command runs in dry-run mode: --dry-run (see
+// EnableDryRun) resolved with the usual precedence. It is false when the flag
+// is not set anywhere.
+func (ctx *Context) DryRun() bool {
+	dryRun, _ := ctx.Bool(dryRunFlagName)
+	return dryRun
+}
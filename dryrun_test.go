@@ -0,0 +1,71 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func newDryRunApp(got *bool) *App {
+	app := NewApp("demo", WithAppDryRun())
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	cmd := NewCommand("delete")
+	cmd.Run = func(ctx *Context) error {
+		*got = ctx.DryRun()
+		return nil
+	}
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", cmd))
+	return app
+}
+
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want bool
+	}{
+		{name: "default", args: []string{"repo", "delete"}},
+		{name: "flag", args: []string{"repo", "delete", "--dry-run"}, want: true},
+		{name: "before command", args: []string{"--dry-run", "repo", "delete"}, want: true},
+		{name: "explicit false", args: []string{"repo", "delete", "--dry-run=false"}, env: "true"},
+		{name: "env", args: []string{"repo", "delete"}, env: "true", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("DEMO_DRY_RUN", tt.env)
+			}
+			var got bool
+			app := newDryRunApp(&got)
+			if err := app.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DryRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRunNotEnabled(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.Err = &bytes.Buffer{}
+	app.Root.Run = func(ctx *Context) error {
+		if ctx.DryRun() {
+			t.Error("DryRun() = true without EnableDryRun")
+		}
+		return nil
+	}
+	if err := app.Run(context.Background(), []string{}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	err := app.Run(context.Background(), []string{"--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), "dry-run") {
+		t.Errorf("Run(--dry-run) error = %v, want an unknown flag error", err)
+	}
+}
@@ -1,9 +1,6 @@
 package main
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/SCKelemen/clix/v2"
 	authcmd "github.com/SCKelemen/clix/v2/examples/gcloud/internal/auth"
 	configcmd "github.com/SCKelemen/clix/v2/examples/gcloud/internal/config"
@@ -66,8 +63,6 @@ func newApp() *clix.App {
 		},
 	}
 
-	root.Long = buildGCloudLongHelp(groups, commands, builders)
-
 	root.Run = func(ctx *clix.Context) error {
 		return clix.HelpRenderer{App: ctx.App, Command: ctx.Command}.Render(ctx.App.Out)
 	}
@@ -79,14 +74,18 @@ func newApp() *clix.App {
 			if _, exists := added[entry.Name]; exists {
 				continue
 			}
+			var cmd *clix.Command
 			if builder, ok := builders[entry.Name]; ok {
 				if !builder.Enabled {
 					continue
 				}
-				subcommands = append(subcommands, builder.Build())
+				cmd = builder.Build()
 			} else {
-				subcommands = append(subcommands, simplecmd.NewCommand(entry.Name, entry.Description))
+				cmd = simplecmd.NewCommand(entry.Name, entry.Description)
 			}
+			// Help lists each command under its category heading.
+			cmd.Category = group.Category
+			subcommands = append(subcommands, cmd)
 			added[entry.Name] = struct{}{}
 		}
 	}
@@ -96,7 +95,9 @@ func newApp() *clix.App {
 			if _, exists := added[entry.Name]; exists {
 				continue
 			}
-			subcommands = append(subcommands, simplecmd.NewCommand(entry.Name, entry.Description))
+			cmd := simplecmd.NewCommand(entry.Name, entry.Description)
+			cmd.Category = category.Category
+			subcommands = append(subcommands, cmd)
 			added[entry.Name] = struct{}{}
 		}
 	}
@@ -107,51 +108,6 @@ func newApp() *clix.App {
 	return app
 }
 
-func buildGCloudLongHelp(groups []struct {
-	Category string
-	Entries  []gcloudEntry
-}, commands []struct {
-	Category string
-	Entries  []gcloudEntry
-}, builders map[string]commandBuilder) string {
-	var b strings.Builder
-	b.WriteString("Available command groups for gcloud:\n\n")
-	for _, group := range groups {
-		var displayed int
-		for _, entry := range group.Entries {
-			if builder, ok := builders[entry.Name]; ok && !builder.Enabled {
-				continue
-			}
-			if displayed == 0 {
-				b.WriteString("  " + group.Category + "\n")
-			}
-			b.WriteString(fmt.Sprintf("      %-22s %s\n", entry.Name, entry.Description))
-			displayed++
-		}
-		if displayed > 0 {
-			b.WriteString("\n")
-		}
-	}
-	b.WriteString("Available commands for gcloud:\n\n")
-	for _, cat := range commands {
-		var displayed int
-		for _, entry := range cat.Entries {
-			if builder, ok := builders[entry.Name]; ok && !builder.Enabled {
-				continue
-			}
-			if displayed == 0 {
-				b.WriteString("  " + cat.Category + "\n")
-			}
-			b.WriteString(fmt.Sprintf("      %-22s %s\n", entry.Name, entry.Description))
-			displayed++
-		}
-		if displayed > 0 {
-			b.WriteString("\n")
-		}
-	}
-	return strings.TrimSpace(b.String())
-}
-
 func gcloudCommandGroups() []struct {
 	Category string
 	Entries  []gcloudEntry
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/SCKelemen/clix/v2"
@@ -18,10 +19,18 @@ func main() {
 	root := clix.NewCommand("dev")
 	root.Short = "Developer tools"
 	
-	// Add shared commands from different teams
-	root.AddCommand(database.NewDatabaseCommand())
-	root.AddCommand(vulnerabilities.NewVulnerabilitiesCommand())
-	root.AddCommand(bigquery.NewBigQueryCommand())
+	// Add shared commands from different teams, failing fast if two teams
+	// claim the same command name or alias
+	for _, cmd := range []*clix.Command{
+		database.NewDatabaseCommand(),
+		vulnerabilities.NewVulnerabilitiesCommand(),
+		bigquery.NewBigQueryCommand(),
+	} {
+		if err := root.AddCommandErr(cmd); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
 
 	app.Root = root
 
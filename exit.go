@@ -0,0 +1,105 @@
+package clix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExitError is an error that carries the process exit status a command wants.
+// App.Run returns it unchanged, and App.Main exits with its Code.
+//
+// Example:
+//
+//	cmd.Run = func(ctx *clix.Context) error {
+//		if !healthy {
+//			return &clix.ExitError{Code: 3, Err: errors.New("service is unhealthy")}
+//		}
+//		return nil
+//	}
+type ExitError struct {
+	// Code is the process exit status.
+	Code int
+
+	// Err is the underlying error. It may be nil to exit with Code without
+	// printing anything.
+	Err error
+}
+
+// Error implements error.
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// Main runs the app with args (os.Args[1:] when nil) and exits the process.
+// It exits with status 0 when Run succeeds. Otherwise the error is printed to
+// a.Err and the process exits with the Code of the first ExitError in the
+// error chain, or 1 when there is none. An ExitError without Err only sets
+// the status. Input errors (see UsageError) are followed by the command's
+// usage. When the resolved --format is json, the error is instead printed as
+// a single {"error": ..., "code": ...} object with the exit status, without
+// usage, so pipelines can parse failures. Command.SilenceErrors and
+// Command.SilenceUsage suppress the error and usage output respectively.
+//
+// Example:
+//
+//	func main() {
+//		newApp().Main(nil)
+//	}
+func (a *App) Main(args []string) {
+	forceExit(a.exitCode(a.Run(context.Background(), args)))
+}
+
+// exitCode prints err, if any, and returns the status Main exits with.
+func (a *App) exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	code := 1
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.Code
+		if exitErr.Err == nil && err == error(exitErr) {
+			// A bare ExitError only sets the status.
+			return code
+		}
+	}
+	silent := silenced(a.runCommand, func(c *Command) bool { return c.SilenceErrors })
+	if strings.EqualFold(a.outputFormat(), FormatJSON) {
+		if !silent {
+			writeJSONError(a.Err, err, code)
+		}
+		return code
+	}
+	if !silent {
+		fmt.Fprintln(a.Err, err)
+	}
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) && !silenced(usageErr.Command, func(c *Command) bool { return c.SilenceUsage }) {
+		a.printUsage(a.Err, usageErr.Command)
+	}
+	return code
+}
+
+// jsonError is the shape of errors printed under --format json.
+type jsonError struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// writeJSONError writes err and its exit status to w as one line of JSON.
+func writeJSONError(w io.Writer, err error, code int) {
+	data, _ := json.Marshal(jsonError{Error: err.Error(), Code: code})
+	fmt.Fprintf(w, "%s\n", data)
+}
@@ -0,0 +1,185 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRunReturnsExitErrorUnchanged(t *testing.T) {
+	app := NewApp("test")
+	app.configLoaded = true
+	exitErr := &ExitError{Code: 3, Err: errors.New("unhealthy")}
+	app.Root.Run = func(ctx *Context) error { return exitErr }
+	app.Root.Use(func(next Handler) Handler {
+		return func(ctx *Context) error { return next(ctx) }
+	})
+
+	err := app.Run(context.Background(), []string{})
+	if err != exitErr {
+		t.Fatalf("expected the ExitError unchanged, got %#v", err)
+	}
+}
+
+func TestMainExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		runErr   error
+		wantCode int
+		wantErr  string
+	}{
+		{"success", nil, 0, ""},
+		{"plain error", errors.New("boom"), 1, "boom\n"},
+		{"exit error", &ExitError{Code: 3, Err: errors.New("unhealthy")}, 3, "unhealthy\n"},
+		{"wrapped exit error", fmt.Errorf("check: %w", &ExitError{Code: 4, Err: errors.New("down")}), 4, "check: down\n"},
+		{"bare exit error", &ExitError{Code: 2}, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code = -1
+			origExit := forceExit
+			forceExit = func(c int) { code = c }
+			t.Cleanup(func() { forceExit = origExit })
+
+			app := NewApp("test")
+			app.configLoaded = true
+			var stderr bytes.Buffer
+			app.Err = &stderr
+			app.Root.Run = func(ctx *Context) error { return tt.runErr }
+
+			app.Main([]string{})
+
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if got := stderr.String(); got != tt.wantErr {
+				t.Errorf("stderr = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMainPrintsUsageForInputErrors(t *testing.T) {
+	const usage = "Usage: test deploy [flags]\nRun 'test deploy --help' for more information.\n"
+	tests := []struct {
+		name       string
+		args       []string
+		silence    CommandOption
+		wantStderr string
+	}{
+		{name: "unknown flag", args: []string{"deploy", "--bogus"}, wantStderr: "unknown flag: --bogus\n" + usage},
+		{name: "unexpected argument", args: []string{"deploy", "extra"}, wantStderr: "unexpected arguments: extra\n" + usage},
+		{name: "handler error", args: []string{"deploy", "--fail"}, wantStderr: "deploy failed\n"},
+		{name: "silence usage", args: []string{"deploy", "--bogus"}, silence: WithCommandSilenceUsage(), wantStderr: "unknown flag: --bogus\n"},
+		{name: "silence errors", args: []string{"deploy", "--fail"}, silence: WithCommandSilenceErrors(), wantStderr: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			code := -1
+			origExit := forceExit
+			forceExit = func(c int) { code = c }
+			t.Cleanup(func() { forceExit = origExit })
+
+			app := NewApp("test")
+			app.configLoaded = true
+			var stderr bytes.Buffer
+			app.Err = &stderr
+			app.Out = &bytes.Buffer{}
+
+			var fail bool
+			cmd := NewCommand("deploy")
+			cmd.Flags.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "fail"}, Value: &fail})
+			cmd.Run = func(*Context) error {
+				if fail {
+					return errors.New("deploy failed")
+				}
+				return nil
+			}
+			app.Root.AddCommand(cmd)
+			if tt.silence != nil {
+				// Silencing on the root applies to every command.
+				tt.silence.ApplyCommand(app.Root)
+			}
+
+			app.Main(tt.args)
+
+			if code != 1 {
+				t.Errorf("exit code = %d, want 1", code)
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestMainPrintsJSONErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		args     []string
+		wantCode int
+		wantJSON *jsonError
+		wantText string
+	}{
+		{name: "handler error", format: "json", args: []string{"deploy", "--fail", "boom"}, wantCode: 1, wantJSON: &jsonError{Error: "boom", Code: 1}},
+		{name: "exit error code", format: "JSON", args: []string{"deploy", "--fail", "code"}, wantCode: 3, wantJSON: &jsonError{Error: "unhealthy", Code: 3}},
+		{name: "usage error omits usage", format: "json", args: []string{"deploy", "--bogus"}, wantCode: 1, wantJSON: &jsonError{Error: "unknown flag: --bogus", Code: 1}},
+		{name: "text format stays plain", format: "text", args: []string{"deploy", "--fail", "boom"}, wantCode: 1, wantText: "boom\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			code := -1
+			origExit := forceExit
+			forceExit = func(c int) { code = c }
+			t.Cleanup(func() { forceExit = origExit })
+
+			app := NewApp("test")
+			app.configLoaded = true
+			var stderr bytes.Buffer
+			app.Err = &stderr
+			app.Out = &bytes.Buffer{}
+
+			var format string
+			app.Flags().StringVar(WithFlagName("format"), WithStringValue(&format))
+
+			var fail string
+			cmd := NewCommand("deploy")
+			cmd.Flags.StringVar(WithFlagName("fail"), WithStringValue(&fail))
+			cmd.Run = func(*Context) error {
+				if fail == "code" {
+					return &ExitError{Code: 3, Err: errors.New("unhealthy")}
+				}
+				return errors.New(fail)
+			}
+			app.Root.AddCommand(cmd)
+
+			app.Main(append([]string{"--format", tt.format}, tt.args...))
+
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if tt.wantJSON == nil {
+				if got := stderr.String(); got != tt.wantText {
+					t.Errorf("stderr = %q, want %q", got, tt.wantText)
+				}
+				return
+			}
+			var got jsonError
+			if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+				t.Fatalf("stderr is not JSON: %v\n%s", err, stderr.String())
+			}
+			if got != *tt.wantJSON {
+				t.Errorf("stderr = %+v, want %+v", got, *tt.wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// ConfigPrefix is the config key prefix aliases are stored under. The alias
+// "co" is the key "aliases.co", so a config file can define aliases directly:
+//
+//	aliases:
+//	  co: checkout --quick
+const ConfigPrefix = "aliases."
+
+// ErrAliasLoop is returned, wrapped, when aliases expand into each other
+// forever, e.g. "a = b" and "b = a".
+var ErrAliasLoop = errors.New("alias loop")
+
+// Extension adds user-defined command aliases to a clix app. Before a command
+// is looked up, the first argument after the app-level flags is replaced by
+// its expansion when it names an alias; the remaining arguments are appended.
+// Expansions are split like a shell command line: whitespace separates
+// arguments, and double quotes (with Go escapes) or single quotes keep one
+// together, as in `commit -m "two words"`. An expansion may itself start with
+// another alias. Commands always win over aliases of the same name.
+//
+// The extension adds:
+//
+//	cli alias set <name> <expansion...> - Define or replace an alias
+//	cli alias list                      - List aliases
+//	cli alias remove <name>             - Delete an alias
+//
+// alias set and alias remove change only that alias in the config file.
+// Everything after the alias name is the expansion, app flags such as
+// --format included.
+//
+// Example:
+//
+//	app := clix.NewApp("myapp")
+//	app.AddExtension(alias.Extension{})
+//
+//	// Users can then define shorthands:
+//	//   myapp alias set co checkout --quick
+//	//   myapp co main   # runs: myapp checkout --quick main
+type Extension struct {
+	// Extension has no configuration options.
+	// Simply add it to your app to enable aliases.
+}
+
+// Extend implements clix.Extension.
+func (Extension) Extend(app *clix.App) error {
+	if app.Root == nil {
+		return nil
+	}
+	if app.Root.ResolvePath([]string{"alias"}) == nil {
+		app.Root.AddCommand(NewAliasCommand(app))
+	}
+	app.RewriteArgs(func(args []string) ([]string, error) {
+		return Expand(app, args)
+	})
+	return nil
+}
+
+// Aliases returns the aliases stored in the app's configuration, keyed by name.
+func Aliases(app *clix.App) map[string]string {
+	aliases := make(map[string]string)
+	if app.Config == nil {
+		return aliases
+	}
+	for key, value := range app.Config.Values() {
+		if name, ok := strings.CutPrefix(key, ConfigPrefix); ok && name != "" {
+			aliases[name] = value
+		}
+	}
+	return aliases
+}
+
+// Expand replaces a leading alias in args with its expansion, following
+// aliases that expand to other aliases. Arguments that do not start with an
+// alias are returned unchanged.
+func Expand(app *clix.App, args []string) ([]string, error) {
+	aliases := Aliases(app)
+	var chain []string
+	for len(args) > 0 {
+		name := args[0]
+		expansion, ok := aliases[name]
+		if !ok || app.Root.ResolvePath([]string{name}) != nil {
+			break
+		}
+		for _, seen := range chain {
+			if seen == name {
+				return nil, fmt.Errorf("%w: %s", ErrAliasLoop, strings.Join(append(chain, name), " -> "))
+			}
+		}
+		chain = append(chain, name)
+		fields, err := splitExpansion(expansion)
+		if err != nil {
+			return nil, fmt.Errorf("alias %s: %w", name, err)
+		}
+		args = append(fields, args[1:]...)
+	}
+	return args, nil
+}
+
+// joinExpansion joins args into the stored form of an expansion, quoting the
+// arguments splitExpansion would otherwise break apart or unquote.
+func joinExpansion(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, "\"'\\") || strings.IndexFunc(arg, unicode.IsSpace) >= 0 {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// splitExpansion splits a stored expansion into arguments. Quoted sections
+// may be joined to unquoted text, as in --message="two words".
+func splitExpansion(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+	)
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated quote in %q", s)
+			}
+			text, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted argument %s", s[i:end+1])
+			}
+			current.WriteString(text)
+			inArg = true
+			i = end + 1
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %q", s)
+			}
+			current.WriteString(s[i+1 : i+1+end])
+			inArg = true
+			i += end + 2
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+			i++
+		default:
+			current.WriteByte(c)
+			inArg = true
+			i++
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// NewAliasCommand returns the "alias" command group.
+func NewAliasCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewGroup("alias", "Manage command aliases",
+		aliasSetCommand(app),
+		aliasListCommand(app),
+		aliasRemoveCommand(app),
+	)
+	cmd.Usage = fmt.Sprintf("%s alias [command]", app.Name)
+	cmd.IsExtensionCommand = true
+	return cmd
+}
+
+func aliasSetCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewCommand("set")
+	cmd.Short = "Define an alias"
+	cmd.Usage = fmt.Sprintf("%s alias set <name> <expansion...>", app.Name)
+	// Everything after the name belongs to the expansion, flags included.
+	cmd.Flags.SetInterspersed(false)
+
+	var name string
+	var expansion []string
+	cmd.Flags.StringVar(clix.StringVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:       "name",
+			Usage:      "Alias name",
+			Positional: true,
+			Required:   true,
+		},
+		Value: &name,
+	})
+	cmd.Flags.StringSliceVar(clix.StringSliceVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:       "expansion",
+			Usage:      "Command and arguments the alias stands for",
+			Positional: true,
+			Variadic:   true,
+			Required:   true,
+		},
+		Value: &expansion,
+	})
+
+	cmd.Run = func(ctx *clix.Context) error {
+		if name == "" || strings.ContainsAny(name, ". \t") || strings.HasPrefix(name, "-") {
+			return fmt.Errorf("invalid alias name %q", name)
+		}
+		if app.Root.ResolvePath([]string{name}) != nil {
+			return fmt.Errorf("%q is already a command", name)
+		}
+		value := joinExpansion(expansion)
+		app.Config.Set(ConfigPrefix+name, value)
+		err := updateConfigFile(app, func(onDisk *clix.ConfigManager) {
+			onDisk.Set(ConfigPrefix+name, value)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Out, "%s = %s\n", name, value)
+		return nil
+	}
+	return cmd
+}
+
+func aliasListCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewCommand("list")
+	cmd.Short = "List aliases"
+	cmd.Run = func(ctx *clix.Context) error {
+		aliases := Aliases(app)
+		values := make(map[string]interface{}, len(aliases))
+		for name, expansion := range aliases {
+			values[name] = expansion
+		}
+		return app.FormatOutput(values)
+	}
+	return cmd
+}
+
+func aliasRemoveCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewCommand("remove")
+	cmd.Short = "Delete an alias"
+
+	var name string
+	cmd.Flags.StringVar(clix.StringVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:       "name",
+			Usage:      "Alias name",
+			Positional: true,
+			Required:   true,
+		},
+		Value: &name,
+	})
+
+	cmd.Run = func(ctx *clix.Context) error {
+		if !app.Config.Delete(ConfigPrefix + name) {
+			return fmt.Errorf("alias %q not found", name)
+		}
+		err := updateConfigFile(app, func(onDisk *clix.ConfigManager) {
+			onDisk.Delete(ConfigPrefix + name)
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Out, "%s removed\n", name)
+		return nil
+	}
+	return cmd
+}
+
+// updateConfigFile applies update to the app's config file as stored on disk
+// and saves it. Values App.Config holds from other layers, .env files or
+// bound flags are not written along with the alias.
+func updateConfigFile(app *clix.App, update func(*clix.ConfigManager)) error {
+	path, err := app.ConfigFile()
+	if err != nil {
+		return err
+	}
+	onDisk := clix.NewConfigManager(app.Name)
+	if err := onDisk.Load(path); err != nil {
+		return err
+	}
+	update(onDisk)
+	return onDisk.Save(path)
+}
@@ -0,0 +1,231 @@
+package alias
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func newAliasApp(t *testing.T, got *[]string, aliases map[string]string) *clix.App {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app := clix.NewApp("myapp")
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+
+	var quick bool
+	var args []string
+	checkout := clix.NewCommand("checkout")
+	checkout.Flags.BoolVar(clix.BoolVarOptions{
+		FlagOptions: clix.FlagOptions{Name: "quick"},
+		Value:       &quick,
+	})
+	checkout.Flags.StringSliceVar(clix.StringSliceVarOptions{
+		FlagOptions: clix.FlagOptions{Name: "refs", Positional: true, Variadic: true},
+		Value:       &args,
+	})
+	checkout.Run = func(*clix.Context) error {
+		*got = append([]string{"checkout"}, args...)
+		if quick {
+			*got = append(*got, "(quick)")
+		}
+		return nil
+	}
+	app.Root.AddCommand(checkout)
+	app.AddExtension(Extension{})
+
+	for name, expansion := range aliases {
+		app.Config.Set(ConfigPrefix+name, expansion)
+	}
+	return app
+}
+
+func TestAliasExpansion(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "simple", args: []string{"co"}, want: []string{"checkout", "(quick)"}},
+		{name: "extra args appended", args: []string{"co", "main", "dev"}, want: []string{"checkout", "main", "dev", "(quick)"}},
+		{name: "alias of alias", args: []string{"c", "main"}, want: []string{"checkout", "main", "(quick)"}},
+		{name: "command not shadowed", args: []string{"checkout", "main"}, want: []string{"checkout", "main"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			app := newAliasApp(t, &got, map[string]string{
+				"co":       "checkout --quick",
+				"c":        "co",
+				"checkout": "co",
+			})
+			if err := app.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ran %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAliasLoop(t *testing.T) {
+	var got []string
+	app := newAliasApp(t, &got, map[string]string{"a": "b x", "b": "a y"})
+
+	err := app.Run(context.Background(), []string{"a"})
+	if !errors.Is(err, ErrAliasLoop) {
+		t.Fatalf("error = %v, want ErrAliasLoop", err)
+	}
+	if !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("error should show the loop, got %q", err)
+	}
+	if got != nil {
+		t.Errorf("command ran despite the loop: %q", got)
+	}
+}
+
+func TestAliasCommands(t *testing.T) {
+	var got []string
+	app := newAliasApp(t, &got, nil)
+	out := app.Out.(*bytes.Buffer)
+
+	if err := app.Run(context.Background(), []string{"alias", "set", "co", "checkout", "--quick"}); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+
+	// A fresh app reads the alias back from the saved config file.
+	fresh := clix.NewApp("myapp")
+	fresh.Out = &bytes.Buffer{}
+	fresh.AddExtension(Extension{})
+	if err := fresh.Run(context.Background(), []string{"alias", "list"}); err != nil {
+		t.Fatalf("alias list: %v", err)
+	}
+	if list := fresh.Out.(*bytes.Buffer).String(); strings.TrimSpace(list) != "co = checkout --quick" {
+		t.Errorf("alias list = %q", list)
+	}
+
+	if err := app.Run(context.Background(), []string{"co", "main"}); err != nil {
+		t.Fatalf("Run co: %v", err)
+	}
+	if want := []string{"checkout", "main", "(quick)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+
+	if err := app.Run(context.Background(), []string{"alias", "set", "checkout", "co"}); err == nil {
+		t.Error("expected an error when an alias shadows a command")
+	}
+
+	out.Reset()
+	if err := app.Run(context.Background(), []string{"alias", "remove", "co"}); err != nil {
+		t.Fatalf("alias remove: %v", err)
+	}
+	if err := app.Run(context.Background(), []string{"alias", "remove", "co"}); err == nil {
+		t.Error("expected an error removing a missing alias")
+	}
+	if _, ok := Aliases(app)["co"]; ok {
+		t.Error("alias still present after remove")
+	}
+}
+
+func TestAliasKeepsMultiWordArguments(t *testing.T) {
+	var got []string
+	app := newAliasApp(t, &got, nil)
+
+	if err := app.Run(context.Background(), []string{"alias", "set", "two", "checkout", "two words", `say "hi"`, ""}); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+	if err := app.Run(context.Background(), []string{"two", "main"}); err != nil {
+		t.Fatalf("Run two: %v", err)
+	}
+	if want := []string{"checkout", "two words", `say "hi"`, "", "main"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+}
+
+func TestAliasSetKeepsAppFlagsInExpansion(t *testing.T) {
+	var got []string
+	app := newAliasApp(t, &got, nil)
+	var format string
+	app.Flags().StringVar(clix.StringVarOptions{FlagOptions: clix.FlagOptions{Name: "format"}, Value: &format})
+
+	if err := app.Run(context.Background(), []string{"alias", "set", "st", "checkout", "--format", "json", "-h"}); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+	if want := "checkout --format json -h"; Aliases(app)["st"] != want {
+		t.Errorf("alias st = %q, want %q", Aliases(app)["st"], want)
+	}
+	if format != "" {
+		t.Errorf("alias set consumed --format %q", format)
+	}
+}
+
+func TestAliasCommandsSaveOnlyTheAlias(t *testing.T) {
+	var got []string
+	app := newAliasApp(t, &got, nil)
+	path, err := app.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("project: demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Held in memory only, as if from a .env file or another layer.
+	app.Config.Set("token", "secret")
+
+	if err := app.Run(context.Background(), []string{"alias", "set", "co", "checkout"}); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+	if err := app.Run(context.Background(), []string{"alias", "set", "ci", "checkout", "--quick"}); err != nil {
+		t.Fatalf("alias set: %v", err)
+	}
+	if err := app.Run(context.Background(), []string{"alias", "remove", "co"}); err != nil {
+		t.Fatalf("alias remove: %v", err)
+	}
+
+	onDisk := clix.NewConfigManager("myapp")
+	if err := onDisk.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"project": "demo", "aliases.ci": "checkout --quick"}
+	if values := onDisk.Values(); !reflect.DeepEqual(values, want) {
+		t.Errorf("config file holds %v, want %v", values, want)
+	}
+}
+
+func TestSplitExpansion(t *testing.T) {
+	tests := map[string][]string{
+		"checkout --quick":             {"checkout", "--quick"},
+		`  commit   -m "two words"  `:  {"commit", "-m", "two words"},
+		`commit --message="a \"b\" c"`: {"commit", `--message=a "b" c`},
+		`log --format='%h %s' ''`:      {"log", "--format=%h %s", ""},
+		"":                             nil,
+	}
+	for input, want := range tests {
+		got, err := splitExpansion(input)
+		if err != nil {
+			t.Errorf("splitExpansion(%q): %v", input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("splitExpansion(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{`commit -m "open`, "log 'open"} {
+		if _, err := splitExpansion(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
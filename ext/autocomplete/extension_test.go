@@ -154,3 +154,48 @@ func findChildInTest(cmd *clix.Command, name string) *clix.Command {
 	}
 	return nil
 }
+
+func TestAutocompleteHiddenCommands(t *testing.T) {
+	newApp := func(ext Extension) (*clix.App, *bytes.Buffer) {
+		app := clix.NewApp("test")
+		root := clix.NewCommand("test")
+		visible := clix.NewCommand("deploy")
+		hidden := clix.NewCommand("debug-dump")
+		hidden.Hidden = true
+		var token string
+		visible.Flags.StringVar(clix.WithFlagName("internal-token"), clix.WithFlagHidden(), clix.WithStringValue(&token))
+		root.AddCommand(visible)
+		root.AddCommand(hidden)
+		app.Root = root
+		app.AddExtension(ext)
+
+		var output bytes.Buffer
+		app.Out = &output
+		return app, &output
+	}
+
+	t.Run("excluded by default", func(t *testing.T) {
+		app, output := newApp(Extension{})
+		if err := app.Run(context.Background(), []string{"autocomplete", "--shell", "bash"}); err != nil {
+			t.Fatalf("autocomplete failed: %v", err)
+		}
+		script := output.String()
+		if !strings.Contains(script, "deploy") {
+			t.Errorf("expected visible command in script, got:\n%s", script)
+		}
+		if strings.Contains(script, "debug-dump") || strings.Contains(script, "--internal-token") {
+			t.Errorf("expected hidden command and flag to be omitted, got:\n%s", script)
+		}
+	})
+
+	t.Run("included on request", func(t *testing.T) {
+		app, output := newApp(Extension{IncludeHidden: true})
+		if err := app.Run(context.Background(), []string{"autocomplete", "--shell", "bash"}); err != nil {
+			t.Fatalf("autocomplete failed: %v", err)
+		}
+		script := output.String()
+		if !strings.Contains(script, "debug-dump") || !strings.Contains(script, "--internal-token") {
+			t.Errorf("expected hidden command and flag in script, got:\n%s", script)
+		}
+	})
+}
@@ -0,0 +1,262 @@
+// Package cobracompat eases migrating from cobra by converting cobra-shaped
+// command definitions into clix commands.
+//
+// Command mirrors the subset of cobra.Command most CLIs use: Use, Short,
+// Long, Run/RunE, subcommands and pflag-style flag registration. Existing
+// definitions usually only need their import changed:
+//
+//	deploy := &cobracompat.Command{
+//		Use:   "deploy [service...]",
+//		Short: "Deploy services",
+//		RunE: func(cmd *cobracompat.Command, args []string) error {
+//			fmt.Fprintln(cmd.OutOrStdout(), "deploying", args)
+//			return nil
+//		},
+//	}
+//	deploy.Flags().StringVarP(&region, "region", "r", "us-east1", "Target region")
+//
+//	app := clix.NewApp("myapp")
+//	app.Root.AddCommand(cobracompat.Convert(deploy))
+//
+// Flags are registered on the underlying clix flag set right away, so they
+// resolve from the environment and config like native clix flags. Positional
+// arguments are passed to Run as args.
+package cobracompat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// argsFlag is the hidden variadic positional that collects Run's args. The
+// name is reserved so it cannot clash with a flag the command defines.
+const argsFlag = "__cobracompat_args"
+
+// Command is a cobra-shaped command definition. Convert turns it into a
+// clix.Command.
+type Command struct {
+	// Use is the one-line usage message. Its first word is the command name,
+	// as in "deploy [service...]".
+	Use string
+
+	// Aliases are alternative names for the command.
+	Aliases []string
+
+	// Short is the description shown in command lists.
+	Short string
+
+	// Long is the description shown in the command's own help.
+	Long string
+
+	// Example shows example usage in help output.
+	Example string
+
+	// Hidden hides the command from help output.
+	Hidden bool
+
+	// Deprecated marks the command as deprecated with this message.
+	Deprecated string
+
+	// PersistentPreRunE runs before this command and any descendant.
+	PersistentPreRunE func(cmd *Command, args []string) error
+
+	// PreRunE runs before Run or RunE.
+	PreRunE func(cmd *Command, args []string) error
+
+	// Run is the command handler. RunE takes precedence when both are set.
+	Run func(cmd *Command, args []string)
+
+	// RunE is the command handler that may fail.
+	RunE func(cmd *Command, args []string) error
+
+	// PostRunE runs after Run or RunE succeeds.
+	PostRunE func(cmd *Command, args []string) error
+
+	commands  []*Command
+	native    *clix.Command
+	converted bool
+	args      []string
+	ctx       *clix.Context
+}
+
+// Name returns the command name: the first word of Use.
+func (c *Command) Name() string {
+	name, _, _ := strings.Cut(strings.TrimSpace(c.Use), " ")
+	return name
+}
+
+// AddCommand adds subcommands.
+func (c *Command) AddCommand(cmds ...*Command) {
+	c.commands = append(c.commands, cmds...)
+}
+
+// Commands returns the subcommands added with AddCommand.
+func (c *Command) Commands() []*Command {
+	return c.commands
+}
+
+// Flags returns the command's flags, registered with pflag-style methods.
+func (c *Command) Flags() *FlagSet {
+	return &FlagSet{fs: c.command().Flags}
+}
+
+// PersistentFlags returns the flags shared with every descendant.
+func (c *Command) PersistentFlags() *FlagSet {
+	return &FlagSet{fs: c.command().PersistentFlags}
+}
+
+// MarkFlagRequired marks the named local or persistent flag as required.
+func (c *Command) MarkFlagRequired(name string) error {
+	if flag := c.Flags().lookup(name); flag != nil {
+		flag.Required = true
+		return nil
+	}
+	return c.PersistentFlags().MarkRequired(name)
+}
+
+// Context returns the context of the running command, or
+// context.Background outside of Run.
+func (c *Command) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// ClixContext returns the clix context of the running command, for
+// handlers being migrated to ctx.String and friends. It is nil outside of
+// Run.
+func (c *Command) ClixContext() *clix.Context {
+	return c.ctx
+}
+
+// OutOrStdout returns the app's output writer while running, and os.Stdout
+// otherwise.
+func (c *Command) OutOrStdout() io.Writer {
+	if c.ctx != nil && c.ctx.App != nil && c.ctx.App.Out != nil {
+		return c.ctx.App.Out
+	}
+	return os.Stdout
+}
+
+// ErrOrStderr returns the app's error writer while running, and os.Stderr
+// otherwise.
+func (c *Command) ErrOrStderr() io.Writer {
+	if c.ctx != nil && c.ctx.App != nil && c.ctx.App.Err != nil {
+		return c.ctx.App.Err
+	}
+	return os.Stderr
+}
+
+// command returns the clix command backing c, creating it on first use.
+func (c *Command) command() *clix.Command {
+	if c.native == nil {
+		c.native = clix.NewCommand(c.Name())
+	}
+	return c.native
+}
+
+// Convert returns the clix command for c and its subcommands. A command
+// without Run or RunE becomes a group that shows help. Convert c once, after
+// its subcommands and flags are defined: converting a command again, directly
+// or as a subcommand of another converted command, panics.
+//
+// The result is an ordinary clix command, so native clix subcommands can be
+// added to it; persistent hooks of converted ancestors still run for them.
+func Convert(c *Command) *clix.Command {
+	return c.convert(make(map[*clix.Command]*Command))
+}
+
+// convert converts c and its subcommands, recording each in commands so
+// persistent hooks can pass the invoked command on.
+func (c *Command) convert(commands map[*clix.Command]*Command) *clix.Command {
+	if c.converted {
+		panic(fmt.Sprintf("cobracompat: command %q converted twice", c.Name()))
+	}
+	c.converted = true
+	cmd := c.command()
+	commands[cmd] = c
+
+	cmd.Aliases = c.Aliases
+	cmd.Short = c.Short
+	cmd.Long = c.Long
+	cmd.Example = c.Example
+	cmd.Hidden = c.Hidden
+	cmd.Deprecated = c.Deprecated
+
+	if c.PersistentPreRunE != nil {
+		cmd.PersistentPreRun = hook(commands, c.PersistentPreRunE)
+	}
+	if c.PreRunE != nil {
+		cmd.PreRun = hook(commands, c.PreRunE)
+	}
+	if c.PostRunE != nil {
+		cmd.PostRun = hook(commands, c.PostRunE)
+	}
+	if c.Run != nil || c.RunE != nil {
+		cmd.Flags.StringSliceVar(clix.StringSliceVarOptions{
+			FlagOptions: clix.FlagOptions{
+				Name:       argsFlag,
+				Positional: true,
+				Variadic:   true,
+				Hidden:     true,
+			},
+			Value: &c.args,
+		})
+		cmd.Run = hook(commands, func(c *Command, args []string) error {
+			if c.RunE != nil {
+				return c.RunE(c, args)
+			}
+			c.Run(c, args)
+			return nil
+		})
+	}
+
+	for _, child := range c.commands {
+		cmd.AddCommand(child.convert(commands))
+	}
+	return cmd
+}
+
+// hook adapts a cobra-style function to a clix hook. Like cobra, it is
+// called with the invoked command and its arguments, which for persistent
+// hooks may be a descendant of the command defining them. When the invoked
+// command is a native clix command, fn gets its nearest converted ancestor
+// and no arguments.
+func hook(commands map[*clix.Command]*Command, fn func(cmd *Command, args []string) error) func(*clix.Context) error {
+	return func(ctx *clix.Context) error {
+		c, invoked := nearest(commands, ctx)
+		if c == nil {
+			return nil
+		}
+		c.ctx = ctx
+		var args []string
+		if invoked {
+			args = append(args, c.args...)
+		}
+		return fn(c, args)
+	}
+}
+
+// nearest returns the converted command closest to the running command,
+// walking up from ctx.Command, and whether it is the running command itself.
+func nearest(commands map[*clix.Command]*Command, ctx *clix.Context) (*Command, bool) {
+	if c, ok := commands[ctx.Command]; ok {
+		return c, true
+	}
+	if ctx.App == nil || ctx.App.Root == nil {
+		return nil, false
+	}
+	path := ctx.CommandPath()
+	for i := len(path) - 1; i > 0; i-- {
+		if c, ok := commands[ctx.App.Root.ResolvePath(path[1:i])]; ok {
+			return c, false
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,234 @@
+package cobracompat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// newTestApp converts a cobra-shaped tree:
+//
+//	svc (persistent --env)
+//	├── deploy [service...]
+//	└── status
+func newTestApp(t *testing.T) (*clix.App, *bytes.Buffer, *[]string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var calls []string
+	svc := &Command{
+		Use:   "svc",
+		Short: "Manage services",
+		PersistentPreRunE: func(cmd *Command, args []string) error {
+			calls = append(calls, "pre:"+cmd.Name())
+			return nil
+		},
+	}
+	env := svc.PersistentFlags().String("env", "dev", "Environment")
+
+	var (
+		region string
+		wait   time.Duration
+	)
+	deploy := &Command{
+		Use:     "deploy [service...]",
+		Aliases: []string{"up"},
+		Short:   "Deploy services",
+	}
+	deploy.Flags().StringVarP(&region, "region", "r", "us-east1", "Target region")
+	deploy.Flags().DurationVar(&wait, "wait", 30*time.Second, "How long to wait")
+	replicas := deploy.Flags().Int("replicas", 1, "Replica count")
+	dryRun := deploy.Flags().BoolP("dry-run", "n", false, "Print actions only")
+	deploy.RunE = func(cmd *Command, args []string) error {
+		calls = append(calls, "run:"+cmd.Name())
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "env=%s region=%s wait=%s replicas=%d dry-run=%t args=%s\n",
+			*env, region, wait, *replicas, *dryRun, strings.Join(args, ","))
+		return err
+	}
+
+	status := &Command{
+		Use:   "status",
+		Short: "Show status",
+		Run: func(cmd *Command, args []string) {
+			calls = append(calls, "run:"+cmd.Name())
+			if cmd.ClixContext() == nil || cmd.Context() == context.Background() {
+				t.Error("expected the running command's context")
+			}
+		},
+	}
+	svc.AddCommand(deploy, status)
+
+	app := clix.NewApp("demo")
+	out := &bytes.Buffer{}
+	app.Out = out
+	app.Err = &bytes.Buffer{}
+	app.Root.AddCommand(Convert(svc))
+	return app, out, &calls
+}
+
+func TestConvertRunsThroughApp(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"defaults", []string{"svc", "deploy"}, "env=dev region=us-east1 wait=30s replicas=1 dry-run=false args=\n"},
+		{
+			"flags and args",
+			[]string{"svc", "up", "-r", "eu-west1", "--replicas", "3", "-n", "api", "--env", "prod", "web"},
+			"env=prod region=eu-west1 wait=30s replicas=3 dry-run=true args=api,web\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, out, calls := newTestApp(t)
+			if err := app.Run(context.Background(), tt.args); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+			// Persistent hooks receive the invoked command, as in cobra.
+			if got := strings.Join(*calls, " "); got != "pre:deploy run:deploy" {
+				t.Errorf("calls = %q", got)
+			}
+		})
+	}
+}
+
+func TestConvertMapsCommandFields(t *testing.T) {
+	app, _, calls := newTestApp(t)
+
+	svc := app.Root.ResolvePath([]string{"svc"})
+	if svc == nil || !svc.IsGroup() || svc.Short != "Manage services" {
+		t.Fatalf("expected svc to convert to a group, got %+v", svc)
+	}
+	deploy := app.Root.ResolvePath([]string{"svc", "up"})
+	if deploy == nil || deploy.Name != "deploy" {
+		t.Fatalf("expected the up alias to resolve to deploy, got %+v", deploy)
+	}
+
+	if err := app.Run(context.Background(), []string{"svc", "status"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := strings.Join(*calls, " "); got != "pre:status run:status" {
+		t.Errorf("calls = %q", got)
+	}
+}
+
+func TestNativeSubcommandOfConvertedGroup(t *testing.T) {
+	app, out, calls := newTestApp(t)
+
+	svc := app.Root.ResolvePath([]string{"svc"})
+	logs := clix.NewCommand("logs")
+	logs.Run = func(ctx *clix.Context) error {
+		*calls = append(*calls, "run:logs")
+		return nil
+	}
+	svc.AddCommand(logs)
+
+	if err := app.Run(context.Background(), []string{"svc", "logs", "--env", "prod"}); err != nil {
+		t.Fatalf("Run: %v (output %q)", err, out.String())
+	}
+	// The persistent hook runs with the nearest converted command.
+	if got := strings.Join(*calls, " "); got != "pre:svc run:logs" {
+		t.Errorf("calls = %q", got)
+	}
+}
+
+func TestArgsFlagNameIsFree(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var got []string
+	cmd := &Command{Use: "exec", RunE: func(cmd *Command, args []string) error {
+		got = args
+		return nil
+	}}
+	extra := cmd.Flags().String("args", "", "Extra arguments")
+
+	app := clix.NewApp("demo")
+	app.Out = &bytes.Buffer{}
+	app.Root.AddCommand(Convert(cmd))
+	if err := app.Run(context.Background(), []string{"exec", "--args", "-v", "ls"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if *extra != "-v" || len(got) != 1 || got[0] != "ls" {
+		t.Errorf("--args = %q, args = %q", *extra, got)
+	}
+}
+
+func TestConvertTwicePanics(t *testing.T) {
+	child := &Command{Use: "child", Run: func(*Command, []string) {}}
+	Convert(child)
+
+	parent := &Command{Use: "parent"}
+	parent.AddCommand(child)
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), `"child" converted twice`) {
+			t.Fatalf("expected a convert-twice panic, got %v", r)
+		}
+	}()
+	Convert(parent)
+}
+
+func TestRunEErrorIsReturned(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	boom := errors.New("boom")
+	cmd := &Command{Use: "fail", RunE: func(*Command, []string) error { return boom }}
+
+	app := clix.NewApp("demo")
+	app.Out = &bytes.Buffer{}
+	app.Root.AddCommand(Convert(cmd))
+	if err := app.Run(context.Background(), []string{"fail"}); !errors.Is(err, boom) {
+		t.Fatalf("expected RunE error, got %v", err)
+	}
+}
+
+func TestMarkFlagRequired(t *testing.T) {
+	cmd := &Command{Use: "login", Run: func(*Command, []string) {}}
+	cmd.Flags().String("user", "", "User name")
+	cmd.PersistentFlags().String("token", "", "API token")
+
+	for _, name := range []string{"user", "token"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			t.Fatalf("MarkFlagRequired(%q): %v", name, err)
+		}
+	}
+	if err := cmd.MarkFlagRequired("missing"); !errors.Is(err, clix.ErrUnknownFlag) {
+		t.Fatalf("expected ErrUnknownFlag, got %v", err)
+	}
+
+	converted := Convert(cmd)
+	for _, flag := range append(converted.Flags.Flags(), converted.PersistentFlags.Flags()...) {
+		if (flag.Name == "user" || flag.Name == "token") && !flag.Required {
+			t.Errorf("expected --%s to be required", flag.Name)
+		}
+	}
+}
+
+func TestGetters(t *testing.T) {
+	cmd := &Command{Use: "get"}
+	flags := cmd.Flags()
+	flags.String("name", "ada", "")
+	flags.Bool("verbose", true, "")
+	flags.Int("count", 7, "")
+
+	if v, err := flags.GetString("name"); err != nil || v != "ada" {
+		t.Errorf("GetString = %q, %v", v, err)
+	}
+	if v, err := flags.GetBool("verbose"); err != nil || !v {
+		t.Errorf("GetBool = %t, %v", v, err)
+	}
+	if v, err := flags.GetInt("count"); err != nil || v != 7 {
+		t.Errorf("GetInt = %d, %v", v, err)
+	}
+	if _, err := flags.GetString("missing"); !errors.Is(err, clix.ErrUnknownFlag) {
+		t.Errorf("expected ErrUnknownFlag, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package cobracompat
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// FlagSet registers flags with pflag-style methods on a clix.FlagSet. The
+// P variants take a one-letter shorthand.
+type FlagSet struct {
+	fs *clix.FlagSet
+}
+
+// Native returns the underlying clix flag set.
+func (f *FlagSet) Native() *clix.FlagSet {
+	return f.fs
+}
+
+// MarkRequired marks the named flag as required.
+func (f *FlagSet) MarkRequired(name string) error {
+	flag := f.lookup(name)
+	if flag == nil {
+		return unknownFlag(name)
+	}
+	flag.Required = true
+	return nil
+}
+
+// MarkHidden hides the named flag from help output.
+func (f *FlagSet) MarkHidden(name string) error {
+	flag := f.lookup(name)
+	if flag == nil {
+		return unknownFlag(name)
+	}
+	flag.Hidden = true
+	return nil
+}
+
+// MarkDeprecated marks the named flag as deprecated with message.
+func (f *FlagSet) MarkDeprecated(name, message string) error {
+	flag := f.lookup(name)
+	if flag == nil {
+		return unknownFlag(name)
+	}
+	flag.Deprecated = message
+	return nil
+}
+
+func unknownFlag(name string) error {
+	return fmt.Errorf("%w: --%s", clix.ErrUnknownFlag, name)
+}
+
+func (f *FlagSet) lookup(name string) *clix.Flag {
+	for _, flag := range f.fs.Flags() {
+		if flag.Name == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func options(name, shorthand, usage string) clix.FlagOptions {
+	return clix.FlagOptions{Name: name, Short: shorthand, Usage: usage}
+}
+
+// StringVarP defines a string flag stored in p.
+func (f *FlagSet) StringVarP(p *string, name, shorthand, value, usage string) {
+	f.fs.StringVar(clix.StringVarOptions{FlagOptions: options(name, shorthand, usage), Default: value, Value: p})
+}
+
+// StringVar defines a string flag stored in p.
+func (f *FlagSet) StringVar(p *string, name, value, usage string) {
+	f.StringVarP(p, name, "", value, usage)
+}
+
+// StringP defines a string flag and returns a pointer to its value.
+func (f *FlagSet) StringP(name, shorthand, value, usage string) *string {
+	p := new(string)
+	f.StringVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// String defines a string flag and returns a pointer to its value.
+func (f *FlagSet) String(name, value, usage string) *string {
+	return f.StringP(name, "", value, usage)
+}
+
+// BoolVarP defines a bool flag stored in p.
+func (f *FlagSet) BoolVarP(p *bool, name, shorthand string, value bool, usage string) {
+	*p = value
+	f.fs.BoolVar(clix.BoolVarOptions{FlagOptions: options(name, shorthand, usage), Value: p})
+}
+
+// BoolVar defines a bool flag stored in p.
+func (f *FlagSet) BoolVar(p *bool, name string, value bool, usage string) {
+	f.BoolVarP(p, name, "", value, usage)
+}
+
+// BoolP defines a bool flag and returns a pointer to its value.
+func (f *FlagSet) BoolP(name, shorthand string, value bool, usage string) *bool {
+	p := new(bool)
+	f.BoolVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Bool defines a bool flag and returns a pointer to its value.
+func (f *FlagSet) Bool(name string, value bool, usage string) *bool {
+	return f.BoolP(name, "", value, usage)
+}
+
+// IntVarP defines an int flag stored in p.
+func (f *FlagSet) IntVarP(p *int, name, shorthand string, value int, usage string) {
+	f.fs.IntVar(clix.IntVarOptions{FlagOptions: options(name, shorthand, usage), Default: strconv.Itoa(value), Value: p})
+}
+
+// IntVar defines an int flag stored in p.
+func (f *FlagSet) IntVar(p *int, name string, value int, usage string) {
+	f.IntVarP(p, name, "", value, usage)
+}
+
+// IntP defines an int flag and returns a pointer to its value.
+func (f *FlagSet) IntP(name, shorthand string, value int, usage string) *int {
+	p := new(int)
+	f.IntVarP(p, name, shorthand, value, usage)
+	return p
+}
+
+// Int defines an int flag and returns a pointer to its value.
+func (f *FlagSet) Int(name string, value int, usage string) *int {
+	return f.IntP(name, "", value, usage)
+}
+
+// Int64VarP defines an int64 flag stored in p.
+func (f *FlagSet) Int64VarP(p *int64, name, shorthand string, value int64, usage string) {
+	f.fs.Int64Var(clix.Int64VarOptions{FlagOptions: options(name, shorthand, usage), Default: strconv.FormatInt(value, 10), Value: p})
+}
+
+// Int64Var defines an int64 flag stored in p.
+func (f *FlagSet) Int64Var(p *int64, name string, value int64, usage string) {
+	f.Int64VarP(p, name, "", value, usage)
+}
+
+// Float64VarP defines a float64 flag stored in p.
+func (f *FlagSet) Float64VarP(p *float64, name, shorthand string, value float64, usage string) {
+	f.fs.Float64Var(clix.Float64VarOptions{FlagOptions: options(name, shorthand, usage), Default: strconv.FormatFloat(value, 'g', -1, 64), Value: p})
+}
+
+// Float64Var defines a float64 flag stored in p.
+func (f *FlagSet) Float64Var(p *float64, name string, value float64, usage string) {
+	f.Float64VarP(p, name, "", value, usage)
+}
+
+// DurationVarP defines a time.Duration flag stored in p.
+func (f *FlagSet) DurationVarP(p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	f.fs.DurationVar(clix.DurationVarOptions{FlagOptions: options(name, shorthand, usage), Default: value.String(), Value: p})
+}
+
+// DurationVar defines a time.Duration flag stored in p.
+func (f *FlagSet) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	f.DurationVarP(p, name, "", value, usage)
+}
+
+// StringSliceVarP defines a string slice flag stored in p. Each occurrence
+// adds one value; unlike pflag, values are not split on commas.
+func (f *FlagSet) StringSliceVarP(p *[]string, name, shorthand string, value []string, usage string) {
+	f.fs.StringSliceVar(clix.StringSliceVarOptions{FlagOptions: options(name, shorthand, usage), Default: value, Value: p})
+}
+
+// StringSliceVar defines a string slice flag stored in p.
+func (f *FlagSet) StringSliceVar(p *[]string, name string, value []string, usage string) {
+	f.StringSliceVarP(p, name, "", value, usage)
+}
+
+// GetString returns the value of the named string flag.
+func (f *FlagSet) GetString(name string) (string, error) {
+	flag := f.lookup(name)
+	if flag == nil {
+		return "", unknownFlag(name)
+	}
+	return flag.Value.String(), nil
+}
+
+// GetBool returns the value of the named bool flag.
+func (f *FlagSet) GetBool(name string) (bool, error) {
+	value, err := f.GetString(name)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// GetInt returns the value of the named int flag.
+func (f *FlagSet) GetInt(name string) (int, error) {
+	value, err := f.GetString(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,85 @@
+package completion
+
+import (
+	"fmt"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// Extension adds the completion command to a clix app.
+// Unlike a flat word list, the generated scripts track which command the user
+// has typed so far and only offer that command's subcommands, aliases and flags
+// (plus the app-level flags, which are accepted everywhere). When any flag has
+// a Complete function, the scripts also ask the app for runtime candidates via
+// its hidden __complete command.
+//
+// Hidden commands and flags are left out unless IncludeHidden is set.
+//
+// The extension adds:
+//
+//	cli completion <bash|zsh|fish> - Print the completion script for the shell
+//
+// Example:
+//
+//	import (
+//		"github.com/SCKelemen/clix/v2"
+//		"github.com/SCKelemen/clix/v2/ext/completion"
+//	)
+//
+//	app := clix.NewApp("myapp")
+//	app.AddExtension(completion.Extension{})
+//
+//	// Users can then install completion:
+//	//   source <(myapp completion bash)
+//	//   myapp completion zsh > "${fpath[1]}/_myapp"
+//	//   myapp completion fish > ~/.config/fish/completions/myapp.fish
+type Extension struct {
+	// IncludeHidden adds hidden commands and flags to the generated scripts.
+	// By default they are omitted, matching help output.
+	IncludeHidden bool
+}
+
+// Extend implements clix.Extension.
+func (e Extension) Extend(app *clix.App) error {
+	if app.Root == nil {
+		return nil
+	}
+
+	// Only add if not already present
+	if app.Root.ResolvePath([]string{"completion"}) == nil {
+		app.Root.AddCommand(newCompletionCommand(app, Options{IncludeHidden: e.IncludeHidden}))
+	}
+
+	return nil
+}
+
+// NewCompletionCommand returns the "completion <shell>" command.
+// Hidden commands and flags are omitted from the scripts.
+func NewCompletionCommand(app *clix.App) *clix.Command {
+	return newCompletionCommand(app, Options{})
+}
+
+func newCompletionCommand(app *clix.App, opts Options) *clix.Command {
+	cmd := clix.NewCommand("completion")
+	cmd.Short = "Generate shell completion script"
+	cmd.Usage = fmt.Sprintf("%s completion <bash|zsh|fish>", app.Name)
+	cmd.IsExtensionCommand = true
+
+	var shell string
+	cmd.Flags.StringVar(clix.StringVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:       "shell",
+			Usage:      "Shell type (bash, zsh, fish)",
+			Positional: true,
+		},
+		Value: &shell,
+	})
+
+	cmd.Run = func(ctx *clix.Context) error {
+		if shell == "" {
+			return app.RenderHelp(ctx, cmd, app.Out)
+		}
+		return Generate(app, shell, app.Out, opts)
+	}
+	return cmd
+}
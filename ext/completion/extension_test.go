@@ -0,0 +1,144 @@
+package completion
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func newTestApp() *clix.App {
+	app := clix.NewApp("demo")
+	var verbose bool
+	app.Flags().BoolVar(clix.WithFlagName("verbose"), clix.WithFlagShort("v"), clix.WithFlagUsage("Verbose output"), clix.WithBoolValue(&verbose))
+
+	var limit int
+	list := clix.NewCommand("list", clix.WithCommandShort("List projects"))
+	list.Aliases = []string{"ls"}
+	list.Flags.IntVar(clix.WithFlagName("limit"), clix.WithFlagShort("n"), clix.WithFlagUsage("Maximum results"), clix.WithIntegerValue(&limit))
+	var name string
+	list.Flags.StringVar(clix.WithFlagName("name"), clix.WithFlagPositional(), clix.WithStringValue(&name),
+		clix.WithFlagComplete(func(ctx *clix.Context, toComplete string) []string {
+			return []string{"api", "web"}
+		}))
+	list.Run = func(ctx *clix.Context) error { return nil }
+
+	secret := clix.NewCommand("secret", clix.WithCommandShort("Internal"))
+	secret.Hidden = true
+	secret.Run = func(ctx *clix.Context) error { return nil }
+
+	project := clix.NewGroup("project", "Manage projects", list, secret)
+	project.Aliases = []string{"proj"}
+	app.Root.AddCommand(project)
+	return app
+}
+
+func TestGenerateGolden(t *testing.T) {
+	generators := map[string]func(*clix.App, io.Writer) error{
+		"bash": GenerateBash,
+		"zsh":  GenerateZsh,
+		"fish": GenerateFish,
+	}
+	for shell, generate := range generators {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := generate(newTestApp(), &out); err != nil {
+				t.Fatalf("generate failed: %v", err)
+			}
+
+			golden := filepath.Join("testdata", shell+".golden")
+			if *update {
+				if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+					t.Fatalf("write golden: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+			if out.String() != string(want) {
+				t.Errorf("%s output does not match %s (run with -update to refresh):\n%s", shell, golden, out.String())
+			}
+		})
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	app := newTestApp()
+	app.AddExtension(Extension{})
+	var out bytes.Buffer
+	app.Out = &out
+
+	if err := app.Run(context.Background(), []string{"completion", "bash"}); err != nil {
+		t.Fatalf("completion failed: %v", err)
+	}
+	var want bytes.Buffer
+	if err := GenerateBash(app, &want); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if out.String() != want.String() {
+		t.Errorf("expected completion command to print the bash script, got:\n%s", out.String())
+	}
+
+	if err := app.Run(context.Background(), []string{"completion", "tcsh"}); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+}
+
+func TestGenerateInheritedFlags(t *testing.T) {
+	app := clix.NewApp("demo")
+	list := clix.NewCommand("list")
+	list.Run = func(ctx *clix.Context) error { return nil }
+	project := clix.NewGroup("project", "Manage projects", list)
+	var env string
+	project.PersistentFlags.StringVar(clix.WithFlagName("env"), clix.WithStringValue(&env),
+		clix.WithFlagComplete(func(ctx *clix.Context, toComplete string) []string {
+			return []string{"dev", "prod"}
+		}))
+	app.Root.AddCommand(project)
+
+	var out bytes.Buffer
+	if err := GenerateBash(app, &out); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	script := out.String()
+	if !strings.Contains(script, "__complete") {
+		t.Errorf("expected the persistent flag's Complete to enable dynamic completion, got:\n%s", script)
+	}
+	var listWords string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.Contains(line, "demo project list") && strings.Contains(line, "words=") {
+			listWords = line
+		}
+	}
+	if !strings.Contains(listWords, "--env") {
+		t.Errorf("expected --env among the list words, got:\n%s", script)
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	tests := []struct {
+		in, shell, fish string
+	}{
+		{"demo project", `'demo project'`, `'demo project'`},
+		{`$HOME "x" ` + "`id`", `'$HOME "x" ` + "`id`'", `'$HOME "x" ` + "`id`'"},
+		{"it's", `'it'\''s'`, `'it\'s'`},
+		{`a\b`, `'a\b'`, `'a\\b'`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.shell {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.in, got, tt.shell)
+		}
+		if got := fishQuote(tt.in); got != tt.fish {
+			t.Errorf("fishQuote(%q) = %s, want %s", tt.in, got, tt.fish)
+		}
+	}
+}
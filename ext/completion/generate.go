@@ -0,0 +1,347 @@
+package completion
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// node is one command in the completion tree, identified by its path from the
+// root (e.g. "myapp project list").
+type node struct {
+	path     string
+	short    string
+	children []*node
+	names    []string // child name followed by aliases, used to enter this node
+	flags    []flagEntry
+}
+
+// tree is the completion tree plus whether any command offers dynamic
+// candidates through a Flag Complete function.
+type tree struct {
+	*node
+	dynamic bool
+}
+
+type flagEntry struct {
+	long  string
+	short string
+	usage string
+}
+
+// Options configures the generated scripts.
+type Options struct {
+	// IncludeHidden adds hidden commands and flags to the scripts. By default
+	// they are omitted, matching help output.
+	IncludeHidden bool
+}
+
+// Generate writes the completion script for shell ("bash", "zsh" or "fish")
+// to w.
+func Generate(app *clix.App, shell string, w io.Writer, opts Options) error {
+	tree := buildTree(app, opts)
+	switch strings.ToLower(shell) {
+	case "bash":
+		return writeBash(app, tree, w)
+	case "zsh":
+		return writeZsh(app, tree, w)
+	case "fish":
+		return writeFish(app, tree, w)
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+}
+
+// buildTree collects the command tree. Each command offers its own flags,
+// then the persistent flags of itself and its ancestors, nearest first, and
+// finally the app-level flags, because App.Run accepts all of them there.
+func buildTree(app *clix.App, opts Options) tree {
+	dynamic := false
+	var walk func(cmd *clix.Command, path string, persistent []*clix.FlagSet) *node
+	walk = func(cmd *clix.Command, path string, persistent []*clix.FlagSet) *node {
+		n := &node{path: path, short: cmd.Short}
+		sets := append([]*clix.FlagSet{cmd.Flags}, persistent...)
+		if cmd != app.Root {
+			sets = append(sets, app.Root.Flags)
+		}
+		for _, fs := range sets {
+			n.flags = mergeFlags(n.flags, flagEntries(fs, opts.IncludeHidden))
+			if fs != nil {
+				for _, flag := range fs.Flags() {
+					dynamic = dynamic || flag.Complete != nil
+				}
+			}
+		}
+		children := cmd.VisibleChildren()
+		if opts.IncludeHidden {
+			children = cmd.Children
+		}
+		for _, child := range children {
+			inherited := append([]*clix.FlagSet{child.PersistentFlags}, persistent...)
+			c := walk(child, path+" "+child.Name, inherited)
+			c.names = append([]string{child.Name}, child.Aliases...)
+			n.children = append(n.children, c)
+		}
+		return n
+	}
+	root := walk(app.Root, app.Name, nil)
+	return tree{node: root, dynamic: dynamic}
+}
+
+// flagEntries lists the flags of fs, leaving hidden ones out unless
+// includeHidden is set.
+func flagEntries(fs *clix.FlagSet, includeHidden bool) []flagEntry {
+	if fs == nil {
+		return nil
+	}
+	var entries []flagEntry
+	for _, flag := range fs.Flags() {
+		if flag.Hidden && !includeHidden {
+			continue
+		}
+		entries = append(entries, flagEntry{long: flag.Name, short: flag.Short, usage: flag.Usage})
+		for _, alias := range flag.Aliases {
+			entries = append(entries, flagEntry{long: alias, usage: flag.Usage})
+		}
+	}
+	return entries
+}
+
+// mergeFlags appends the flags from extra whose long name is not already in flags.
+func mergeFlags(flags, extra []flagEntry) []flagEntry {
+	seen := make(map[string]bool, len(flags))
+	for _, f := range flags {
+		seen[f.long] = true
+	}
+	for _, f := range extra {
+		if !seen[f.long] {
+			flags = append(flags, f)
+		}
+	}
+	return flags
+}
+
+// nodes returns n and its descendants in depth-first order.
+func (n *node) nodes() []*node {
+	all := []*node{n}
+	for _, child := range n.children {
+		all = append(all, child.nodes()...)
+	}
+	return all
+}
+
+// funcName turns an app name into a shell identifier.
+func funcName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// GenerateBash writes a bash completion script for app to w.
+func GenerateBash(app *clix.App, w io.Writer) error {
+	return Generate(app, "bash", w, Options{})
+}
+
+func writeBash(app *clix.App, tree tree, w io.Writer) error {
+	fn := "_" + funcName(app.Name) + "_completions"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", app.Name)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(&b, "    local cmdpath=%s\n", shellQuote(tree.path))
+	b.WriteString("    local i\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        case \"$cmdpath:${COMP_WORDS[i]}\" in\n")
+	for _, n := range tree.nodes() {
+		for _, child := range n.children {
+			patterns := make([]string, len(child.names))
+			for i, name := range child.names {
+				patterns[i] = shellQuote(n.path + ":" + name)
+			}
+			fmt.Fprintf(&b, "            %s) cmdpath=%s ;;\n", strings.Join(patterns, "|"), shellQuote(child.path))
+		}
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n")
+	if tree.dynamic {
+		// Ask the app for runtime candidates first; fall back to the static
+		// words when it has none for this position.
+		b.WriteString("    local dynamic\n")
+		fmt.Fprintf(&b, "    dynamic=$(%s __complete \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null)\n", app.Name)
+		b.WriteString("    if [[ -n \"$dynamic\" ]]; then\n")
+		b.WriteString("        local IFS=$'\\n'\n")
+		b.WriteString("        COMPREPLY=( $dynamic )\n")
+		b.WriteString("        return\n")
+		b.WriteString("    fi\n")
+	}
+	b.WriteString("    local words=\"\"\n")
+	b.WriteString("    case \"$cmdpath\" in\n")
+	for _, n := range tree.nodes() {
+		var words []string
+		for _, child := range n.children {
+			words = append(words, child.names...)
+		}
+		for _, f := range n.flags {
+			words = append(words, "--"+f.long)
+			if f.short != "" {
+				words = append(words, "-"+f.short)
+			}
+		}
+		fmt.Fprintf(&b, "        %s) words=%s ;;\n", shellQuote(n.path), shellQuote(strings.Join(words, " ")))
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    COMPREPLY=( $(compgen -W \"$words\" -- \"$cur\") )\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, app.Name)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// GenerateZsh writes a zsh completion script for app to w.
+func GenerateZsh(app *clix.App, w io.Writer) error {
+	return Generate(app, "zsh", w, Options{})
+}
+
+func writeZsh(app *clix.App, tree tree, w io.Writer) error {
+	fn := "_" + funcName(app.Name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", app.Name)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	fmt.Fprintf(&b, "    local cmdpath=%s\n", shellQuote(tree.path))
+	b.WriteString("    local i\n")
+	b.WriteString("    for ((i = 2; i < CURRENT; i++)); do\n")
+	b.WriteString("        case \"$cmdpath:${words[i]}\" in\n")
+	for _, n := range tree.nodes() {
+		for _, child := range n.children {
+			patterns := make([]string, len(child.names))
+			for i, name := range child.names {
+				patterns[i] = shellQuote(n.path + ":" + name)
+			}
+			fmt.Fprintf(&b, "            (%s) cmdpath=%s ;;\n", strings.Join(patterns, "|"), shellQuote(child.path))
+		}
+	}
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n")
+	if tree.dynamic {
+		b.WriteString("    local -a dynamic\n")
+		fmt.Fprintf(&b, "    dynamic=(${(f)\"$(%s __complete \"${(@)words[2,CURRENT]}\" 2>/dev/null)\"})\n", app.Name)
+		b.WriteString("    if (( ${#dynamic} )); then\n")
+		b.WriteString("        compadd -a dynamic\n")
+		b.WriteString("        return\n")
+		b.WriteString("    fi\n")
+	}
+	b.WriteString("    local -a candidates\n")
+	b.WriteString("    case \"$cmdpath\" in\n")
+	for _, n := range tree.nodes() {
+		fmt.Fprintf(&b, "        (%s)\n", shellQuote(n.path))
+		b.WriteString("            candidates=(\n")
+		for _, child := range n.children {
+			for _, name := range child.names {
+				fmt.Fprintf(&b, "                %s\n", shellQuote(describe(name, child.short)))
+			}
+		}
+		for _, f := range n.flags {
+			fmt.Fprintf(&b, "                %s\n", shellQuote(describe("--"+f.long, f.usage)))
+			if f.short != "" {
+				fmt.Fprintf(&b, "                %s\n", shellQuote(describe("-"+f.short, f.usage)))
+			}
+		}
+		b.WriteString("            )\n")
+		b.WriteString("            ;;\n")
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    _describe 'command' candidates\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", fn, app.Name)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// describe formats a _describe entry, escaping colons in the value.
+func describe(value, desc string) string {
+	value = strings.ReplaceAll(value, ":", "\\:")
+	if desc == "" {
+		return value
+	}
+	return value + ":" + desc
+}
+
+// shellQuote single-quotes s for bash and zsh; embedded quotes close, escape
+// and reopen the string.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote single-quotes s for fish, where a quote is escaped as \'.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// GenerateFish writes a fish completion script for app to w.
+func GenerateFish(app *clix.App, w io.Writer) error {
+	return Generate(app, "fish", w, Options{})
+}
+
+func writeFish(app *clix.App, tree tree, w io.Writer) error {
+	fn := "__" + funcName(app.Name) + "_cmdpath"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", app.Name)
+	fmt.Fprintf(&b, "function %s\n", fn)
+	fmt.Fprintf(&b, "    set -l cmdpath %s\n", fishQuote(tree.path))
+	b.WriteString("    for word in (commandline -opc)[2..-1]\n")
+	b.WriteString("        switch \"$cmdpath:$word\"\n")
+	for _, n := range tree.nodes() {
+		for _, child := range n.children {
+			patterns := make([]string, len(child.names))
+			for i, name := range child.names {
+				patterns[i] = fishQuote(n.path + ":" + name)
+			}
+			fmt.Fprintf(&b, "            case %s\n", strings.Join(patterns, " "))
+			fmt.Fprintf(&b, "                set cmdpath %s\n", fishQuote(child.path))
+		}
+	}
+	b.WriteString("        end\n")
+	b.WriteString("    end\n")
+	b.WriteString("    echo $cmdpath\n")
+	b.WriteString("end\n\n")
+	fmt.Fprintf(&b, "complete -c %s -f\n", app.Name)
+	if tree.dynamic {
+		fmt.Fprintf(&b, "complete -c %s -a %s\n", app.Name, fishQuote(fmt.Sprintf("(%s __complete (commandline -opc)[2..-1] (commandline -ct))", app.Name)))
+	}
+	for _, n := range tree.nodes() {
+		cond := fishQuote(fmt.Sprintf("test (%s) = %s", fn, fishQuote(n.path)))
+		for _, child := range n.children {
+			for _, name := range child.names {
+				fmt.Fprintf(&b, "complete -c %s -n %s -a %s", app.Name, cond, fishQuote(name))
+				if child.short != "" {
+					fmt.Fprintf(&b, " -d %s", fishQuote(child.short))
+				}
+				b.WriteString("\n")
+			}
+		}
+		for _, f := range n.flags {
+			fmt.Fprintf(&b, "complete -c %s -n %s -l %s", app.Name, cond, f.long)
+			if f.short != "" {
+				fmt.Fprintf(&b, " -s %s", f.short)
+			}
+			if f.usage != "" {
+				fmt.Fprintf(&b, " -d %s", fishQuote(f.usage))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
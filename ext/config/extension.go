@@ -21,9 +21,11 @@ import (
 //   - cli config set --key <key_path> --value <value>  - Persist a value at the given path
 //   - cli config unset --key <key_path>       - Remove a value from persisted config (no-op if missing)
 //   - cli config reset                        - Remove all persisted configuration
+//   - cli config debug                        - Show every effective value and its source
 //
 // Key paths use dot notation (e.g. "project.default", "api.timeout").
 // List/get/set/unset/reset operate purely on persisted config—they do not reflect flags or env vars.
+// Debug resolves app flags, env vars and config like a command would, via Context.EffectiveConfig.
 // The `list` command respects the `--format` flag (json|yaml|text). Default output is YAML/text.
 //
 // Example:
@@ -76,6 +78,7 @@ func NewConfigCommand(app *clix.App) *clix.Command {
 		configSetCommand(app),
 		configUnsetCommand(app),
 		configResetCommand(app),
+		configDebugCommand(app),
 	)
 	cmd.Usage = fmt.Sprintf("%s config [command]", app.Name)
 	cmd.IsExtensionCommand = true
@@ -233,6 +236,19 @@ func configResetCommand(app *clix.App) *clix.Command {
 	return cmd
 }
 
+func configDebugCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewCommand("debug")
+	cmd.Short = "Show effective configuration values and their sources"
+	cmd.Run = func(ctx *clix.Context) error {
+		values := make(map[string]interface{})
+		for key, value := range ctx.EffectiveConfig() {
+			values[key] = value
+		}
+		return app.FormatOutput(values)
+	}
+	return cmd
+}
+
 func quoteIfNeeded(value string) string {
 	if strings.ContainsAny(value, ":#") || strings.HasPrefix(value, " ") || strings.HasSuffix(value, " ") {
 		return fmt.Sprintf("%q", value)
@@ -273,6 +273,35 @@ func TestConfigExtension(t *testing.T) {
 		}
 	})
 
+	t.Run("config debug shows effective values and sources", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", tempDir)
+		t.Setenv("TEST_REGION", "eu-west")
+
+		app := clix.NewApp("test")
+		configDir := filepath.Join(tempDir, "test")
+		os.MkdirAll(configDir, 0755)
+		os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("project.default: dev\n"), 0644)
+
+		root := clix.NewCommand("test")
+		var region string
+		root.Flags.StringVar(clix.WithFlagName("region"), clix.WithStringValue(&region))
+		app.Root = root
+
+		var output bytes.Buffer
+		app.Out = &output
+		app.AddExtension(Extension{})
+
+		if err := app.Run(context.Background(), []string{"config", "debug"}); err != nil {
+			t.Fatalf("config debug command failed: %v", err)
+		}
+
+		expected := "project.default = dev (config file)\nregion = eu-west (environment variable)"
+		if strings.TrimSpace(output.String()) != expected {
+			t.Fatalf("unexpected debug output.\nexpected:\n%s\n\ngot:\n%s", expected, output.String())
+		}
+	})
+
 	t.Run("config API still works without extension", func(t *testing.T) {
 		// Config API should work even without the extension
 		app := clix.NewApp("test")
@@ -6,33 +6,63 @@ import (
 	"github.com/SCKelemen/clix/v2"
 )
 
-// Extension registers a global --format / -f flag on the app root.
-// Import this extension to opt in to output format selection; without it
-// the flag is not present and callers should default to clix.FormatText.
+// Extension registers the global --format / -f and --output / -o flags on the
+// app root. Import this extension to opt in to output format selection;
+// without it the flags are not present and callers should default to
+// clix.FormatText. When --output names a file, App.FormatOutput and
+// Context.OutputWriter write to it instead of app.Out.
+//
+// Flags the app already defines are left alone: if the app registers its own
+// --format it is used as is, and a short name taken by an app flag or by any
+// command's flags is omitted.
 //
 // Example:
 //
 //	app := clix.NewApp("myapp")
 //	app.AddExtension(format.Extension{})
-//	// Now --format / -f is available globally
+//	// Now --format / -f and --output / -o are available globally
 type Extension struct{}
 
 // Extend implements clix.Extension.
 func (Extension) Extend(app *clix.App) error {
-	var format = clix.FormatText
-	app.Flags().StringVar(clix.StringVarOptions{
-		FlagOptions: clix.FlagOptions{
-			Name:  "format",
-			Short: "f",
-			Usage: "Output format (json, yaml, text)",
-		},
-		Default: clix.FormatText,
-		Value:   &format,
-	})
+	flags := app.Flags()
+	if flags.Lookup("format") == nil {
+		var format = clix.FormatText
+		flags.StringVar(clix.StringVarOptions{
+			FlagOptions: clix.FlagOptions{
+				Name:  "format",
+				Short: freeShort(app, "f"),
+				Usage: "Output format (json, yaml, text, table, csv, tsv)",
+			},
+			Default: clix.FormatText,
+			Value:   &format,
+		})
+	}
+	if flags.Lookup("output") == nil {
+		var output string
+		flags.StringVar(clix.StringVarOptions{
+			FlagOptions: clix.FlagOptions{
+				Name:  "output",
+				Short: freeShort(app, "o"),
+				Usage: "Write output to a file instead of stdout",
+			},
+			Value: &output,
+		})
+	}
 	return nil
 }
 
-// OutputFormat reads the --format flag from the app and validates it.
+// freeShort returns short if no flag of the app or its commands uses it yet,
+// and "" otherwise.
+func freeShort(app *clix.App, short string) string {
+	if app.ShortFlagInUse(short) {
+		return ""
+	}
+	return short
+}
+
+// OutputFormat reads the --format flag from the app and validates it against
+// the built-in formats and those added with App.RegisterFormat.
 // Returns clix.FormatText if the flag is absent or invalid.
 func OutputFormat(app *clix.App) string {
 	flags := app.Flags()
@@ -40,9 +70,7 @@ func OutputFormat(app *clix.App) string {
 		return clix.FormatText
 	}
 	if v, ok := flags.String("format"); ok && v != "" {
-		f := strings.ToLower(v)
-		switch f {
-		case clix.FormatJSON, clix.FormatYAML, clix.FormatText:
+		if f := strings.ToLower(v); app.HasFormat(f) {
 			return f
 		}
 	}
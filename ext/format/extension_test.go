@@ -2,7 +2,11 @@ package format_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -70,6 +74,29 @@ func TestOutputFormat(t *testing.T) {
 	})
 }
 
+func TestExtensionKeepsAppFlags(t *testing.T) {
+	app := clix.NewApp("test")
+	var fields string
+	app.Flags().StringVar(clix.WithFlagName("fields"), clix.WithFlagShort("f"), clix.WithStringValue(&fields))
+	app.AddExtension(format.Extension{})
+	if err := app.ApplyExtensions(); err != nil {
+		t.Fatalf("apply extensions: %v", err)
+	}
+
+	if _, err := app.Flags().Parse([]string{"-f", "name", "--format", "json"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if fields != "name" {
+		t.Errorf("expected -f to set --fields, got %q", fields)
+	}
+	if f := format.OutputFormat(app); f != "json" {
+		t.Errorf("expected format 'json', got %q", f)
+	}
+	if flag := app.Flags().Lookup("format"); flag == nil || flag.Short != "" {
+		t.Errorf("expected --format without a short name, got %+v", flag)
+	}
+}
+
 func TestFormatOutputViaExtension(t *testing.T) {
 	app := newAppWithFormat()
 	app.Out = &bytes.Buffer{}
@@ -119,3 +146,192 @@ func TestFormatOutputViaExtension(t *testing.T) {
 		app.Out.(*bytes.Buffer).Reset()
 	})
 }
+
+func TestFormatOutputTable(t *testing.T) {
+	app := newAppWithFormat()
+	var out bytes.Buffer
+	app.Out = &out
+	app.Flags().Parse([]string{"--format", "table"})
+
+	if f := format.OutputFormat(app); f != clix.FormatTable {
+		t.Fatalf("expected format 'table', got %q", f)
+	}
+
+	data := []map[string]interface{}{{"name": "api", "port": 8080}}
+	if err := app.FormatOutput(data); err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	if want := "NAME  PORT\napi   8080\n"; out.String() != want {
+		t.Errorf("unexpected table output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	app := newAppWithFormat()
+	var out bytes.Buffer
+	app.Out = &out
+	app.RegisterFormat("ndjson", func(w io.Writer, v interface{}) error {
+		enc := json.NewEncoder(w)
+		for _, item := range v.([]map[string]interface{}) {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	app.RegisterFormat("JSON", func(w io.Writer, v interface{}) error {
+		_, err := io.WriteString(w, "custom json\n")
+		return err
+	})
+
+	data := []map[string]interface{}{{"id": 1}, {"id": 2}}
+
+	t.Run("custom format through --format", func(t *testing.T) {
+		out.Reset()
+		app.Flags().Parse([]string{"--format", "ndjson"})
+		if f := format.OutputFormat(app); f != "ndjson" {
+			t.Fatalf("expected registered format to be accepted, got %q", f)
+		}
+		if err := app.FormatOutput(data); err != nil {
+			t.Fatalf("FormatOutput failed: %v", err)
+		}
+		if want := "{\"id\":1}\n{\"id\":2}\n"; out.String() != want {
+			t.Errorf("unexpected ndjson output: %q, want %q", out.String(), want)
+		}
+	})
+
+	t.Run("registered format overrides built-in", func(t *testing.T) {
+		out.Reset()
+		app.Flags().Parse([]string{"--format", "json"})
+		if err := app.FormatOutput(data); err != nil {
+			t.Fatalf("FormatOutput failed: %v", err)
+		}
+		if out.String() != "custom json\n" {
+			t.Errorf("expected override to be used, got %q", out.String())
+		}
+	})
+}
+
+func TestOutputFlag(t *testing.T) {
+	newApp := func() *clix.App {
+		app := clix.NewApp("test")
+		app.AddExtension(format.Extension{})
+		list := clix.NewCommand("list")
+		list.Run = func(ctx *clix.Context) error {
+			return ctx.App.FormatOutput(map[string]interface{}{"name": "api"})
+		}
+		app.Root.AddCommand(list)
+		return app
+	}
+
+	t.Run("writes to the named file", func(t *testing.T) {
+		app := newApp()
+		var out bytes.Buffer
+		app.Out = &out
+		path := filepath.Join(t.TempDir(), "result.json")
+		if err := os.WriteFile(path, []byte("stale contents that should be truncated"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := app.Run(context.Background(), []string{"list", "--output", path, "--format", "json"}); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("expected nothing on app.Out, got %q", out.String())
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read output file: %v", err)
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal(contents, &result); err != nil {
+			t.Fatalf("output file is not valid JSON: %v, contents: %s", err, contents)
+		}
+		if result["name"] != "api" {
+			t.Errorf("unexpected JSON output: %v", result)
+		}
+	})
+
+	t.Run("app that owns -o keeps it", func(t *testing.T) {
+		app := clix.NewApp("test")
+		var owner string
+		app.Flags().StringVar(clix.WithFlagName("owner"), clix.WithFlagShort("o"), clix.WithStringValue(&owner))
+		app.AddExtension(format.Extension{})
+		list := clix.NewCommand("list")
+		list.Run = func(ctx *clix.Context) error {
+			return ctx.App.FormatOutput(map[string]interface{}{"owner": owner})
+		}
+		app.Root.AddCommand(list)
+		var out bytes.Buffer
+		app.Out = &out
+		path := filepath.Join(t.TempDir(), "result.json")
+
+		if err := app.Run(context.Background(), []string{"list", "-o", "alice", "--output", path, "--format", "json"}); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if owner != "alice" {
+			t.Errorf("expected -o to set --owner, got %q", owner)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read output file: %v", err)
+		}
+		if !strings.Contains(string(contents), `"owner": "alice"`) {
+			t.Errorf("expected --output to still write the file, got %q", contents)
+		}
+	})
+
+	t.Run("command that owns -o keeps it", func(t *testing.T) {
+		app := clix.NewApp("test")
+		app.AddExtension(format.Extension{})
+		var out string
+		build := clix.NewCommand("build")
+		build.Flags.StringVar(clix.WithFlagName("out"), clix.WithFlagShort("o"), clix.WithStringValue(&out))
+		build.Run = func(ctx *clix.Context) error {
+			return ctx.App.FormatOutput(map[string]interface{}{"out": out})
+		}
+		app.Root.AddCommand(build)
+		var buf bytes.Buffer
+		app.Out = &buf
+		dir := t.TempDir()
+		t.Chdir(dir)
+
+		if err := app.Run(context.Background(), []string{"build", "-o", "bin"}); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if out != "bin" {
+			t.Errorf("expected -o to set --out, got %q", out)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "bin")); !os.IsNotExist(err) {
+			t.Errorf("expected no file named bin, stat error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "bin") {
+			t.Errorf("expected output on app.Out, got %q", buf.String())
+		}
+		if flag := app.Flags().Lookup("output"); flag == nil || flag.Short != "" {
+			t.Errorf("expected --output without a short name, got %+v", flag)
+		}
+	})
+
+	t.Run("defaults to app.Out", func(t *testing.T) {
+		app := newApp()
+		var out bytes.Buffer
+		app.Out = &out
+		var writer io.Writer
+		app.Root.Children[0].Run = func(ctx *clix.Context) error {
+			writer = ctx.OutputWriter()
+			return ctx.App.FormatOutput(map[string]interface{}{"name": "api"})
+		}
+
+		if err := app.Run(context.Background(), []string{"list", "-f", "json"}); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if writer != io.Writer(&out) {
+			t.Errorf("expected OutputWriter to return app.Out, got %T", writer)
+		}
+		if !strings.Contains(out.String(), `"name": "api"`) {
+			t.Errorf("expected JSON on app.Out, got %q", out.String())
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 //
 //   - cli help                       - Show help for the root command
 //   - cli help --command [command]   - Show help for a specific command
+//   - cli help --filter [text]       - List only subcommands matching text
+//   - cli help search [query]        - Search help text across all commands
 //
 // Note: Flag-based help (-h, --help) is handled by the core library
 // and does not require this extension. This extension only adds the
@@ -63,8 +65,9 @@ func findChild(cmd *clix.Command, name string) *clix.Command {
 func NewHelpCommand(app *clix.App) *clix.Command {
 	cmd := clix.NewCommand("help")
 	cmd.Short = "Show help for commands"
-	cmd.Usage = fmt.Sprintf("%s help [--command <name>]", app.Name)
+	cmd.Usage = fmt.Sprintf("%s help [--command <name>] [--filter <text>]", app.Name)
 	cmd.IsExtensionCommand = true
+	cmd.AddCommand(NewSearchCommand(app))
 
 	var command string
 	cmd.Flags.StringVar(clix.StringVarOptions{
@@ -75,6 +78,15 @@ func NewHelpCommand(app *clix.App) *clix.Command {
 		Value: &command,
 	})
 
+	var filter string
+	cmd.Flags.StringVar(clix.StringVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:  "filter",
+			Usage: "Only list subcommands whose name or description contains this text",
+		},
+		Value: &filter,
+	})
+
 	cmd.Run = func(ctx *clix.Context) error {
 		target := app.Root
 		if command != "" {
@@ -82,11 +94,15 @@ func NewHelpCommand(app *clix.App) *clix.Command {
 			if resolved := app.Root.ResolvePath(parts); resolved != nil {
 				target = resolved
 			} else {
-				return fmt.Errorf("unknown command: %s", command)
+				return fmt.Errorf("%w: %s", clix.ErrUnknownCommand, command)
 			}
 		}
-		helper := clix.HelpRenderer{App: app, Command: target}
-		return helper.Render(app.Out)
+		if filter != "" {
+			// Filtering is a feature of the built-in renderer, so it is
+			// used even when the app sets a HelpFunc.
+			return clix.HelpRenderer{App: app, Command: target, Filter: filter}.Render(app.Out)
+		}
+		return app.RenderHelp(ctx, target, app.Out)
 	}
 	return cmd
 }
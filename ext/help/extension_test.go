@@ -145,3 +145,35 @@ func TestHelpExtension(t *testing.T) {
 		}
 	})
 }
+
+func TestHelpCommandFilter(t *testing.T) {
+	app := clix.NewApp("test")
+	for name, short := range map[string]string{
+		"deploy":  "Deploy the service",
+		"destroy": "Tear down the service",
+		"login":   "Authenticate",
+	} {
+		child := clix.NewCommand(name)
+		child.Short = short
+		child.Run = func(*clix.Context) error { return nil }
+		app.Root.AddCommand(child)
+	}
+
+	var output bytes.Buffer
+	app.Out = &output
+	app.AddExtension(Extension{})
+
+	if err := app.Run(context.Background(), []string{"help", "--filter", "service"}); err != nil {
+		t.Fatalf("help --filter failed: %v", err)
+	}
+
+	help := output.String()
+	for _, want := range []string{"deploy", "destroy"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in filtered help, got:\n%s", want, help)
+		}
+	}
+	if strings.Contains(help, "login") {
+		t.Errorf("expected login to be filtered out, got:\n%s", help)
+	}
+}
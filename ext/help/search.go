@@ -0,0 +1,158 @@
+package help
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// snippetWidth is the number of runes of help text shown around a match.
+const snippetWidth = 60
+
+// SearchResult is a command whose help matched a search query.
+type SearchResult struct {
+	// Command is the matching command.
+	Command *clix.Command
+
+	// Path is the full command path, such as "gcloud compute instances".
+	Path string
+
+	// Snippet is the help text shown for the match: the short description,
+	// or an excerpt of the long help around the query when only it matched.
+	Snippet string
+
+	rank int // 0 for a name or alias match, 1 for short, 2 for long
+}
+
+// Search walks the command tree below root and returns the visible commands
+// whose name, aliases, short or long help contain query, ignoring case.
+// Name matches come first, then short and long help matches, each in
+// command path order.
+func Search(root *clix.Command, query string) []SearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if root == nil || query == "" {
+		return nil
+	}
+
+	var results []SearchResult
+	var walk func(cmd *clix.Command)
+	walk = func(cmd *clix.Command) {
+		for _, child := range cmd.VisibleChildren() {
+			if result, ok := match(child, query); ok {
+				results = append(results, result)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].rank != results[j].rank {
+			return results[i].rank < results[j].rank
+		}
+		return results[i].Path < results[j].Path
+	})
+	return results
+}
+
+func match(cmd *clix.Command, query string) (SearchResult, bool) {
+	result := SearchResult{Command: cmd, Path: cmd.Path(), Snippet: cmd.Short}
+	for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+		if strings.Contains(strings.ToLower(name), query) {
+			return result, true
+		}
+	}
+	if strings.Contains(strings.ToLower(cmd.Short), query) {
+		result.rank = 1
+		return result, true
+	}
+	if snippet, ok := excerpt(cmd.Long, query); ok {
+		result.rank = 2
+		result.Snippet = snippet
+		return result, true
+	}
+	return SearchResult{}, false
+}
+
+// excerpt returns about snippetWidth runes of text centred on the first
+// occurrence of query, on one line. Text is lowered rune by rune, since some
+// runes change length in bytes when lowercased (Ⱥ becomes ⱥ), so a match is
+// located by its rune offset rather than its byte offset.
+func excerpt(text, query string) (string, bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.Map(unicode.ToLower, text)
+	i := strings.Index(lower, query)
+	if i < 0 {
+		return "", false
+	}
+
+	runes := []rune(text)
+	at := utf8.RuneCountInString(lower[:i])
+	start := at - (snippetWidth-utf8.RuneCountInString(query))/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWidth
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := string(runes[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return snippet, true
+}
+
+// NewSearchCommand constructs the help search command, which lists the
+// commands whose help contains a query.
+func NewSearchCommand(app *clix.App) *clix.Command {
+	cmd := clix.NewCommand("search")
+	cmd.Short = "Search help text across all commands"
+	cmd.Usage = fmt.Sprintf("%s help search <query...>", app.Name)
+	cmd.IsExtensionCommand = true
+
+	var query []string
+	cmd.Flags.StringSliceVar(clix.StringSliceVarOptions{
+		FlagOptions: clix.FlagOptions{
+			Name:       "query",
+			Usage:      "Text to search for",
+			Positional: true,
+			Variadic:   true,
+			Required:   true,
+		},
+		Value: &query,
+	})
+
+	cmd.Run = func(ctx *clix.Context) error {
+		text := strings.Join(query, " ")
+		results := Search(app.Root, text)
+		if len(results) == 0 {
+			fmt.Fprintf(app.Out, "No commands match %q.\n", text)
+			return nil
+		}
+
+		width := 0
+		for _, result := range results {
+			if n := utf8.RuneCountInString(result.Path); n > width {
+				width = n
+			}
+		}
+		for _, result := range results {
+			line := result.Path
+			if result.Snippet != "" {
+				line += strings.Repeat(" ", width-utf8.RuneCountInString(result.Path)+2) + result.Snippet
+			}
+			fmt.Fprintln(app.Out, line)
+		}
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,140 @@
+package help
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func searchApp() *clix.App {
+	app := clix.NewApp("cloud")
+
+	leaf := func(name, short, long string) *clix.Command {
+		cmd := clix.NewCommand(name)
+		cmd.Short = short
+		cmd.Long = long
+		cmd.Run = func(*clix.Context) error { return nil }
+		return cmd
+	}
+
+	instances := clix.NewGroup("instances", "Manage virtual machine instances",
+		leaf("create", "Create an instance", "Create a virtual machine from an image, optionally attaching a network."),
+		leaf("delete", "Delete an instance", ""),
+	)
+	compute := clix.NewGroup("compute", "Compute Engine resources", instances)
+	networks := leaf("networks", "Manage VPC networks", "")
+	app.Root.AddCommand(compute)
+	app.Root.AddCommand(networks)
+	app.Root.AddCommand(leaf("dns", "Manage DNS zones", "Records that point at a network load balancer."))
+	return app
+}
+
+func TestSearchMatchesNestedCommands(t *testing.T) {
+	app := searchApp()
+
+	results := Search(app.Root, "INSTANCE")
+	var paths []string
+	for _, result := range results {
+		paths = append(paths, result.Path)
+	}
+	want := []string{
+		"cloud compute instances",
+		"cloud compute instances create",
+		"cloud compute instances delete",
+	}
+	if strings.Join(paths, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, paths)
+	}
+}
+
+func TestSearchRanksNameMatchesFirst(t *testing.T) {
+	app := searchApp()
+
+	results := Search(app.Root, "network")
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d: %+v", len(results), results)
+	}
+	if results[0].Path != "cloud networks" {
+		t.Errorf("expected name match first, got %q", results[0].Path)
+	}
+	if results[0].Snippet != "Manage VPC networks" {
+		t.Errorf("expected short help as snippet, got %q", results[0].Snippet)
+	}
+
+	// The remaining matches are in long help only, in path order.
+	if results[1].Path != "cloud compute instances create" || results[2].Path != "cloud dns" {
+		t.Errorf("unexpected long-help order: %q, %q", results[1].Path, results[2].Path)
+	}
+	if !strings.Contains(results[2].Snippet, "network load balancer") {
+		t.Errorf("expected snippet around the match, got %q", results[2].Snippet)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("lorem ipsum ", 10) + "needle" + strings.Repeat(" dolor sit", 10)
+	snippet, ok := excerpt(long, "needle")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if !strings.HasPrefix(snippet, "...") || !strings.HasSuffix(snippet, "...") {
+		t.Errorf("expected ellipses on both sides, got %q", snippet)
+	}
+	if !strings.Contains(snippet, "needle") {
+		t.Errorf("expected snippet to contain the query, got %q", snippet)
+	}
+
+	if snippet, _ := excerpt("Short\n  text", "short"); snippet != "Short text" {
+		t.Errorf("expected whitespace to be collapsed, got %q", snippet)
+	}
+}
+
+func TestExcerptNonASCII(t *testing.T) {
+	// Ⱥ takes two bytes but lowercases to ⱥ, which takes three.
+	long := strings.Repeat("Ⱥ", 40) + " Größe needle"
+	snippet, ok := excerpt(long, "größe")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if !strings.HasSuffix(snippet, "Größe needle") || !utf8.ValidString(snippet) {
+		t.Errorf("expected the snippet to end at the match, got %q", snippet)
+	}
+
+	if snippet, ok := excerpt("ȺȺȺ needle", "needle"); !ok || snippet != "ȺȺȺ needle" {
+		t.Errorf("excerpt = %q, %v", snippet, ok)
+	}
+}
+
+func TestHelpSearchCommand(t *testing.T) {
+	app := searchApp()
+	var output bytes.Buffer
+	app.Out = &output
+	app.AddExtension(Extension{})
+
+	if err := app.Run(context.Background(), []string{"help", "search", "delete", "an"}); err != nil {
+		t.Fatalf("help search failed: %v", err)
+	}
+	got := output.String()
+	if !strings.Contains(got, "cloud compute instances delete  Delete an instance") {
+		t.Errorf("expected path and snippet, got:\n%s", got)
+	}
+
+	output.Reset()
+	if err := app.Run(context.Background(), []string{"help", "search", "kubernetes"}); err != nil {
+		t.Fatalf("help search failed: %v", err)
+	}
+	if !strings.Contains(output.String(), `No commands match "kubernetes".`) {
+		t.Errorf("expected no-match notice, got:\n%s", output.String())
+	}
+
+	output.Reset()
+	if err := app.Run(context.Background(), []string{"help", "--command", "dns"}); err != nil {
+		t.Fatalf("help --command failed: %v", err)
+	}
+	if !strings.Contains(output.String(), "Manage DNS zones") {
+		t.Errorf("expected help for dns, got:\n%s", output.String())
+	}
+}
@@ -0,0 +1,515 @@
+package bubble
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SCKelemen/clix/v2"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPageSize is the number of options shown at once when the request
+// does not set PageSize.
+const defaultPageSize = 10
+
+// stringModel is a model whose answer is a single string.
+type stringModel interface {
+	tea.Model
+	result() (string, error)
+}
+
+// defaultAccepter is implemented by every model; it returns the model as if
+// the user had accepted the prompt's Default, e.g. when its Timeout elapses.
+type defaultAccepter interface {
+	acceptDefault(value string) tea.Model
+}
+
+func timedOut(m tea.Model, value string) tea.Model {
+	return m.(defaultAccepter).acceptDefault(value)
+}
+
+func render(style clix.TextStyle, value string) string {
+	if style == nil || value == "" {
+		return value
+	}
+	return style.Render(value)
+}
+
+func placeholderStyle(theme clix.PromptTheme) clix.TextStyle {
+	if theme.PlaceholderStyle != nil {
+		return theme.PlaceholderStyle
+	}
+	return theme.DefaultStyle
+}
+
+// header renders the prefix, label and hint line shared by all prompts.
+func header(cfg *clix.PromptConfig) string {
+	var b strings.Builder
+	b.WriteString(render(cfg.Theme.PrefixStyle, cfg.Theme.Prefix))
+	b.WriteString(render(cfg.Theme.LabelStyle, cfg.Label))
+	if cfg.Theme.Hint != "" {
+		b.WriteString(" ")
+		b.WriteString(render(cfg.Theme.HintStyle, cfg.Theme.Hint))
+	}
+	return b.String()
+}
+
+// answered renders the line left on screen once a prompt is answered.
+func answered(cfg *clix.PromptConfig, value string) string {
+	return header(cfg) + " " + render(cfg.Theme.DefaultStyle, value) + "\n"
+}
+
+// errorLine renders a validation error below the input.
+func errorLine(cfg *clix.PromptConfig, err error) string {
+	if err == nil {
+		return ""
+	}
+	return "\n" + render(cfg.Theme.ErrorStyle, cfg.Theme.Error+err.Error())
+}
+
+// textModel is a single-line text prompt.
+type textModel struct {
+	cfg       *clix.PromptConfig
+	input     textinput.Model
+	value     string
+	err       error
+	done      bool
+	cancelled bool
+}
+
+func newTextModel(cfg *clix.PromptConfig) textModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Focus()
+	if cfg.Default != "" {
+		ti.Placeholder = cfg.Default
+	} else {
+		ti.Placeholder = cfg.NoDefaultPlaceholder
+	}
+	return textModel{cfg: cfg, input: ti}
+}
+
+func (m textModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m textModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.cancelled = true
+			return m, tea.Quit
+		case tea.KeyTab:
+			if m.input.Value() == "" && m.cfg.Default != "" {
+				m.input.SetValue(m.cfg.Default)
+				m.input.CursorEnd()
+			}
+			return m, nil
+		case tea.KeyEnter:
+			value := m.input.Value()
+			if value == "" {
+				value = m.cfg.Default
+			}
+			if m.cfg.Validate != nil {
+				if err := m.cfg.Validate(value); err != nil {
+					m.err = err
+					return m, nil
+				}
+			}
+			m.value = value
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	m.err = nil
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m textModel) View() string {
+	if m.done {
+		return answered(m.cfg, m.value)
+	}
+	if m.cancelled {
+		return header(m.cfg) + "\n"
+	}
+	return header(m.cfg) + "\n" + m.input.View() + errorLine(m.cfg, m.err) + "\n"
+}
+
+func (m textModel) result() (string, error) {
+	if m.cancelled {
+		return "", errCancelled
+	}
+	return m.value, nil
+}
+
+func (m textModel) acceptDefault(value string) tea.Model {
+	m.value = value
+	m.done = true
+	return m
+}
+
+// confirmModel is a yes/no prompt, with an optional abort answer.
+type confirmModel struct {
+	cfg       *clix.PromptConfig
+	value     string
+	done      bool
+	cancelled bool
+	aborted   bool
+}
+
+func newConfirmModel(cfg *clix.PromptConfig) confirmModel {
+	return confirmModel{cfg: cfg}
+}
+
+func (m confirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeyEnter:
+		if answer := normalizeConfirm(m.cfg.Default); answer != "" {
+			m.value = answer
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	switch strings.ToLower(key.String()) {
+	case "y":
+		m.value, m.done = "y", true
+	case "n":
+		m.value, m.done = "n", true
+	case "a":
+		if m.cfg.Abort {
+			m.aborted, m.done = true, true
+		}
+	}
+	if m.done {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func normalizeConfirm(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes":
+		return "y"
+	case "n", "no":
+		return "n"
+	}
+	return ""
+}
+
+func (m confirmModel) choices() string {
+	yes, no := "y", "n"
+	switch normalizeConfirm(m.cfg.Default) {
+	case "y":
+		yes = "Y"
+	case "n":
+		no = "N"
+	}
+	choices := yes + "/" + no
+	if m.cfg.Abort {
+		choices += "/a"
+	}
+	return render(placeholderStyle(m.cfg.Theme), "("+choices+")")
+}
+
+func (m confirmModel) View() string {
+	switch {
+	case m.aborted:
+		return answered(m.cfg, "abort")
+	case m.done && m.value == "y":
+		return answered(m.cfg, "yes")
+	case m.done:
+		return answered(m.cfg, "no")
+	case m.cancelled:
+		return header(m.cfg) + "\n"
+	}
+	return header(m.cfg) + " " + m.choices() + " "
+}
+
+func (m confirmModel) result() (string, error) {
+	switch {
+	case m.cancelled:
+		return "", errCancelled
+	case m.aborted:
+		return "", clix.ErrAborted
+	}
+	return m.value, nil
+}
+
+func (m confirmModel) acceptDefault(value string) tea.Model {
+	m.value = normalizeConfirm(value)
+	m.done = true
+	return m
+}
+
+// list holds the cursor and scrolling state shared by select prompts.
+type list struct {
+	cfg    *clix.PromptConfig
+	cursor int
+	offset int
+}
+
+func (l *list) pageSize() int {
+	if l.cfg.PageSize > 0 && l.cfg.PageSize < len(l.cfg.Options) {
+		return l.cfg.PageSize
+	}
+	if len(l.cfg.Options) > defaultPageSize {
+		return defaultPageSize
+	}
+	return len(l.cfg.Options)
+}
+
+// move handles navigation keys and reports whether key was one.
+func (l *list) move(key tea.KeyMsg) bool {
+	n := len(l.cfg.Options)
+	switch key.String() {
+	case "up", "k", "ctrl+p":
+		l.cursor = (l.cursor - 1 + n) % n
+	case "down", "j", "ctrl+n":
+		l.cursor = (l.cursor + 1) % n
+	case "home":
+		l.cursor = 0
+	case "end":
+		l.cursor = n - 1
+	default:
+		return false
+	}
+	page := l.pageSize()
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	} else if l.cursor >= l.offset+page {
+		l.offset = l.cursor - page + 1
+	}
+	return true
+}
+
+// view renders the visible options, calling mark for the box before each.
+func (l *list) view(mark func(i int) string) string {
+	var b strings.Builder
+	theme := l.cfg.Theme
+	end := l.offset + l.pageSize()
+	for i := l.offset; i < end; i++ {
+		opt := l.cfg.Options[i]
+		cursor := "  "
+		label := opt.Label
+		if i == l.cursor {
+			cursor = render(theme.PrefixStyle, "> ")
+			label = render(theme.LabelStyle, opt.Label)
+		}
+		b.WriteString(cursor + mark(i) + label)
+		if opt.Description != "" {
+			b.WriteString(" " + render(theme.HintStyle, opt.Description))
+		}
+		b.WriteString("\n")
+	}
+	if len(l.cfg.Options) > l.pageSize() {
+		b.WriteString(render(placeholderStyle(theme), fmt.Sprintf("(%d/%d)", l.cursor+1, len(l.cfg.Options))) + "\n")
+	}
+	return b.String()
+}
+
+// defaultIndex returns the index of the option matching value by Value or
+// Label, or -1.
+func defaultIndex(cfg *clix.PromptConfig, value string) int {
+	for i, opt := range cfg.Options {
+		if opt.Value == value || opt.Label == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectModel is a single-choice list.
+type selectModel struct {
+	list
+	chosen    clix.SelectOption
+	err       error
+	done      bool
+	cancelled bool
+}
+
+func newSelectModel(cfg *clix.PromptConfig) selectModel {
+	m := selectModel{list: list{cfg: cfg}}
+	if i := defaultIndex(cfg, cfg.Default); i >= 0 {
+		m.cursor = i
+		m.offset = max(0, i-m.pageSize()+1)
+	}
+	return m
+}
+
+func (m selectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeyEnter:
+		opt := m.cfg.Options[m.cursor]
+		if m.cfg.Validate != nil {
+			if err := m.cfg.Validate(opt.Value); err != nil {
+				m.err = err
+				return m, nil
+			}
+		}
+		m.chosen = opt
+		m.done = true
+		return m, tea.Quit
+	}
+	if m.move(key) {
+		m.err = nil
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	if m.done {
+		return answered(m.cfg, m.chosen.Label)
+	}
+	if m.cancelled {
+		return header(m.cfg) + "\n"
+	}
+	return header(m.cfg) + "\n" + m.list.view(func(int) string { return "" }) + strings.TrimPrefix(errorLine(m.cfg, m.err), "\n")
+}
+
+func (m selectModel) acceptDefault(value string) tea.Model {
+	if i := defaultIndex(m.cfg, value); i >= 0 {
+		m.chosen = m.cfg.Options[i]
+	} else {
+		m.chosen = clix.SelectOption{Label: value, Value: value}
+	}
+	m.done = true
+	return m
+}
+
+// multiModel is a multiple-choice list toggled with space.
+type multiModel struct {
+	list
+	selected  map[int]bool
+	err       error
+	done      bool
+	cancelled bool
+}
+
+func newMultiModel(cfg *clix.PromptConfig) multiModel {
+	m := multiModel{list: list{cfg: cfg}, selected: make(map[int]bool)}
+	m.selectDefaults(cfg.Default)
+	return m
+}
+
+// selectDefaults preselects the options named in a comma-separated Default.
+func (m *multiModel) selectDefaults(value string) {
+	if value == "" {
+		return
+	}
+	for _, name := range strings.Split(value, ",") {
+		if i := defaultIndex(m.cfg, strings.TrimSpace(name)); i >= 0 {
+			m.selected[i] = true
+		}
+	}
+}
+
+func (m multiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m multiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeySpace:
+		m.selected = toggle(m.selected, m.cursor)
+		m.err = nil
+		return m, nil
+	case tea.KeyEnter:
+		if m.cfg.Validate != nil {
+			if err := m.cfg.Validate(strings.Join(m.values(), ",")); err != nil {
+				m.err = err
+				return m, nil
+			}
+		}
+		m.done = true
+		return m, tea.Quit
+	}
+	if m.move(key) {
+		m.err = nil
+	}
+	return m, nil
+}
+
+// toggle returns a copy of selected with i flipped, so earlier model values
+// are not changed.
+func toggle(selected map[int]bool, i int) map[int]bool {
+	next := make(map[int]bool, len(selected)+1)
+	for k, v := range selected {
+		next[k] = v
+	}
+	next[i] = !next[i]
+	return next
+}
+
+// values returns the selected option values in option order.
+func (m multiModel) values() []string {
+	var values []string
+	for i, opt := range m.cfg.Options {
+		if m.selected[i] {
+			values = append(values, opt.Value)
+		}
+	}
+	return values
+}
+
+func (m multiModel) View() string {
+	if m.done {
+		var labels []string
+		for i, opt := range m.cfg.Options {
+			if m.selected[i] {
+				labels = append(labels, opt.Label)
+			}
+		}
+		return answered(m.cfg, strings.Join(labels, ", "))
+	}
+	if m.cancelled {
+		return header(m.cfg) + "\n"
+	}
+	mark := func(i int) string {
+		if m.selected[i] {
+			return render(m.cfg.Theme.DefaultStyle, "[x]") + " "
+		}
+		return "[ ] "
+	}
+	hint := render(placeholderStyle(m.cfg.Theme), "(space to toggle, enter to confirm)")
+	return header(m.cfg) + " " + hint + "\n" + m.list.view(mark) + strings.TrimPrefix(errorLine(m.cfg, m.err), "\n")
+}
+
+func (m multiModel) acceptDefault(value string) tea.Model {
+	m.selected = make(map[int]bool)
+	m.selectDefaults(value)
+	m.done = true
+	return m
+}
@@ -0,0 +1,181 @@
+package bubble
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// testTheme builds a theme from lipgloss styles rendered with a fixed ANSI
+// profile, so the escape sequences do not depend on the test's terminal.
+func testTheme() (clix.PromptTheme, map[string]string) {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+	prefix := r.NewStyle().Foreground(lipgloss.Color("4"))
+	label := r.NewStyle().Bold(true)
+	hint := r.NewStyle().Foreground(lipgloss.Color("6"))
+	errs := r.NewStyle().Foreground(lipgloss.Color("1"))
+	theme := clix.PromptTheme{
+		Prefix:      "? ",
+		Error:       "! ",
+		PrefixStyle: prefix,
+		LabelStyle:  label,
+		HintStyle:   hint,
+		ErrorStyle:  errs,
+	}
+	return theme, map[string]string{
+		"prefix": prefix.Render("? "),
+		"label":  label.Render("Color"),
+		"hint":   hint.Render("the primary one"),
+		"cursor": prefix.Render("> "),
+		"active": label.Render("Green"),
+	}
+}
+
+func keys(m tea.Model, msgs ...tea.KeyMsg) tea.Model {
+	for _, msg := range msgs {
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+var (
+	enter = tea.KeyMsg{Type: tea.KeyEnter}
+	down  = tea.KeyMsg{Type: tea.KeyDown}
+	space = tea.KeyMsg{Type: tea.KeySpace}
+)
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestSelectModelAppliesTheme(t *testing.T) {
+	theme, want := testTheme()
+	cfg := &clix.PromptConfig{
+		Label: "Color",
+		Theme: theme,
+		Options: []clix.SelectOption{
+			{Label: "Red", Value: "red"},
+			{Label: "Green", Value: "green", Description: "the primary one"},
+		},
+	}
+	m := keys(newSelectModel(cfg), down)
+	view := m.View()
+	for name, segment := range want {
+		if !strings.Contains(view, segment) {
+			t.Errorf("view is missing the styled %s %q:\n%q", name, segment, view)
+		}
+	}
+	if !strings.Contains(view, "\x1b[") {
+		t.Errorf("view has no ANSI styling:\n%q", view)
+	}
+
+	m = keys(m, enter).(selectModel)
+	if got := m.(selectModel).chosen.Value; got != "green" {
+		t.Errorf("chosen = %q, want green", got)
+	}
+}
+
+func TestTextModelValidationErrorIsStyled(t *testing.T) {
+	theme, _ := testTheme()
+	cfg := &clix.PromptConfig{
+		Label: "Name",
+		Theme: theme,
+		Validate: func(s string) error {
+			if len(s) < 2 {
+				return errors.New("too short")
+			}
+			return nil
+		},
+	}
+	m := keys(newTextModel(cfg), runes("A"), enter)
+	if want := theme.ErrorStyle.Render("! too short"); !strings.Contains(m.View(), want) {
+		t.Fatalf("view is missing the styled error %q:\n%q", want, m.View())
+	}
+	m = keys(m, runes("da"), enter)
+	if got, err := m.(textModel).result(); err != nil || got != "Ada" {
+		t.Fatalf("result = %q, %v; want Ada", got, err)
+	}
+}
+
+func TestTextModelDefault(t *testing.T) {
+	cfg := &clix.PromptConfig{Label: "Name", Default: "World"}
+	m := keys(newTextModel(cfg), enter)
+	if got, _ := m.(textModel).result(); got != "World" {
+		t.Errorf("result = %q, want World", got)
+	}
+	m = keys(newTextModel(cfg), tea.KeyMsg{Type: tea.KeyCtrlC})
+	if _, err := m.(textModel).result(); err == nil {
+		t.Error("Ctrl+C should cancel the prompt")
+	}
+}
+
+func TestConfirmModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     clix.PromptConfig
+		key     tea.KeyMsg
+		want    string
+		wantErr error
+	}{
+		{name: "yes", key: runes("y"), want: "y"},
+		{name: "upper no", key: runes("N"), want: "n"},
+		{name: "enter uses default", cfg: clix.PromptConfig{Default: "yes"}, key: enter, want: "y"},
+		{name: "abort", cfg: clix.PromptConfig{Abort: true}, key: runes("a"), wantErr: clix.ErrAborted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Label = "Continue?"
+			m := keys(newConfirmModel(&cfg), tt.key).(confirmModel)
+			got, err := m.result()
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("result = %q, %v; want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+
+	// Enter without a default waits for an answer.
+	m := keys(newConfirmModel(&clix.PromptConfig{Label: "Continue?"}), enter).(confirmModel)
+	if m.done {
+		t.Error("Enter without a default answered the prompt")
+	}
+}
+
+func TestMultiModel(t *testing.T) {
+	cfg := &clix.PromptConfig{
+		Label:   "Colors",
+		Default: "blue",
+		Options: []clix.SelectOption{
+			{Label: "Red", Value: "red"},
+			{Label: "Green", Value: "green"},
+			{Label: "Blue", Value: "blue"},
+		},
+	}
+	m := keys(newMultiModel(cfg), space, down, down, space, enter).(multiModel)
+	if got := m.values(); !reflect.DeepEqual(got, []string{"red"}) {
+		t.Errorf("values = %q, want red (blue was preselected, then toggled off)", got)
+	}
+	if !strings.Contains(m.View(), "Red") {
+		t.Errorf("answered view = %q", m.View())
+	}
+}
+
+func TestListScrollsWithPageSize(t *testing.T) {
+	cfg := &clix.PromptConfig{Label: "Pick", PageSize: 2}
+	for _, v := range []string{"a", "b", "c", "d"} {
+		cfg.Options = append(cfg.Options, clix.SelectOption{Label: v, Value: v})
+	}
+	m := keys(newSelectModel(cfg), down, down).(selectModel)
+	view := m.View()
+	if strings.Contains(view, " a\n") || !strings.Contains(view, "c") || !strings.Contains(view, "(3/4)") {
+		t.Errorf("view does not scroll to the cursor:\n%s", view)
+	}
+}
@@ -0,0 +1,211 @@
+// Package bubble provides a clix.Prompter built on Bubble Tea.
+//
+// The prompter renders text, select, multi-select and confirm prompts as small
+// Bubble Tea programs, styled with the request's clix.PromptTheme, so themes
+// built from lipgloss.Style values apply unchanged. When input or output is
+// not a terminal it falls back to prompt.TerminalPrompter's line-based
+// prompts, so the same code works in pipes and CI.
+//
+// The package lives in its own module so that apps which do not use it do
+// not pull in Bubble Tea:
+//
+//	app.Prompter = bubble.Prompter{In: os.Stdin, Out: os.Stdout}
+package bubble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/SCKelemen/clix/v2"
+	"github.com/SCKelemen/clix/v2/ext/prompt"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// isTTY reports whether fd is a terminal. Tests replace it.
+var isTTY = term.IsTerminal
+
+// errCancelled matches the error prompt.TerminalPrompter returns for Ctrl+C.
+var errCancelled = errors.New("cancelled")
+
+// Prompter implements clix.Prompter with Bubble Tea programs.
+//
+// Multi-line prompts, prompts with Suggest completion and prompts with a
+// CommandHandler or KeyMap bindings (such as survey's undo keys) are handled
+// by prompt.TerminalPrompter, which supports them.
+type Prompter struct {
+	In  io.Reader
+	Out io.Writer
+}
+
+var (
+	_ clix.Prompter      = Prompter{}
+	_ clix.Selector      = Prompter{}
+	_ clix.MultiSelector = Prompter{}
+	_ clix.InputSource   = Prompter{}
+)
+
+// Input returns In, so that clix.InteractiveAuto can tell whether it is a
+// terminal.
+func (p Prompter) Input() io.Reader {
+	return p.In
+}
+
+// Prompt displays a prompt and returns the user's answer. Multi-select
+// answers are joined with commas; use PromptMulti to get them as a slice.
+func (p Prompter) Prompt(ctx context.Context, opts ...clix.PromptOption) (string, error) {
+	if p.In == nil || p.Out == nil {
+		return "", errors.New("prompter missing IO")
+	}
+	cfg := newConfig(opts)
+	if p.delegate(cfg) {
+		return p.fallback().Prompt(ctx, opts...)
+	}
+
+	switch {
+	case cfg.Confirm:
+		return p.runString(ctx, cfg, newConfirmModel(cfg))
+	case len(cfg.Options) > 0 && cfg.MultiSelect:
+		values, err := p.promptMulti(ctx, cfg)
+		return strings.Join(values, ","), err
+	case len(cfg.Options) > 0:
+		opt, err := p.selectOne(ctx, cfg)
+		return opt.Value, err
+	default:
+		return p.runString(ctx, cfg, newTextModel(cfg))
+	}
+}
+
+// SelectOne implements clix.Selector. It runs a select prompt and returns
+// the chosen option, including its Label and Description.
+func (p Prompter) SelectOne(ctx context.Context, opts ...clix.PromptOption) (clix.SelectOption, error) {
+	if p.In == nil || p.Out == nil {
+		return clix.SelectOption{}, errors.New("prompter missing IO")
+	}
+	cfg := newConfig(opts)
+	if len(cfg.Options) == 0 {
+		return clix.SelectOption{}, errors.New("select prompt requires options")
+	}
+	if p.delegate(cfg) {
+		return p.fallback().SelectOne(ctx, opts...)
+	}
+	return p.selectOne(ctx, cfg)
+}
+
+// PromptMulti implements clix.MultiSelector. It runs a multi-select prompt
+// and returns the selected values in option order.
+func (p Prompter) PromptMulti(ctx context.Context, opts ...clix.PromptOption) ([]string, error) {
+	if p.In == nil || p.Out == nil {
+		return nil, errors.New("prompter missing IO")
+	}
+	cfg := newConfig(opts)
+	if len(cfg.Options) == 0 {
+		return nil, errors.New("multi-select prompt requires options")
+	}
+	if p.delegate(cfg) {
+		return p.fallback().PromptMulti(ctx, opts...)
+	}
+	return p.promptMulti(ctx, cfg)
+}
+
+func newConfig(opts []clix.PromptOption) *clix.PromptConfig {
+	cfg := &clix.PromptConfig{Theme: clix.DefaultPromptTheme}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	return cfg
+}
+
+// delegate reports whether cfg should be handled by prompt.TerminalPrompter:
+// when there is no terminal to draw on, or the prompt needs features the
+// Bubble Tea models do not implement.
+func (p Prompter) delegate(cfg *clix.PromptConfig) bool {
+	if !p.interactive() {
+		return true
+	}
+	return cfg.Multiline || cfg.Suggest != nil || cfg.CommandHandler != nil || len(cfg.KeyMap.Bindings) > 0
+}
+
+// interactive reports whether both In and Out are terminals.
+func (p Prompter) interactive() bool {
+	in, ok := p.In.(*os.File)
+	if !ok || !isTTY(int(in.Fd())) {
+		return false
+	}
+	out, ok := p.Out.(*os.File)
+	return ok && isTTY(int(out.Fd()))
+}
+
+func (p Prompter) fallback() prompt.TerminalPrompter {
+	return prompt.TerminalPrompter{In: p.In, Out: p.Out}
+}
+
+func (p Prompter) selectOne(ctx context.Context, cfg *clix.PromptConfig) (clix.SelectOption, error) {
+	final, err := p.run(ctx, cfg, newSelectModel(cfg))
+	if err != nil {
+		return clix.SelectOption{}, err
+	}
+	m := final.(selectModel)
+	if m.cancelled {
+		return clix.SelectOption{}, errCancelled
+	}
+	return m.chosen, nil
+}
+
+func (p Prompter) promptMulti(ctx context.Context, cfg *clix.PromptConfig) ([]string, error) {
+	final, err := p.run(ctx, cfg, newMultiModel(cfg))
+	if err != nil {
+		return nil, err
+	}
+	m := final.(multiModel)
+	if m.cancelled {
+		return nil, errCancelled
+	}
+	return m.values(), nil
+}
+
+// runString runs a text or confirm model and returns its answer.
+func (p Prompter) runString(ctx context.Context, cfg *clix.PromptConfig, m stringModel) (string, error) {
+	final, err := p.run(ctx, cfg, m)
+	if err != nil {
+		return "", err
+	}
+	return final.(stringModel).result()
+}
+
+// run executes m as a Bubble Tea program on In and Out. A prompt Timeout
+// falls back to the Default, or fails with clix.ErrPromptTimeout without one.
+// A text prompt's Default must also pass Validate.
+func (p Prompter) run(ctx context.Context, cfg *clix.PromptConfig, m tea.Model) (tea.Model, error) {
+	runCtx := ctx
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	program := tea.NewProgram(m, tea.WithInput(p.In), tea.WithOutput(p.Out), tea.WithContext(runCtx))
+	final, err := program.Run()
+	if err == nil {
+		return final, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		if cfg.Default == "" {
+			return nil, clix.ErrPromptTimeout
+		}
+		if _, text := m.(textModel); text && cfg.Validate != nil {
+			if err := cfg.Validate(cfg.Default); err != nil {
+				return nil, fmt.Errorf("%w: default %q: %w", clix.ErrPromptTimeout, cfg.Default, err)
+			}
+		}
+		return timedOut(m, cfg.Default), nil
+	}
+	return nil, err
+}
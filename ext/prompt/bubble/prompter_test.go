@@ -0,0 +1,159 @@
+package bubble
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func TestPrompterFallsBackWithoutTerminal(t *testing.T) {
+	ctx := context.Background()
+	options := []clix.SelectOption{
+		{Label: "Red", Value: "red"},
+		{Label: "Green", Value: "green"},
+		{Label: "Blue", Value: "blue"},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		p := Prompter{In: strings.NewReader("Ada\n"), Out: out}
+		got, err := p.Prompt(ctx, clix.PromptRequest{Label: "Name"})
+		if err != nil || got != "Ada" {
+			t.Fatalf("Prompt = %q, %v; want Ada", got, err)
+		}
+		if !strings.Contains(out.String(), "Name") {
+			t.Errorf("label not written: %q", out.String())
+		}
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		p := Prompter{In: strings.NewReader("y\n"), Out: &bytes.Buffer{}}
+		got, err := p.Prompt(ctx, clix.PromptRequest{Label: "Continue?", Confirm: true})
+		if err != nil || got != "y" {
+			t.Fatalf("Prompt = %q, %v; want y", got, err)
+		}
+	})
+
+	t.Run("select", func(t *testing.T) {
+		p := Prompter{In: strings.NewReader("2\n"), Out: &bytes.Buffer{}}
+		got, err := p.SelectOne(ctx, clix.PromptRequest{Label: "Color", Options: options})
+		if err != nil || got.Value != "green" {
+			t.Fatalf("SelectOne = %+v, %v; want green", got, err)
+		}
+	})
+
+	t.Run("multi-select", func(t *testing.T) {
+		p := Prompter{In: strings.NewReader("1,3\ndone\n"), Out: &bytes.Buffer{}}
+		got, err := p.PromptMulti(ctx, clix.PromptRequest{Label: "Colors", Options: options})
+		if err != nil || !reflect.DeepEqual(got, []string{"red", "blue"}) {
+			t.Fatalf("PromptMulti = %q, %v; want red, blue", got, err)
+		}
+	})
+}
+
+func TestPrompterMissingIO(t *testing.T) {
+	if _, err := (Prompter{}).Prompt(context.Background(), clix.WithLabel("Name")); err == nil {
+		t.Fatal("expected an error without In and Out")
+	}
+}
+
+func TestPrompterDelegatesUnsupportedPrompts(t *testing.T) {
+	restore := fakeTTY(t)
+	defer restore()
+
+	in, out := ttyFiles(t)
+	p := Prompter{In: in.r, Out: out}
+	if p.delegate(&clix.PromptConfig{}) {
+		t.Fatal("plain prompts on a terminal should use Bubble Tea")
+	}
+	for name, cfg := range map[string]*clix.PromptConfig{
+		"multiline": {Multiline: true},
+		"suggest":   {Suggest: func(string) []string { return nil }},
+		"keymap":    {KeyMap: clix.PromptKeyMap{Bindings: []clix.PromptKeyBinding{{Description: "Back"}}}},
+	} {
+		if !p.delegate(cfg) {
+			t.Errorf("%s prompts should use the terminal prompter", name)
+		}
+	}
+	if (Prompter{In: in.r, Out: &bytes.Buffer{}}).interactive() {
+		t.Error("a non-file output is not a terminal")
+	}
+}
+
+func TestPrompterRunsBubbleTeaProgram(t *testing.T) {
+	restore := fakeTTY(t)
+	defer restore()
+
+	in, out := ttyFiles(t)
+	go func() {
+		// Typed keys followed by Enter, as a terminal would send them.
+		_, _ = in.w.WriteString("Ada\r")
+	}()
+	p := Prompter{In: in.r, Out: out}
+	got, err := p.Prompt(context.Background(), clix.PromptRequest{Label: "Name"})
+	if err != nil || got != "Ada" {
+		t.Fatalf("Prompt = %q, %v; want Ada", got, err)
+	}
+}
+
+func TestPrompterTimeoutUsesDefault(t *testing.T) {
+	restore := fakeTTY(t)
+	defer restore()
+
+	in, out := ttyFiles(t)
+	p := Prompter{In: in.r, Out: out}
+	got, err := p.Prompt(context.Background(), clix.PromptRequest{Label: "Region", Default: "eu", Timeout: 20 * time.Millisecond})
+	if err != nil || got != "eu" {
+		t.Fatalf("Prompt = %q, %v; want the default eu", got, err)
+	}
+
+	_, err = p.Prompt(context.Background(), clix.PromptRequest{Label: "Region", Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, clix.ErrPromptTimeout) {
+		t.Fatalf("error = %v, want ErrPromptTimeout", err)
+	}
+
+	invalid := func(string) error { return errors.New("unknown region") }
+	_, err = p.Prompt(context.Background(), clix.PromptRequest{Label: "Region", Default: "mars", Validate: invalid, Timeout: 20 * time.Millisecond})
+	if !errors.Is(err, clix.ErrPromptTimeout) {
+		t.Fatalf("error = %v, want ErrPromptTimeout for a default Validate rejects", err)
+	}
+}
+
+// fakeTTY makes every file descriptor look like a terminal.
+func fakeTTY(t *testing.T) func() {
+	t.Helper()
+	orig := isTTY
+	isTTY = func(int) bool { return true }
+	return func() { isTTY = orig }
+}
+
+type pipe struct {
+	r, w *os.File
+}
+
+// ttyFiles returns a pipe standing in for the terminal's input and a
+// temporary file for its output.
+func ttyFiles(t *testing.T) (pipe, *os.File) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+		out.Close()
+	})
+	return pipe{r: r, w: w}, out
+}
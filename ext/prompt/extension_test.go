@@ -3,6 +3,7 @@ package prompt
 import (
 	"bytes"
 	"context"
+	"os"
 	"strings"
 	"testing"
 
@@ -210,3 +211,54 @@ func TestPromptExtensionIntegration(t *testing.T) {
 		}
 	})
 }
+
+func TestTerminalPrompterAsAppPrompter(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	app := clix.NewApp("test")
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	var prompter clix.Prompter = TerminalPrompter{In: bytes.NewBufferString("2\n"), Out: app.Out}
+	app.Prompter = prompter
+
+	var region string
+	cmd := clix.NewCommand("deploy")
+	cmd.Run = func(ctx *clix.Context) error {
+		value, err := ctx.App.Prompter.Prompt(ctx, clix.PromptRequest{
+			Label: "Region",
+			Options: []clix.SelectOption{
+				{Label: "US East", Value: "us-east"},
+				{Label: "EU West", Value: "eu-west"},
+			},
+		})
+		region = value
+		return err
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if region != "eu-west" {
+		t.Errorf("region = %q, want eu-west", region)
+	}
+}
+
+func TestTerminalPrompterNonTerminalInput(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	app := clix.NewApp("test")
+	app.Prompter = TerminalPrompter{In: devNull, Out: &bytes.Buffer{}}
+	if app.IsInteractive() {
+		t.Error("IsInteractive() = true for /dev/null input")
+	}
+
+	app.Prompter = TerminalPrompter{In: strings.NewReader("y\n"), Out: &bytes.Buffer{}}
+	if !app.IsInteractive() {
+		t.Error("IsInteractive() = false for an in-memory reader")
+	}
+}
@@ -0,0 +1,49 @@
+package prompt
+
+import "github.com/SCKelemen/clix/v2"
+
+// historyCursor walks a prompt history from the newest entry backwards. The
+// line being typed when the user first presses Up is kept as a draft and
+// comes back when they move past the newest entry again.
+type historyCursor struct {
+	entries []string
+	pos     int // index into entries; len(entries) means the draft
+	draft   string
+}
+
+func newHistoryCursor(history *clix.PromptHistory) *historyCursor {
+	entries := history.Entries()
+	return &historyCursor{entries: entries, pos: len(entries)}
+}
+
+// older moves to the previous entry. current is saved as the draft when
+// leaving it. It reports false when already at the oldest entry.
+func (c *historyCursor) older(current string) (string, bool) {
+	if c.pos == 0 {
+		return "", false
+	}
+	if c.pos == len(c.entries) {
+		c.draft = current
+	}
+	c.pos--
+	return c.entries[c.pos], true
+}
+
+// newer moves to the next entry, or back to the draft after the newest one.
+// It reports false when already at the draft.
+func (c *historyCursor) newer() (string, bool) {
+	if c.pos == len(c.entries) {
+		return "", false
+	}
+	c.pos++
+	if c.pos == len(c.entries) {
+		return c.draft, true
+	}
+	return c.entries[c.pos], true
+}
+
+// reset returns to the draft position, e.g. after a rejected submission.
+func (c *historyCursor) reset() {
+	c.pos = len(c.entries)
+	c.draft = ""
+}
@@ -0,0 +1,74 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// runTextKeys drives the interactive text loop with a raw key stream.
+func runTextKeys(t *testing.T, history *clix.PromptHistory, keys string) string {
+	t.Helper()
+	prompter := TerminalPrompter{In: bytes.NewBufferString(keys), Out: &bytes.Buffer{}}
+	cfg := &clix.PromptConfig{Label: "Query", Theme: clix.DefaultPromptTheme, History: history}
+	value, err := prompter.promptTextInteractive(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("prompt returned error: %v", err)
+	}
+	return value
+}
+
+func TestTextPromptHistory(t *testing.T) {
+	const up, down = "\x1b[A", "\x1b[B"
+
+	t.Run("up recalls the previous entry", func(t *testing.T) {
+		history := clix.NewPromptHistory(10)
+		runTextKeys(t, history, "first\r")
+		runTextKeys(t, history, "second\r")
+
+		if value := runTextKeys(t, history, up+"\r"); value != "second" {
+			t.Fatalf("expected newest entry 'second', got %q", value)
+		}
+		if value := runTextKeys(t, history, up+up+"\r"); value != "first" {
+			t.Fatalf("expected older entry 'first', got %q", value)
+		}
+	})
+
+	t.Run("down returns to the draft", func(t *testing.T) {
+		history := clix.NewPromptHistory(10)
+		runTextKeys(t, history, "first\r")
+
+		if value := runTextKeys(t, history, "dra"+up+down+"ft\r"); value != "draft" {
+			t.Fatalf("expected draft to be restored, got %q", value)
+		}
+	})
+
+	t.Run("recalled entry can be edited", func(t *testing.T) {
+		history := clix.NewPromptHistory(10)
+		runTextKeys(t, history, "select 1\r")
+
+		if value := runTextKeys(t, history, up+"\x7f2\r"); value != "select 2" {
+			t.Fatalf("expected edited entry 'select 2', got %q", value)
+		}
+	})
+
+	t.Run("submissions are appended", func(t *testing.T) {
+		history := clix.NewPromptHistory(10)
+		runTextKeys(t, history, "a\r")
+		runTextKeys(t, history, "b\r")
+		runTextKeys(t, history, up+up+"\r")
+
+		got := history.Entries()
+		want := []string{"a", "b", "a"}
+		if len(got) != len(want) {
+			t.Fatalf("expected entries %q, got %q", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected entries %q, got %q", want, got)
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"github.com/SCKelemen/clix/v2"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -212,3 +213,35 @@ func TestPromptMultiSelect(t *testing.T) {
 		}
 	})
 }
+
+func TestPromptMultiKeepsCommasInValues(t *testing.T) {
+	options := []clix.SelectOption{
+		{Label: "Warm", Value: "red, orange"},
+		{Label: "Cool", Value: "blue"},
+		{Label: "Pair", Value: "black,white"},
+	}
+
+	t.Run("method", func(t *testing.T) {
+		prompter := TerminalPrompter{In: bytes.NewBufferString("3 1\ndone\n"), Out: &bytes.Buffer{}}
+		got, err := prompter.PromptMulti(context.Background(), clix.PromptRequest{Label: "Colours", Options: options})
+		if err != nil {
+			t.Fatalf("PromptMulti: %v", err)
+		}
+		// Option order, not the order the user picked.
+		want := []string{"red, orange", "black,white"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("PromptMulti = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("through clix.PromptMulti", func(t *testing.T) {
+		var p clix.Prompter = TerminalPrompter{In: bytes.NewBufferString("1\n\n"), Out: &bytes.Buffer{}}
+		got, err := clix.PromptMulti(context.Background(), p, clix.WithLabel("Colours"), Select(options))
+		if err != nil {
+			t.Fatalf("PromptMulti: %v", err)
+		}
+		if want := []string{"red, orange"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("PromptMulti = %q, want %q", got, want)
+		}
+	})
+}
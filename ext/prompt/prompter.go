@@ -1,13 +1,13 @@
 package prompt
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/SCKelemen/clix/v2"
@@ -201,6 +201,20 @@ type TerminalPrompter struct {
 	Out io.Writer
 }
 
+// TerminalPrompter is a drop-in replacement for clix.TextPrompter.
+var (
+	_ clix.Prompter      = TerminalPrompter{}
+	_ clix.Selector      = TerminalPrompter{}
+	_ clix.MultiSelector = TerminalPrompter{}
+	_ clix.InputSource   = TerminalPrompter{}
+)
+
+// Input returns In, so that clix.InteractiveAuto can tell whether it is a
+// terminal.
+func (p TerminalPrompter) Input() io.Reader {
+	return p.In
+}
+
 // Prompt displays a prompt and reads the user's response.
 // Supports all prompt types: text, select, multi-select, and confirm.
 func (p TerminalPrompter) Prompt(ctx context.Context, opts ...clix.PromptOption) (string, error) {
@@ -216,6 +230,42 @@ func (p TerminalPrompter) Prompt(ctx context.Context, opts ...clix.PromptOption)
 	return p.prompt(ctx, cfg)
 }
 
+// SelectOne implements clix.Selector. It runs a select prompt and returns
+// the chosen option, including its Label and Description.
+func (p TerminalPrompter) SelectOne(ctx context.Context, opts ...clix.PromptOption) (clix.SelectOption, error) {
+	if p.In == nil || p.Out == nil {
+		return clix.SelectOption{}, errors.New("prompter missing IO")
+	}
+
+	cfg := &clix.PromptConfig{Theme: clix.DefaultPromptTheme}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	if len(cfg.Options) == 0 {
+		return clix.SelectOption{}, errors.New("select prompt requires options")
+	}
+	return p.promptSelect(ctx, cfg)
+}
+
+// PromptMulti implements clix.MultiSelector. It runs a multi-select prompt
+// and returns the selected values in option order, so values containing
+// commas are not split apart.
+func (p TerminalPrompter) PromptMulti(ctx context.Context, opts ...clix.PromptOption) ([]string, error) {
+	if p.In == nil || p.Out == nil {
+		return nil, errors.New("prompter missing IO")
+	}
+
+	cfg := &clix.PromptConfig{Theme: clix.DefaultPromptTheme}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	if len(cfg.Options) == 0 {
+		return nil, errors.New("multi-select prompt requires options")
+	}
+	cfg.MultiSelect = true
+	return p.promptMultiSelect(ctx, cfg)
+}
+
 func (p TerminalPrompter) prompt(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
 	if p.In == nil || p.Out == nil {
 		return "", errors.New("prompter missing IO")
@@ -228,12 +278,18 @@ func (p TerminalPrompter) prompt(ctx context.Context, cfg *clix.PromptConfig) (s
 
 	// Handle multi-select prompt
 	if len(cfg.Options) > 0 && cfg.MultiSelect {
-		return p.promptMultiSelect(ctx, cfg)
+		values, err := p.promptMultiSelect(ctx, cfg)
+		return strings.Join(values, ","), err
 	}
 
 	// Handle select prompt (options list)
 	if len(cfg.Options) > 0 {
-		return p.promptSelect(ctx, cfg)
+		opt, err := p.promptSelect(ctx, cfg)
+		return opt.Value, err
+	}
+
+	if cfg.Multiline {
+		return p.promptMultiline(ctx, cfg)
 	}
 
 	// Regular text prompt
@@ -249,17 +305,24 @@ func (p TerminalPrompter) promptText(ctx context.Context, cfg *clix.PromptConfig
 	}
 
 	// Check if it's actually a TTY
-	if !term.IsTerminal(int(inFile.Fd())) {
+	if !isTTY(int(inFile.Fd())) {
+		return p.promptTextLineBased(ctx, cfg)
+	}
+
+	// Enable raw mode for individual keystroke handling
+	state, ctx, err := enableRawMode(ctx, inFile, p.Out)
+	if err != nil {
+		// Fall back to line-based if raw mode fails
 		return p.promptTextLineBased(ctx, cfg)
 	}
+	defer state.Restore()
 
-	// Use interactive mode with raw terminal
-	return p.promptTextInteractive(ctx, cfg, inFile)
+	return p.promptTextInteractive(ctx, cfg)
 }
 
 // promptTextLineBased handles text input with line-based reading (fallback for non-terminals).
 func (p TerminalPrompter) promptTextLineBased(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
@@ -273,7 +336,11 @@ func (p TerminalPrompter) promptTextLineBased(ctx context.Context, cfg *clix.Pro
 
 		fmt.Fprint(p.Out, ": ")
 
-		line, err := reader.ReadString('\n')
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			return timeoutValue(cfg)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -295,21 +362,86 @@ func (p TerminalPrompter) promptTextLineBased(ctx context.Context, cfg *clix.Pro
 			}
 		}
 
+		cfg.History.Add(value)
 		return value, nil
 	}
 }
 
-// promptTextInteractive handles text input with raw terminal mode for advanced features.
-func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.PromptConfig, inFile *os.File) (string, error) {
-	// Enable raw mode for individual keystroke handling
-	state, err := EnableRawMode(inFile)
-	if err != nil {
-		// Fall back to line-based if raw mode fails
-		return p.promptTextLineBased(ctx, cfg)
+// promptMultiline reads lines until the end marker or EOF and joins them.
+// It reads whole lines even on a terminal so the line discipline handles editing.
+func (p TerminalPrompter) promptMultiline(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
+	deadline := promptDeadline(cfg.Timeout)
+	end := cfg.MultilineEnd
+	if end == "" {
+		end = clix.DefaultMultilineEnd
 	}
-	defer state.Restore()
 
+	for {
+		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
+		label := renderText(cfg.Theme.LabelStyle, cfg.Label)
+		fmt.Fprintf(p.Out, "%s%s", prefix, label)
+		if placeholder := placeholderText(cfg); placeholder != "" {
+			def := renderText(placeholderStyle(cfg.Theme), placeholder)
+			fmt.Fprintf(p.Out, " [%s]", def)
+		}
+		hint := clix.MultilineHint(end)
+		if cfg.Theme.Hint != "" {
+			hint = cfg.Theme.Hint + " " + hint
+		}
+		fmt.Fprintf(p.Out, " %s:\n", renderText(cfg.Theme.HintStyle, hint))
+
+		var lines []string
+		eof := false
+		for !eof {
+			line, err := readLine(ctx, p.In, deadline)
+			if errors.Is(err, clix.ErrPromptTimeout) {
+				return timeoutValue(cfg)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return "", err
+			}
+			eof = err != nil
+			line = strings.TrimRight(line, "\r\n")
+			if line == end {
+				break
+			}
+			if !eof || line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		value := strings.Join(lines, "\n")
+		if strings.TrimSpace(value) == "" {
+			value = cfg.Default
+		}
+
+		if cfg.Validate != nil {
+			if err := cfg.Validate(value); err != nil {
+				errPrefix := renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error)
+				errMsg := err.Error()
+				if errMsg != "" {
+					errMsg = renderText(cfg.Theme.ErrorStyle, errMsg)
+				}
+				fmt.Fprintf(p.Out, "%s%s\n", errPrefix, errMsg)
+				if eof {
+					return "", err
+				}
+				continue
+			}
+		}
+
+		return value, nil
+	}
+}
+
+// promptTextInteractive runs the raw-mode text input loop, reading keys from
+// p.In. Up and Down recall entries from cfg.History; Tab completes against
+// cfg.Suggest.
+func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
 	currentInput := ""
+	recall := newHistoryCursor(cfg.History)
+	completion := newCompleter(cfg)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		// Clear both lines (input and hint)
@@ -334,7 +466,10 @@ func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.P
 			fmt.Fprint(p.Out, currentInput)
 		}
 
-		suggestion := suggestionText(cfg, currentInput)
+		suggestion := completion.ghost(currentInput)
+		if suggestion == "" {
+			suggestion = suggestionText(cfg, currentInput)
+		}
 		if suggestion != "" {
 			def := renderText(suggestionStyle(cfg.Theme), suggestion)
 			fmt.Fprint(p.Out, def)
@@ -371,13 +506,21 @@ func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.P
 		}
 
 		// Read a single keypress
-		key, err := ReadKey(p.In)
+		key, err := readKey(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprint(p.Out, "\n")
+			fmt.Fprint(p.Out, "\r\033[K") // Clear hint line
+			return timeoutValue(cfg)
+		}
 		if err != nil {
 			return "", err
 		}
 
 		ShowCursor(p.Out) // Show cursor for input
 
+		if key != KeyTab {
+			completion.reset()
+		}
 		switch key {
 		case KeyEnter:
 			state := clix.PromptKeyState{
@@ -416,11 +559,23 @@ func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.P
 					}
 					fmt.Fprintf(p.Out, "%s%s\n", errPrefix, errMsg)
 					currentInput = ""
+					recall.reset()
 					continue
 				}
 			}
 
+			cfg.History.Add(value)
 			return value, nil
+		case KeyUp:
+			if entry, ok := recall.older(currentInput); ok {
+				currentInput = entry
+			}
+		case KeyDown:
+			if entry, ok := recall.newer(); ok {
+				currentInput = entry
+			}
+		case KeySpace:
+			currentInput += " "
 		case KeyBackspace:
 			if len(currentInput) > 0 {
 				currentInput = currentInput[:len(currentInput)-1]
@@ -443,8 +598,10 @@ func (p TerminalPrompter) promptTextInteractive(ctx context.Context, cfg *clix.P
 			if action.Handled {
 				continue
 			}
-			// Tab completion to default
-			if cfg.Default != "" {
+			// Tab completes against Suggest, else to the default
+			if next, ok := completion.tab(currentInput); ok {
+				currentInput = next
+			} else if cfg.Default != "" {
 				currentInput = cfg.Default
 			}
 		case KeyCtrlC:
@@ -510,7 +667,7 @@ func renderText(style clix.TextStyle, value string) string {
 }
 
 // promptSelect handles select-style prompts with navigable options.
-func (p TerminalPrompter) promptSelect(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
+func (p TerminalPrompter) promptSelect(ctx context.Context, cfg *clix.PromptConfig) (clix.SelectOption, error) {
 	// Check if input is a terminal - if not, use line-based fallback
 	inFile, isTerminal := p.In.(*os.File)
 	if !isTerminal {
@@ -518,248 +675,193 @@ func (p TerminalPrompter) promptSelect(ctx context.Context, cfg *clix.PromptConf
 	}
 
 	// Check if it's actually a TTY
-	if !term.IsTerminal(int(inFile.Fd())) {
+	if !isTTY(int(inFile.Fd())) {
 		return p.promptSelectLineBased(ctx, cfg)
 	}
 
 	// Enable raw mode for arrow key navigation
-	state, err := EnableRawMode(inFile)
+	state, ctx, err := enableRawMode(ctx, inFile, p.Out)
 	if err != nil {
 		// Fall back to line-based if raw mode fails
 		return p.promptSelectLineBased(ctx, cfg)
 	}
 	defer state.Restore()
 
-	// Find default option index
-	selectedIdx := 0
-	if cfg.Default != "" {
-		for i, opt := range cfg.Options {
-			if opt.Value == cfg.Default || opt.Label == cfg.Default {
-				selectedIdx = i
-				break
-			}
-		}
-	}
+	return p.promptSelectInteractive(ctx, cfg)
+}
+
+// promptSelectInteractive runs the raw-mode select loop, reading keys from
+// p.In. Typing filters the options; arrows move within the filtered list.
+func (p TerminalPrompter) promptSelectInteractive(ctx context.Context, cfg *clix.PromptConfig) (clix.SelectOption, error) {
+	sel := newSelectState(cfg.Options, cfg.Default, selectPageSize(cfg, p.Out))
 
 	// Hide cursor during selection
 	HideCursor(p.Out)
 	defer ShowCursor(p.Out)
 
-	// Calculate number of lines we'll render (label + options + hint line)
-	hintLines := 0
-	if cfg.Theme.Hint != "" {
-		hintLines = 1
+	// Initial render; redraws move back up over the lines rendered last time
+	rendered := p.renderSelectPrompt(cfg, sel)
+	redraw := func() {
+		MoveCursorUp(p.Out, rendered)
+		rendered = p.renderSelectPrompt(cfg, sel)
+	}
+	// finish clears the prompt and, if chosen is non-nil, echoes the selection
+	finish := func(chosen *clix.SelectOption) {
+		MoveCursorUp(p.Out, rendered)
+		fmt.Fprint(p.Out, "\r")
+		ClearToEndOfScreen(p.Out)
+		if chosen != nil {
+			prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
+			label := renderText(cfg.Theme.LabelStyle, cfg.Label)
+			fmt.Fprintf(p.Out, "%s%s: %s\n", prefix, label, chosen.Label)
+			// Ensure cursor is at column 0 for next prompt
+			fmt.Fprint(p.Out, "\r")
+		}
+		ShowCursor(p.Out)
 	}
-	linesToRender := 1 + len(cfg.Options) + hintLines // label line + options + hint
 
-	// Initial render
-	p.renderSelectPrompt(cfg, selectedIdx)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		// Read a single keypress
-		key, err := ReadKey(p.In)
+		key, err := readKey(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			if i := defaultOption(cfg); i >= 0 {
+				opt := cfg.Options[i]
+				finish(&opt)
+				return opt, nil
+			}
+			finish(nil)
+			return clix.SelectOption{}, err
+		}
 		if err != nil {
-			return "", err
+			return clix.SelectOption{}, err
 		}
 
-		// Handle navigation
 		switch key {
 		case KeyUp:
-			if selectedIdx > 0 {
-				selectedIdx--
-			} else {
-				selectedIdx = len(cfg.Options) - 1 // Wrap to bottom
-			}
-			// Move cursor up to start of prompt, then redraw
-			MoveCursorUp(p.Out, linesToRender)
-			p.renderSelectPrompt(cfg, selectedIdx)
+			sel.move(-1)
+			redraw()
 		case KeyDown:
-			if selectedIdx < len(cfg.Options)-1 {
-				selectedIdx++
-			} else {
-				selectedIdx = 0 // Wrap to top
-			}
-			// Move cursor up to start of prompt, then redraw
-			MoveCursorUp(p.Out, linesToRender)
-			p.renderSelectPrompt(cfg, selectedIdx)
+			sel.move(1)
+			redraw()
+		case KeyHome:
+			sel.moveTo(0)
+			redraw()
+		case KeyEnd:
+			sel.moveTo(len(sel.visible) - 1)
+			redraw()
 		case KeyEnter:
-			// Selection confirmed - clear all prompt lines and show selection
-			MoveCursorUp(p.Out, linesToRender)
-			// Clear all lines we rendered
-			for i := 0; i < linesToRender; i++ {
-				fmt.Fprint(p.Out, "\r\033[K")
-				if i < linesToRender-1 {
-					MoveCursorDown(p.Out, 1)
-				}
-			}
-			// Move back to the top line and clear it, then show the selected value
-			MoveCursorUp(p.Out, linesToRender-1)
-			fmt.Fprint(p.Out, "\r\033[K")
-			if len(cfg.Options) > 0 {
-				// Show what was selected (optional - can be removed if not desired)
-				prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
-				label := renderText(cfg.Theme.LabelStyle, cfg.Label)
-				fmt.Fprintf(p.Out, "%s%s: %s\n", prefix, label, cfg.Options[selectedIdx].Label)
-				// Ensure cursor is at column 0 for next prompt
-				fmt.Fprint(p.Out, "\r")
+			if opt, ok := sel.selected(); ok {
+				finish(&opt)
+				return opt, nil
 			}
-			ShowCursor(p.Out)
-			if len(cfg.Options) > 0 {
-				return cfg.Options[selectedIdx].Value, nil
+		case KeyBackspace:
+			if sel.query != "" {
+				runes := []rune(sel.query)
+				sel.setQuery(string(runes[:len(runes)-1]))
+				redraw()
 			}
 		case KeyCtrlC:
-			// Cancelled - clear all prompt lines
-			MoveCursorUp(p.Out, linesToRender)
-			// Clear all lines we rendered
-			for i := 0; i < linesToRender; i++ {
-				fmt.Fprint(p.Out, "\r\033[K")
-				if i < linesToRender-1 {
-					MoveCursorDown(p.Out, 1)
-				}
-			}
-			// Move back to the top line
-			MoveCursorUp(p.Out, linesToRender-1)
-			fmt.Fprint(p.Out, "\r\033[K")
-			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
-		case KeyEscape:
-			state := clix.PromptKeyState{Command: clix.PromptCommand{Type: clix.PromptCommandEscape}, Default: cfg.Default}
-			action := dispatchCommand(cfg, state, nil)
+			finish(nil)
+			return clix.SelectOption{}, errors.New("cancelled")
+		case KeyEscape, KeyF1, KeyF2, KeyF3, KeyF4, KeyF5, KeyF6, KeyF7, KeyF8, KeyF9, KeyF10, KeyF11, KeyF12:
+			command := clix.PromptCommand{Type: clix.PromptCommandEscape}
+			if key != KeyEscape {
+				command = clix.PromptCommand{Type: clix.PromptCommandFunction, FunctionKey: functionKeyNumber(key)}
+			}
+			action := dispatchCommand(cfg, clix.PromptKeyState{Command: command, Input: sel.query, Default: cfg.Default}, nil)
 			if action.Exit {
-				MoveCursorUp(p.Out, linesToRender)
-				for i := 0; i < linesToRender; i++ {
-					fmt.Fprint(p.Out, "\r\033[K")
-					if i < linesToRender-1 {
-						MoveCursorDown(p.Out, 1)
-					}
-				}
-				MoveCursorUp(p.Out, linesToRender-1)
-				fmt.Fprint(p.Out, "\r\033[K")
-				ShowCursor(p.Out)
-				return "", action.ExitErr
+				finish(nil)
+				return clix.SelectOption{}, action.ExitErr
 			}
 			if action.Handled {
-				MoveCursorUp(p.Out, linesToRender)
-				p.renderSelectPrompt(cfg, selectedIdx)
+				redraw()
 				continue
 			}
-			// Default: treat as cancel
-			MoveCursorUp(p.Out, linesToRender)
-			for i := 0; i < linesToRender; i++ {
-				fmt.Fprint(p.Out, "\r\033[K")
-				if i < linesToRender-1 {
-					MoveCursorDown(p.Out, 1)
-				}
-			}
-			MoveCursorUp(p.Out, linesToRender-1)
-			fmt.Fprint(p.Out, "\r\033[K")
-			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
-		case KeyF1, KeyF2, KeyF3, KeyF4, KeyF5, KeyF6, KeyF7, KeyF8, KeyF9, KeyF10, KeyF11, KeyF12:
-			state := clix.PromptKeyState{
-				Command: clix.PromptCommand{Type: clix.PromptCommandFunction, FunctionKey: functionKeyNumber(key)},
-				Default: cfg.Default,
-			}
-			action := dispatchCommand(cfg, state, nil)
-			if action.Exit {
-				MoveCursorUp(p.Out, linesToRender)
-				for i := 0; i < linesToRender; i++ {
-					fmt.Fprint(p.Out, "\r\033[K")
-					if i < linesToRender-1 {
-						MoveCursorDown(p.Out, 1)
-					}
-				}
-				MoveCursorUp(p.Out, linesToRender-1)
-				fmt.Fprint(p.Out, "\r\033[K")
-				ShowCursor(p.Out)
-				return "", action.ExitErr
-			}
-			if action.Handled {
-				MoveCursorUp(p.Out, linesToRender)
-				p.renderSelectPrompt(cfg, selectedIdx)
+			// Default: Escape clears an active filter, otherwise cancels
+			if key == KeyEscape && sel.query != "" {
+				sel.setQuery("")
+				redraw()
 				continue
 			}
-			// Default: treat as cancel
-			MoveCursorUp(p.Out, linesToRender)
-			for i := 0; i < linesToRender; i++ {
-				fmt.Fprint(p.Out, "\r\033[K")
-				if i < linesToRender-1 {
-					MoveCursorDown(p.Out, 1)
-				}
-			}
-			MoveCursorUp(p.Out, linesToRender-1)
-			fmt.Fprint(p.Out, "\r\033[K")
-			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
-		case KeyHome:
-			selectedIdx = 0
-			MoveCursorUp(p.Out, linesToRender)
-			p.renderSelectPrompt(cfg, selectedIdx)
-		case KeyEnd:
-			selectedIdx = len(cfg.Options) - 1
-			MoveCursorUp(p.Out, linesToRender)
-			p.renderSelectPrompt(cfg, selectedIdx)
+			finish(nil)
+			return clix.SelectOption{}, errors.New("cancelled")
 		default:
-			// Try to match by number (1-9) for quick selection
-			if key.IsPrintable() && key.Rune >= '1' && key.Rune <= '9' {
-				idx := int(key.Rune - '1')
-				if idx < len(cfg.Options) {
-					selectedIdx = idx
-					// Clear all prompt lines and show selection
-					MoveCursorUp(p.Out, linesToRender)
-					// Clear all lines we rendered
-					for i := 0; i < linesToRender; i++ {
-						fmt.Fprint(p.Out, "\r\033[K")
-						if i < linesToRender-1 {
-							MoveCursorDown(p.Out, 1)
-						}
-					}
-					// Clear all lines and show the selected value on a fresh line
-					MoveCursorUp(p.Out, linesToRender)
-					for i := 0; i < linesToRender; i++ {
-						fmt.Fprint(p.Out, "\r\033[K")
-						if i < linesToRender-1 {
-							fmt.Fprint(p.Out, "\n")
-						}
-					}
-					// Show what was selected on a clean line (starting from beginning of line)
-					fmt.Fprint(p.Out, "\r\033[K") // Ensure we're at start of line
-					prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
-					label := renderText(cfg.Theme.LabelStyle, cfg.Label)
-					fmt.Fprintf(p.Out, "%s%s: %s\n", prefix, label, cfg.Options[selectedIdx].Label)
-					// Ensure cursor is at column 0 for next prompt
-					fmt.Fprint(p.Out, "\r")
-					ShowCursor(p.Out)
-					return cfg.Options[selectedIdx].Value, nil
+			// Digits 1-9 pick an option directly while no filter is typed
+			if sel.query == "" && key.IsPrintable() && key.Rune >= '1' && key.Rune <= '9' {
+				if idx := int(key.Rune - '1'); idx < len(cfg.Options) {
+					opt := cfg.Options[idx]
+					finish(&opt)
+					return opt, nil
 				}
 			}
-			// For typing, we might want to switch to filtering mode
-			// For now, just ignore non-navigation keys
+			if key.IsPrintable() || key == KeySpace {
+				sel.setQuery(sel.query + string(key.Rune))
+				redraw()
+			}
 		}
 	}
 }
 
-// renderSelectPrompt renders the select prompt with the current selection.
-func (p TerminalPrompter) renderSelectPrompt(cfg *clix.PromptConfig, selectedIdx int) {
+// selectPageSize returns cfg.PageSize or, when unset, the number of options
+// that fit on the terminal behind out alongside the label, hint and "more"
+// markers. It returns zero (no paging) when out is not a terminal.
+func selectPageSize(cfg *clix.PromptConfig, out io.Writer) int {
+	if cfg.PageSize > 0 {
+		return cfg.PageSize
+	}
+	file, ok := out.(*os.File)
+	if !ok {
+		return 0
+	}
+	_, height, err := term.GetSize(int(file.Fd()))
+	if err != nil || height <= 0 {
+		return 0
+	}
+	// label, two "more" markers, optional hint and the line the cursor rests on
+	reserved := 4
+	if cfg.Theme.Hint != "" {
+		reserved++
+	}
+	return max(height-reserved, 1)
+}
+
+// renderSelectPrompt renders the select prompt with the current selection
+// and returns the number of lines written.
+func (p TerminalPrompter) renderSelectPrompt(cfg *clix.PromptConfig, sel *selectState) int {
+	lines := 0
+
 	// Move to start of line and clear it
 	fmt.Fprint(p.Out, "\r\033[K")
 	prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
 	label := renderText(cfg.Theme.LabelStyle, cfg.Label)
 	fmt.Fprintf(p.Out, "%s%s", prefix, label)
 
-	if cfg.Theme.Hint != "" {
+	if sel.query != "" {
+		fmt.Fprintf(p.Out, ": %s", sel.query)
+	} else if cfg.Theme.Hint != "" {
 		hint := renderText(cfg.Theme.HintStyle, cfg.Theme.Hint)
 		fmt.Fprintf(p.Out, " %s", hint)
 	}
 	// Clear rest of line and move to next
 	fmt.Fprint(p.Out, "\033[K\n")
+	lines++
 
-	// Display options
-	for i, opt := range cfg.Options {
+	// Display the current page of options, with markers for scrolled-off ones
+	start, end := sel.page()
+	if start > 0 {
+		fmt.Fprint(p.Out, "\r\033[K")
+		fmt.Fprint(p.Out, renderText(cfg.Theme.HintStyle, "  ↑ more"))
+		fmt.Fprint(p.Out, "\033[K\n")
+		lines++
+	}
+	for i := start; i < end; i++ {
+		opt := cfg.Options[sel.visible[i]]
 		// Move to start of line and clear it
 		fmt.Fprint(p.Out, "\r\033[K")
 		marker := " "
-		if i == selectedIdx {
+		if i == sel.cursor {
 			marker = ">"
 		}
 		fmt.Fprintf(p.Out, "%s %s", marker, opt.Label)
@@ -768,6 +870,19 @@ func (p TerminalPrompter) renderSelectPrompt(cfg *clix.PromptConfig, selectedIdx
 		}
 		// Clear rest of line and move to next
 		fmt.Fprint(p.Out, "\033[K\n")
+		lines++
+	}
+	if end < len(sel.visible) {
+		fmt.Fprint(p.Out, "\r\033[K")
+		fmt.Fprint(p.Out, renderText(cfg.Theme.HintStyle, "  ↓ more"))
+		fmt.Fprint(p.Out, "\033[K\n")
+		lines++
+	}
+	if len(sel.visible) == 0 {
+		fmt.Fprint(p.Out, "\r\033[K")
+		fmt.Fprint(p.Out, renderText(cfg.Theme.HintStyle, "  no matching options"))
+		fmt.Fprint(p.Out, "\033[K\n")
+		lines++
 	}
 
 	// Show hint at bottom in low contrast
@@ -776,23 +891,18 @@ func (p TerminalPrompter) renderSelectPrompt(cfg *clix.PromptConfig, selectedIdx
 		hint := renderText(cfg.Theme.HintStyle, cfg.Theme.Hint)
 		fmt.Fprint(p.Out, hint)
 		fmt.Fprint(p.Out, "\n")
+		lines++
 	}
+
+	// Clear anything left over from a longer previous render
+	ClearToEndOfScreen(p.Out)
+	return lines
 }
 
 // promptSelectLineBased is the fallback line-based implementation for non-terminal input.
-func (p TerminalPrompter) promptSelectLineBased(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
-
-	// Find default option index
-	defaultIdx := -1
-	if cfg.Default != "" {
-		for i, opt := range cfg.Options {
-			if opt.Value == cfg.Default || opt.Label == cfg.Default {
-				defaultIdx = i
-				break
-			}
-		}
-	}
+func (p TerminalPrompter) promptSelectLineBased(ctx context.Context, cfg *clix.PromptConfig) (clix.SelectOption, error) {
+	defaultIdx := defaultOption(cfg)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
@@ -825,9 +935,16 @@ func (p TerminalPrompter) promptSelectLineBased(ctx context.Context, cfg *clix.P
 
 		fmt.Fprint(p.Out, "> ")
 
-		line, err := reader.ReadString('\n')
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			if defaultIdx >= 0 {
+				return cfg.Options[defaultIdx], nil
+			}
+			return clix.SelectOption{}, err
+		}
 		if err != nil {
-			return "", err
+			return clix.SelectOption{}, err
 		}
 
 		input := strings.TrimSpace(line)
@@ -835,26 +952,26 @@ func (p TerminalPrompter) promptSelectLineBased(ctx context.Context, cfg *clix.P
 		// Empty input uses default or first option
 		if input == "" {
 			if defaultIdx >= 0 {
-				return cfg.Options[defaultIdx].Value, nil
+				return cfg.Options[defaultIdx], nil
 			}
 			if len(cfg.Options) > 0 {
-				return cfg.Options[0].Value, nil
+				return cfg.Options[0], nil
 			}
 		}
 
 		// Try to match by number (1-based index)
 		if idx := parseIndex(input, len(cfg.Options)); idx >= 0 {
-			return cfg.Options[idx].Value, nil
+			return cfg.Options[idx], nil
 		}
 
 		// Try to match by value or label
 		for _, opt := range cfg.Options {
 			if strings.EqualFold(opt.Value, input) || strings.EqualFold(opt.Label, input) {
-				return opt.Value, nil
+				return opt, nil
 			}
 			// Partial match on label (for filtering)
 			if strings.HasPrefix(strings.ToLower(opt.Label), strings.ToLower(input)) {
-				return opt.Value, nil
+				return opt, nil
 			}
 		}
 
@@ -871,9 +988,23 @@ func (p TerminalPrompter) promptSelectLineBased(ctx context.Context, cfg *clix.P
 			}
 		}
 
-		// If validation passes, return the input
-		return input, nil
+		// If validation passes, return the input as an ad-hoc option
+		return clix.SelectOption{Label: input, Value: input}, nil
+	}
+}
+
+// defaultOption returns the index of the option cfg.Default names by value or
+// label, or -1 if it names none.
+func defaultOption(cfg *clix.PromptConfig) int {
+	if cfg.Default == "" {
+		return -1
 	}
+	for i, opt := range cfg.Options {
+		if opt.Value == cfg.Default || opt.Label == cfg.Default {
+			return i
+		}
+	}
+	return -1
 }
 
 // parseIndex attempts to parse input as a 1-based index.
@@ -893,8 +1024,6 @@ func parseIndex(input string, max int) int {
 
 // promptConfirm handles yes/no confirmation prompts.
 func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
-
 	// Determine default (Y/n or y/N)
 	defaultYes := true
 	defaultText := "Y"
@@ -902,6 +1031,7 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 		defaultYes = false
 		defaultText = "N"
 	}
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		// Ensure cursor is at column 0 (in case previous prompt left it elsewhere)
@@ -911,10 +1041,14 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 		fmt.Fprintf(p.Out, "%s%s", prefix, label)
 
 		// Show default in prompt
+		abortChoice := ""
+		if cfg.Abort {
+			abortChoice = "/a"
+		}
 		if defaultYes {
-			fmt.Fprintf(p.Out, " (%s/n)", defaultText)
+			fmt.Fprintf(p.Out, " (%s/n%s)", defaultText, abortChoice)
 		} else {
-			fmt.Fprintf(p.Out, " (y/%s)", defaultText)
+			fmt.Fprintf(p.Out, " (y/%s%s)", defaultText, abortChoice)
 		}
 
 		// Show hint if provided (may include "back" instruction from survey)
@@ -925,8 +1059,14 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 
 		fmt.Fprint(p.Out, ": ")
 
-		line, err := reader.ReadString('\n')
-		if err != nil {
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			if cfg.Default == "" {
+				return "", err
+			}
+			line = ""
+		} else if err != nil {
 			return "", err
 		}
 
@@ -947,6 +1087,9 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 		if lowerValue == "n" || lowerValue == "no" {
 			return "n", nil
 		}
+		if cfg.Abort && (lowerValue == "a" || lowerValue == "abort") {
+			return "", clix.ErrAborted
+		}
 
 		// Allow "back" to pass through for undo functionality (survey will handle it)
 		if lowerValue == "back" {
@@ -956,6 +1099,9 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 		// Invalid input
 		errPrefix := renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error)
 		errMsg := "please enter 'y' or 'n'"
+		if cfg.Abort {
+			errMsg = "please enter 'y', 'n' or 'a'"
+		}
 		if cfg.Theme.ErrorStyle != nil {
 			errMsg = renderText(cfg.Theme.ErrorStyle, errMsg)
 		}
@@ -964,7 +1110,7 @@ func (p TerminalPrompter) promptConfirm(ctx context.Context, cfg *clix.PromptCon
 }
 
 // promptMultiSelect handles multi-select prompts where users can choose multiple options.
-func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
+func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.PromptConfig) ([]string, error) {
 	// Check if input is a terminal - if not, use line-based fallback
 	inFile, isTerminal := p.In.(*os.File)
 	if !isTerminal {
@@ -972,41 +1118,20 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 	}
 
 	// Check if it's actually a TTY
-	if !term.IsTerminal(int(inFile.Fd())) {
+	if !isTTY(int(inFile.Fd())) {
 		return p.promptMultiSelectLineBased(ctx, cfg)
 	}
 
 	// Enable raw mode for arrow key navigation
-	state, err := EnableRawMode(inFile)
+	state, ctx, err := enableRawMode(ctx, inFile, p.Out)
 	if err != nil {
 		// Fall back to line-based if raw mode fails
 		return p.promptMultiSelectLineBased(ctx, cfg)
 	}
 	defer state.Restore()
 
-	// Parse default selections
-	selected := make(map[int]bool)
-	if cfg.Default != "" {
-		// Try parsing as indices first (e.g., "1,2,3")
-		indices := parseIndices(cfg.Default, len(cfg.Options))
-		if len(indices) > 0 {
-			for _, idx := range indices {
-				selected[idx] = true
-			}
-		} else {
-			// Try parsing as comma-separated values (e.g., "a,b,c")
-			values := strings.Split(cfg.Default, ",")
-			for _, val := range values {
-				val = strings.TrimSpace(val)
-				for i, opt := range cfg.Options {
-					if opt.Value == val || opt.Label == val {
-						selected[i] = true
-						break
-					}
-				}
-			}
-		}
-	}
+	selected := defaultSelections(cfg)
+	deadline := promptDeadline(cfg.Timeout)
 
 	currentIdx := 0
 	if len(cfg.Options) > 0 {
@@ -1038,9 +1163,13 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 
 	for {
 		// Read a single keypress
-		key, err := ReadKey(p.In)
+		key, err := readKey(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprint(p.Out, "\n")
+			return timeoutSelections(cfg)
+		}
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		// Handle navigation and selection
@@ -1086,7 +1215,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 				if hasSelection {
 					ShowCursor(p.Out)
 					fmt.Fprint(p.Out, "\n")
-					return p.formatSelectedValues(cfg.Options, selected), nil
+					return selectedValues(cfg.Options, selected), nil
 				}
 				// No selections - stay on continue button (can't continue without selections)
 			} else {
@@ -1110,7 +1239,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 			MoveCursorUp(p.Out, linesToRender-1)
 			fmt.Fprint(p.Out, "\r\033[K")
 			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
+			return nil, errors.New("cancelled")
 		case KeyEscape:
 			state := clix.PromptKeyState{Command: clix.PromptCommand{Type: clix.PromptCommandEscape}, Default: cfg.Default}
 			action := dispatchCommand(cfg, state, nil)
@@ -1125,7 +1254,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 				MoveCursorUp(p.Out, linesToRender-1)
 				fmt.Fprint(p.Out, "\r\033[K")
 				ShowCursor(p.Out)
-				return "", action.ExitErr
+				return nil, action.ExitErr
 			}
 			if action.Handled {
 				MoveCursorUp(p.Out, linesToRender)
@@ -1143,7 +1272,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 			MoveCursorUp(p.Out, linesToRender-1)
 			fmt.Fprint(p.Out, "\r\033[K")
 			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
+			return nil, errors.New("cancelled")
 		case KeyF1, KeyF2, KeyF3, KeyF4, KeyF5, KeyF6, KeyF7, KeyF8, KeyF9, KeyF10, KeyF11, KeyF12:
 			state := clix.PromptKeyState{
 				Command: clix.PromptCommand{Type: clix.PromptCommandFunction, FunctionKey: functionKeyNumber(key)},
@@ -1161,7 +1290,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 				MoveCursorUp(p.Out, linesToRender-1)
 				fmt.Fprint(p.Out, "\r\033[K")
 				ShowCursor(p.Out)
-				return "", action.ExitErr
+				return nil, action.ExitErr
 			}
 			if action.Handled {
 				MoveCursorUp(p.Out, linesToRender)
@@ -1179,7 +1308,7 @@ func (p TerminalPrompter) promptMultiSelect(ctx context.Context, cfg *clix.Promp
 			MoveCursorUp(p.Out, linesToRender-1)
 			fmt.Fprint(p.Out, "\r\033[K")
 			ShowCursor(p.Out)
-			return "", errors.New("cancelled")
+			return nil, errors.New("cancelled")
 		case KeyHome:
 			onContinueButton = false
 			currentIdx = 0
@@ -1262,8 +1391,9 @@ func (p TerminalPrompter) renderMultiSelectPrompt(cfg *clix.PromptConfig, select
 	}
 }
 
-// formatSelectedValues formats selected options into a comma-delimited string of values.
-func (p TerminalPrompter) formatSelectedValues(options []clix.SelectOption, selected map[int]bool) string {
+// selectedValues returns the values of the selected options in option order.
+// An option without a Value contributes its Label.
+func selectedValues(options []clix.SelectOption, selected map[int]bool) []string {
 	var values []string
 	for i, opt := range options {
 		if selected[i] {
@@ -1274,7 +1404,7 @@ func (p TerminalPrompter) formatSelectedValues(options []clix.SelectOption, sele
 			values = append(values, opt.Label)
 		}
 	}
-	return strings.Join(values, ",")
+	return values
 }
 
 // parseIndices parses a string containing indices (supports comma, space, or comma-space separated).
@@ -1299,32 +1429,9 @@ func parseIndices(input string, max int) []int {
 }
 
 // promptMultiSelectLineBased is the fallback line-based implementation for non-terminal input.
-func (p TerminalPrompter) promptMultiSelectLineBased(ctx context.Context, cfg *clix.PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
-
-	// Parse default selections
-	selected := make(map[int]bool)
-	if cfg.Default != "" {
-		// Try parsing as indices first (e.g., "1,2,3")
-		indices := parseIndices(cfg.Default, len(cfg.Options))
-		if len(indices) > 0 {
-			for _, idx := range indices {
-				selected[idx] = true
-			}
-		} else {
-			// Try parsing as comma-separated values (e.g., "a,b,c")
-			values := strings.Split(cfg.Default, ",")
-			for _, val := range values {
-				val = strings.TrimSpace(val)
-				for i, opt := range cfg.Options {
-					if opt.Value == val || opt.Label == val {
-						selected[i] = true
-						break
-					}
-				}
-			}
-		}
-	}
+func (p TerminalPrompter) promptMultiSelectLineBased(ctx context.Context, cfg *clix.PromptConfig) ([]string, error) {
+	selected := defaultSelections(cfg)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
@@ -1352,9 +1459,13 @@ func (p TerminalPrompter) promptMultiSelectLineBased(ctx context.Context, cfg *c
 
 		fmt.Fprint(p.Out, "> ")
 
-		line, err := reader.ReadString('\n')
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, clix.ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			return timeoutSelections(cfg)
+		}
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		input := strings.TrimSpace(line)
@@ -1365,13 +1476,13 @@ func (p TerminalPrompter) promptMultiSelectLineBased(ctx context.Context, cfg *c
 				fmt.Fprintf(p.Out, "%sPlease select at least one option\n", renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error))
 				continue
 			}
-			return p.formatSelectedValues(cfg.Options, selected), nil
+			return selectedValues(cfg.Options, selected), nil
 		}
 
 		// Empty input with selections - return selected values
 		if input == "" {
 			if len(selected) > 0 {
-				return p.formatSelectedValues(cfg.Options, selected), nil
+				return selectedValues(cfg.Options, selected), nil
 			}
 			fmt.Fprintf(p.Out, "%sPlease select at least one option\n", renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error))
 			continue
@@ -1413,3 +1524,72 @@ func (p TerminalPrompter) promptMultiSelectLineBased(ctx context.Context, cfg *c
 		// After toggling, continue loop to show updated state
 	}
 }
+
+// promptDeadline returns when a prompt with the given timeout expires, or the
+// zero time for no timeout.
+func promptDeadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
+// timeoutValue is the result of a text prompt whose timeout elapsed: its
+// Default, provided Validate accepts it, or clix.ErrPromptTimeout.
+func timeoutValue(cfg *clix.PromptConfig) (string, error) {
+	if cfg.Default == "" {
+		return "", clix.ErrPromptTimeout
+	}
+	if cfg.Validate != nil {
+		if err := cfg.Validate(cfg.Default); err != nil {
+			return "", fmt.Errorf("%w: default %q: %w", clix.ErrPromptTimeout, cfg.Default, err)
+		}
+	}
+	return cfg.Default, nil
+}
+
+// timeoutSelections is the result of a multi-select prompt whose timeout
+// elapsed: the options Default selects, or clix.ErrPromptTimeout.
+func timeoutSelections(cfg *clix.PromptConfig) ([]string, error) {
+	selected := defaultSelections(cfg)
+	if len(selected) == 0 {
+		return nil, clix.ErrPromptTimeout
+	}
+	return selectedValues(cfg.Options, selected), nil
+}
+
+// defaultSelections parses cfg.Default for a multi-select prompt, either as
+// 1-based indices ("1,2,3") or as comma-separated values or labels ("a,b,c").
+func defaultSelections(cfg *clix.PromptConfig) map[int]bool {
+	selected := make(map[int]bool)
+	if cfg.Default == "" {
+		return selected
+	}
+	if indices := parseIndices(cfg.Default, len(cfg.Options)); len(indices) > 0 {
+		for _, idx := range indices {
+			selected[idx] = true
+		}
+		return selected
+	}
+	for _, val := range strings.Split(cfg.Default, ",") {
+		val = strings.TrimSpace(val)
+		for i, opt := range cfg.Options {
+			if opt.Value == val || opt.Label == val {
+				selected[i] = true
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// readLine reads one line from in (see clix.ReadPromptLine). A read abandoned
+// on timeout or cancel hands its line to the next prompt instead of losing it.
+func readLine(ctx context.Context, in io.Reader, deadline time.Time) (string, error) {
+	return clix.ReadPromptLine(ctx, in, deadline)
+}
+
+// readKey reads one keypress from in (see clix.PromptReader).
+func readKey(ctx context.Context, in io.Reader, deadline time.Time) (Key, error) {
+	return ReadKey(clix.PromptReader(ctx, in, deadline))
+}
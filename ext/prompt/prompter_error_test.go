@@ -102,7 +102,7 @@ func TestPrompterErrorHandling(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		// formatSelectedValues returns comma-separated without spaces: "a,b"
+		// Prompt joins the selected values with commas, without spaces: "a,b"
 		if value != "a,b" {
 			t.Fatalf("expected 'a,b', got %q", value)
 		}
@@ -338,7 +338,7 @@ func TestMultiSelectPromptEdgeCases(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		// formatSelectedValues returns comma-separated without spaces: "a,b"
+		// Prompt joins the selected values with commas, without spaces: "a,b"
 		if value != "a,b" {
 			t.Fatalf("expected 'a,b', got %q", value)
 		}
@@ -407,3 +407,28 @@ func TestReadKeyEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestTerminalPrompterConfirmAbort(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "yes\n", want: "y"},
+		{input: "n\n", want: "n"},
+		{input: "a\n", wantErr: clix.ErrAborted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input[:len(tt.input)-1], func(t *testing.T) {
+			out := &bytes.Buffer{}
+			prompter := TerminalPrompter{In: bytes.NewBufferString(tt.input), Out: out}
+			value, err := prompter.Prompt(context.Background(), clix.WithLabel("Overwrite?"), clix.WithConfirm(), clix.WithAbort())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if value != tt.want {
+				t.Errorf("value = %q, want %q", value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func TestTerminalPrompterMultiline(t *testing.T) {
+	t.Run("ends at sentinel line", func(t *testing.T) {
+		in := bytes.NewBufferString("line one\n  indented\n.\n")
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), clix.PromptRequest{
+			Label:     "Description",
+			Multiline: true,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if want := "line one\n  indented"; value != want {
+			t.Fatalf("expected %q, got %q", want, value)
+		}
+	})
+
+	t.Run("ends at EOF", func(t *testing.T) {
+		in := bytes.NewBufferString("only\nlines\n")
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), clix.WithLabel("Notes"), clix.WithMultiline())
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if want := "only\nlines"; value != want {
+			t.Fatalf("expected %q, got %q", want, value)
+		}
+	})
+}
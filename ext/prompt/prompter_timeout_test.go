@@ -0,0 +1,182 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func TestTerminalPrompterTimeout(t *testing.T) {
+	t.Run("returns default when timeout elapses", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), clix.PromptRequest{
+			Label:   "Region",
+			Default: "us-east-1",
+			Timeout: 20 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "us-east-1" {
+			t.Fatalf("expected default after timeout, got %q", value)
+		}
+	})
+
+	t.Run("errors without a default", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		_, err := prompter.Prompt(context.Background(), clix.WithLabel("Region"), clix.WithTimeout(20*time.Millisecond))
+		if !errors.Is(err, clix.ErrPromptTimeout) {
+			t.Fatalf("expected ErrPromptTimeout, got %v", err)
+		}
+	})
+
+	t.Run("next prompt receives input after a timeout", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		if _, err := prompter.Prompt(context.Background(), clix.WithLabel("First"), clix.WithTimeout(20*time.Millisecond)); !errors.Is(err, clix.ErrPromptTimeout) {
+			t.Fatalf("expected ErrPromptTimeout, got %v", err)
+		}
+
+		go w.Write([]byte("second\n"))
+		value, err := prompter.Prompt(context.Background(), clix.WithLabel("Second"))
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "second" {
+			t.Fatalf("expected the second prompt to get %q, got %q", "second", value)
+		}
+	})
+
+	t.Run("returns input submitted in time", func(t *testing.T) {
+		prompter := TerminalPrompter{In: bytes.NewBufferString("eu-west-1\n"), Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), clix.PromptRequest{
+			Label:   "Region",
+			Default: "us-east-1",
+			Timeout: time.Second,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "eu-west-1" {
+			t.Fatalf("expected submitted value, got %q", value)
+		}
+	})
+}
+
+func TestTerminalPrompterTimeoutChoices(t *testing.T) {
+	options := []clix.SelectOption{{Label: "Alpha", Value: "a"}, {Label: "Beta", Value: "b"}}
+	timeout := 20 * time.Millisecond
+
+	t.Run("confirm answers its default", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), clix.PromptRequest{Label: "Continue?", Confirm: true, Default: "n", Timeout: timeout})
+		if err != nil || value != "n" {
+			t.Fatalf("Prompt = %q, %v; want the default n", value, err)
+		}
+	})
+
+	t.Run("select returns the default option", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		opt, err := prompter.SelectOne(context.Background(), clix.PromptRequest{Label: "Choose", Options: options, Default: "b", Timeout: timeout})
+		if err != nil || opt.Value != "b" {
+			t.Fatalf("SelectOne = %+v, %v; want the default b", opt, err)
+		}
+	})
+
+	t.Run("multi-select returns the default selections", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+		values, err := prompter.PromptMulti(context.Background(), clix.PromptRequest{Label: "Choose", Options: options, MultiSelect: true, Default: "a,b", Timeout: timeout})
+		if err != nil || strings.Join(values, ",") != "a,b" {
+			t.Fatalf("PromptMulti = %q, %v; want the defaults a,b", values, err)
+		}
+	})
+
+	for name, req := range map[string]clix.PromptRequest{
+		"confirm":      {Label: "Delete?", Confirm: true},
+		"select":       {Label: "Choose", Options: options},
+		"multi-select": {Label: "Choose", Options: options, MultiSelect: true},
+		"invalid text": {Label: "Port", Default: "http", Validate: func(string) error { return errors.New("not a number") }},
+	} {
+		t.Run(name+" without a usable default errors", func(t *testing.T) {
+			in, w := io.Pipe()
+			defer w.Close()
+
+			req.Timeout = timeout
+			prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+			var err error
+			if req.MultiSelect {
+				_, err = prompter.PromptMulti(context.Background(), req)
+			} else {
+				_, err = prompter.Prompt(context.Background(), req)
+			}
+			if !errors.Is(err, clix.ErrPromptTimeout) {
+				t.Fatalf("expected ErrPromptTimeout, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTerminalPrompterContextCancel(t *testing.T) {
+	for name, req := range map[string]clix.PromptRequest{
+		"text":    {Label: "Name"},
+		"confirm": {Label: "Continue?", Confirm: true},
+		"select":  {Label: "Choose", Options: []clix.SelectOption{{Label: "A", Value: "a"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			in, w := io.Pipe()
+			defer w.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+
+			prompter := TerminalPrompter{In: in, Out: &bytes.Buffer{}}
+			if _, err := prompter.Prompt(ctx, req); !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadKeyAfterTimeout(t *testing.T) {
+	in, w := io.Pipe()
+	defer w.Close()
+
+	deadline := time.Now().Add(20 * time.Millisecond)
+	if _, err := readKey(context.Background(), in, deadline); !errors.Is(err, clix.ErrPromptTimeout) {
+		t.Fatalf("expected ErrPromptTimeout, got %v", err)
+	}
+
+	// The abandoned read gets the keypress and hands it to the next reader
+	// rather than dropping it.
+	go w.Write([]byte("\x1b[A"))
+	key, err := readKey(context.Background(), in, time.Time{})
+	if err != nil {
+		t.Fatalf("readKey returned error: %v", err)
+	}
+	if key != KeyUp {
+		t.Fatalf("expected KeyUp, got %v", key)
+	}
+}
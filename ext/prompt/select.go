@@ -0,0 +1,104 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// selectState tracks the filter query and highlighted option of an
+// interactive select prompt.
+type selectState struct {
+	options []clix.SelectOption
+	query   string
+	visible []int // indices into options that match query
+	cursor  int   // position of the highlighted option within visible
+
+	pageSize int // visible options per page; zero shows them all
+	offset   int // position within visible of the first option shown
+}
+
+// newSelectState shows every option and highlights the one matching def
+// (by value or label), or the first.
+func newSelectState(options []clix.SelectOption, def string, pageSize int) *selectState {
+	s := &selectState{options: options, pageSize: pageSize}
+	s.setQuery("")
+	for i, opt := range options {
+		if def != "" && (opt.Value == def || opt.Label == def) {
+			s.cursor = i
+			break
+		}
+	}
+	s.scroll()
+	return s
+}
+
+// setQuery filters the options by case-insensitive substring match on Label.
+// The highlighted option stays highlighted if it still matches.
+func (s *selectState) setQuery(query string) {
+	current := -1
+	if s.cursor >= 0 && s.cursor < len(s.visible) {
+		current = s.visible[s.cursor]
+	}
+
+	s.query = query
+	s.visible = s.visible[:0]
+	s.cursor = 0
+	needle := strings.ToLower(query)
+	for i, opt := range s.options {
+		if strings.Contains(strings.ToLower(opt.Label), needle) {
+			if i == current {
+				s.cursor = len(s.visible)
+			}
+			s.visible = append(s.visible, i)
+		}
+	}
+	s.scroll()
+}
+
+// move shifts the highlight by delta within the visible options, wrapping
+// around at either end.
+func (s *selectState) move(delta int) {
+	if len(s.visible) == 0 {
+		return
+	}
+	s.cursor = ((s.cursor+delta)%len(s.visible) + len(s.visible)) % len(s.visible)
+	s.scroll()
+}
+
+// moveTo highlights the option at position i of the visible options.
+func (s *selectState) moveTo(i int) {
+	s.cursor = max(min(i, len(s.visible)-1), 0)
+	s.scroll()
+}
+
+// scroll adjusts the page so the highlighted option is shown.
+func (s *selectState) scroll() {
+	if s.pageSize <= 0 || len(s.visible) <= s.pageSize {
+		s.offset = 0
+		return
+	}
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+s.pageSize {
+		s.offset = s.cursor - s.pageSize + 1
+	}
+	s.offset = max(min(s.offset, len(s.visible)-s.pageSize), 0)
+}
+
+// page returns the bounds, within visible, of the options currently shown.
+func (s *selectState) page() (start, end int) {
+	if s.pageSize <= 0 {
+		return 0, len(s.visible)
+	}
+	return s.offset, min(s.offset+s.pageSize, len(s.visible))
+}
+
+// selected returns the highlighted option, if any option is visible.
+func (s *selectState) selected() (clix.SelectOption, bool) {
+	if s.cursor < 0 || s.cursor >= len(s.visible) {
+		return clix.SelectOption{}, false
+	}
+	return s.options[s.visible[s.cursor]], true
+}
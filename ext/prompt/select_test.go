@@ -0,0 +1,220 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+var fruitOptions = []clix.SelectOption{
+	{Label: "Apple", Value: "apple"},
+	{Label: "Banana", Value: "banana"},
+	{Label: "Grape", Value: "grape"},
+	{Label: "Pineapple", Value: "pineapple"},
+	{Label: "Mango", Value: "mango"},
+}
+
+// runSelectKeys drives the interactive select loop with a raw key stream.
+func runSelectKeys(t *testing.T, keys string) (string, string) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	prompter := TerminalPrompter{In: bytes.NewBufferString(keys), Out: out}
+	cfg := &clix.PromptConfig{Label: "Fruit", Theme: clix.DefaultPromptTheme, Options: fruitOptions}
+	opt, err := prompter.promptSelectInteractive(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("select returned error: %v", err)
+	}
+	return opt.Value, out.String()
+}
+
+func TestSelectFiltering(t *testing.T) {
+	t.Run("typing filters to a subset", func(t *testing.T) {
+		value, out := runSelectKeys(t, "APP\r")
+		if value != "apple" {
+			t.Fatalf("expected first filtered option 'apple', got %q", value)
+		}
+		// The last render before Enter lists only the matches
+		last := out[strings.LastIndex(out, "Fruit: APP"):]
+		if !strings.Contains(last, "Pineapple") || strings.Contains(last, "Banana") {
+			t.Errorf("expected only matching options after filtering, got %q", last)
+		}
+	})
+
+	t.Run("arrows move within the filtered subset", func(t *testing.T) {
+		value, _ := runSelectKeys(t, "pp\x1b[B\r")
+		if value != "pineapple" {
+			t.Fatalf("expected second filtered option 'pineapple', got %q", value)
+		}
+	})
+
+	t.Run("backspace clears the filter", func(t *testing.T) {
+		// Grape stays highlighted once the filter is cleared, so Down lands
+		// on the option after it in the full list.
+		value, out := runSelectKeys(t, "gr\x7f\x7f\x1b[B\r")
+		if value != "pineapple" {
+			t.Fatalf("expected 'pineapple' after clearing the filter, got %q", value)
+		}
+		renders := strings.Split(out, "? Fruit")
+		last := renders[len(renders)-2] // the final split is the echoed selection
+		for _, opt := range fruitOptions {
+			if !strings.Contains(last, opt.Label) {
+				t.Errorf("expected %s to be listed again after clearing, got %q", opt.Label, last)
+			}
+		}
+	})
+
+	t.Run("wrapping stays inside the filter", func(t *testing.T) {
+		value, _ := runSelectKeys(t, "an\x1b[A\r")
+		if value != "mango" {
+			t.Fatalf("expected wrap to last filtered option 'mango', got %q", value)
+		}
+	})
+
+	t.Run("enter with no matches is ignored", func(t *testing.T) {
+		value, out := runSelectKeys(t, "xyz\r\x7f\x7f\x7f\r")
+		if value != "apple" {
+			t.Fatalf("expected 'apple' after clearing the filter, got %q", value)
+		}
+		if !strings.Contains(out, "no matching options") {
+			t.Errorf("expected empty-filter notice, got %q", out)
+		}
+	})
+}
+
+func TestSelectStateKeepsHighlight(t *testing.T) {
+	s := newSelectState(fruitOptions, "pineapple", 0)
+	if opt, _ := s.selected(); opt.Value != "pineapple" {
+		t.Fatalf("expected default to be highlighted, got %q", opt.Value)
+	}
+	s.setQuery("apple")
+	if opt, _ := s.selected(); opt.Value != "pineapple" {
+		t.Errorf("expected highlight to survive filtering, got %q", opt.Value)
+	}
+	s.setQuery("ban")
+	if opt, _ := s.selected(); opt.Value != "banana" {
+		t.Errorf("expected highlight to move to first match, got %q", opt.Value)
+	}
+}
+
+func numberedOptions(n int) []clix.SelectOption {
+	options := make([]clix.SelectOption, n)
+	for i := range options {
+		options[i] = clix.SelectOption{Label: fmt.Sprintf("Item %02d", i+1), Value: fmt.Sprintf("item-%02d", i+1)}
+	}
+	return options
+}
+
+func TestSelectPagination(t *testing.T) {
+	options := numberedOptions(50)
+
+	t.Run("window scrolls with the selection", func(t *testing.T) {
+		s := newSelectState(options, "", 5)
+		if start, end := s.page(); start != 0 || end != 5 {
+			t.Fatalf("expected first page [0,5), got [%d,%d)", start, end)
+		}
+		for i := 0; i < 7; i++ {
+			s.move(1)
+		}
+		if start, end := s.page(); start != 3 || end != 8 {
+			t.Fatalf("expected page [3,8) with cursor at 7, got [%d,%d)", start, end)
+		}
+		for i := 0; i < 5; i++ {
+			s.move(-1)
+		}
+		if start, _ := s.page(); start != 2 {
+			t.Fatalf("expected page to start at the cursor (2), got %d", start)
+		}
+	})
+
+	t.Run("selection wraps to the last page", func(t *testing.T) {
+		s := newSelectState(options, "", 5)
+		s.move(-1)
+		if opt, _ := s.selected(); opt.Value != "item-50" {
+			t.Fatalf("expected wrap to item-50, got %q", opt.Value)
+		}
+		if start, end := s.page(); start != 45 || end != 50 {
+			t.Fatalf("expected last page [45,50), got [%d,%d)", start, end)
+		}
+		s.move(1)
+		if start, _ := s.page(); start != 0 {
+			t.Fatalf("expected wrap back to the first page, got start %d", start)
+		}
+	})
+
+	t.Run("render shows only the window and more markers", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		// Down six times puts item 7 at the bottom of a 5-option window
+		prompter := TerminalPrompter{In: bytes.NewBufferString(strings.Repeat("\x1b[B", 6) + "\r"), Out: out}
+		cfg := &clix.PromptConfig{Label: "Item", Theme: clix.DefaultPromptTheme, Options: options, PageSize: 5}
+		opt, err := prompter.promptSelectInteractive(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("select returned error: %v", err)
+		}
+		if opt.Value != "item-07" {
+			t.Fatalf("expected item-07, got %q", opt.Value)
+		}
+
+		renders := strings.Split(out.String(), "? Item")
+		last := renders[len(renders)-2]
+		for _, want := range []string{"↑ more", "Item 03", "> Item 07", "↓ more"} {
+			if !strings.Contains(last, want) {
+				t.Errorf("expected %q in final render, got %q", want, last)
+			}
+		}
+		for _, notWant := range []string{"Item 02", "Item 08"} {
+			if strings.Contains(last, notWant) {
+				t.Errorf("did not expect %q outside the window, got %q", notWant, last)
+			}
+		}
+		// Each redraw moves up over the 7 lines of the previous render
+		// (label, two markers and a five-option window)
+		if !strings.Contains(out.String(), "\033[7A") {
+			t.Errorf("expected cursor to move up by the window height, got %q", out.String())
+		}
+	})
+}
+
+func TestTerminalPrompterSelectOne(t *testing.T) {
+	options := []clix.SelectOption{
+		{Label: "Small", Value: "s", Description: "1 vCPU"},
+		{Label: "Large", Value: "l", Description: "8 vCPU"},
+	}
+
+	t.Run("line-based input", func(t *testing.T) {
+		prompter := TerminalPrompter{In: bytes.NewBufferString("2\n"), Out: &bytes.Buffer{}}
+		got, err := prompter.SelectOne(context.Background(), clix.PromptRequest{Label: "Size", Options: options})
+		if err != nil {
+			t.Fatalf("SelectOne: %v", err)
+		}
+		if got != options[1] {
+			t.Errorf("SelectOne = %+v, want %+v", got, options[1])
+		}
+	})
+
+	t.Run("through clix.SelectOne", func(t *testing.T) {
+		var p clix.Prompter = TerminalPrompter{In: bytes.NewBufferString("small\n"), Out: &bytes.Buffer{}}
+		got, err := clix.SelectOne(context.Background(), p, clix.WithLabel("Size"), Select(options))
+		if err != nil {
+			t.Fatalf("SelectOne: %v", err)
+		}
+		if got.Label != "Small" || got.Description != "1 vCPU" {
+			t.Errorf("SelectOne = %+v, want the Small option", got)
+		}
+	})
+
+	t.Run("interactive selection", func(t *testing.T) {
+		prompter := TerminalPrompter{In: bytes.NewBufferString("\x1b[B\r"), Out: &bytes.Buffer{}}
+		cfg := &clix.PromptConfig{Label: "Size", Theme: clix.DefaultPromptTheme, Options: options}
+		got, err := prompter.promptSelectInteractive(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if got != options[1] {
+			t.Errorf("select = %+v, want %+v", got, options[1])
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package prompt
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+// completer implements Tab completion against PromptConfig.Suggest. The first
+// Tab completes to the longest prefix shared by the matching candidates; once
+// the input cannot grow any further, each Tab replaces it with the next
+// candidate. Any other key ends the cycle.
+type completer struct {
+	suggest    func(string) []string
+	candidates []string // candidates being cycled, nil when not cycling
+	index      int
+}
+
+func newCompleter(cfg *clix.PromptConfig) *completer {
+	return &completer{suggest: cfg.Suggest}
+}
+
+// matches returns the candidates for input that extend it.
+func (c *completer) matches(input string) []string {
+	if c == nil || c.suggest == nil {
+		return nil
+	}
+	var matches []string
+	for _, candidate := range c.suggest(input) {
+		if strings.HasPrefix(candidate, input) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// tab returns the input after pressing Tab, and false when there is nothing
+// to complete so the caller can fall back to the Default.
+func (c *completer) tab(input string) (string, bool) {
+	if c == nil || c.suggest == nil {
+		return input, false
+	}
+	if len(c.candidates) > 0 {
+		c.index = (c.index + 1) % len(c.candidates)
+		return c.candidates[c.index], true
+	}
+
+	matches := c.matches(input)
+	switch len(matches) {
+	case 0:
+		return input, false
+	case 1:
+		return matches[0], true
+	}
+	if prefix := commonPrefix(matches); len(prefix) > len(input) {
+		return prefix, true
+	}
+	c.candidates = matches
+	c.index = 0
+	return matches[0], true
+}
+
+// reset ends a Tab cycle.
+func (c *completer) reset() {
+	if c != nil {
+		c.candidates = nil
+	}
+}
+
+// ghost returns the rest of the first candidate extending input, for display
+// after the cursor.
+func (c *completer) ghost(input string) string {
+	if c == nil || input == "" || len(c.candidates) > 0 {
+		return ""
+	}
+	for _, candidate := range c.matches(input) {
+		if candidate != input {
+			return candidate[len(input):]
+		}
+	}
+	return ""
+}
+
+// commonPrefix returns the longest prefix shared by all values.
+func commonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,79 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+var regions = []string{"europe-west1", "europe-west4", "us-central1"}
+
+func suggestRegions(input string) []string {
+	return regions
+}
+
+// runSuggestKeys drives the interactive text loop with a Suggest provider.
+func runSuggestKeys(t *testing.T, keys string, theme clix.PromptTheme) (string, string) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	prompter := TerminalPrompter{In: bytes.NewBufferString(keys), Out: out}
+	cfg := &clix.PromptConfig{Label: "Region", Theme: theme, Suggest: suggestRegions}
+	value, err := prompter.promptTextInteractive(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("prompt returned error: %v", err)
+	}
+	return value, out.String()
+}
+
+func TestTextPromptSuggest(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want string
+	}{
+		{name: "tab completes the common prefix", keys: "eu\t\r", want: "europe-west"},
+		{name: "second tab starts cycling", keys: "eu\t\t\r", want: "europe-west1"},
+		{name: "third tab cycles to the next candidate", keys: "eu\t\t\t\r", want: "europe-west4"},
+		{name: "cycling wraps around", keys: "eu\t\t\t\t\r", want: "europe-west1"},
+		{name: "a single match completes fully", keys: "u\t\r", want: "us-central1"},
+		{name: "typing ends the cycle", keys: "eu\t\t-x\r", want: "europe-west1-x"},
+		{name: "no match leaves the input", keys: "asia\t\r", want: "asia"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := runSuggestKeys(t, tt.keys, clix.DefaultPromptTheme); got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextPromptSuggestGhostText(t *testing.T) {
+	theme := clix.DefaultPromptTheme
+	theme.SuggestionStyle = clix.StyleFunc(func(strs ...string) string {
+		return "<" + strings.Join(strs, "") + ">"
+	})
+	_, out := runSuggestKeys(t, "us\r", theme)
+	if !strings.Contains(out, "<-central1>") {
+		t.Errorf("expected ghost text styled with SuggestionStyle, got %q", out)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		in   []string
+		want string
+	}{
+		{in: []string{"europe-west1", "europe-west4"}, want: "europe-west"},
+		{in: []string{"café", "cafè"}, want: "caf"},
+		{in: []string{"a", "b"}, want: ""},
+		{in: []string{"only"}, want: "only"},
+	} {
+		if got := commonPrefix(tt.in); got != tt.want {
+			t.Errorf("commonPrefix(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
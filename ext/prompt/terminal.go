@@ -1,55 +1,112 @@
 package prompt
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"golang.org/x/term"
 )
 
+// Terminal access and signal redelivery are swapped out in tests.
+var (
+	isTTY           = term.IsTerminal
+	makeRaw         = term.MakeRaw
+	restoreTerminal = term.Restore
+	resignal        = func(sig os.Signal) {
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			p.Signal(sig)
+		}
+	}
+)
+
 // TerminalState manages raw terminal mode for interactive prompts.
 type TerminalState struct {
 	fd       int
 	oldState *term.State
-	restored bool
+	out      io.Writer
+	signals  chan os.Signal
+	done     chan struct{}
+	cancel   context.CancelFunc
+	once     sync.Once
+	err      error
 }
 
 // EnableRawMode enables raw terminal mode for reading individual keystrokes.
+// Until Restore is called, an interrupt or SIGTERM restores the terminal and
+// is then delivered again, so the process reacts to it as it would have
+// (exiting, or canceling App.RunWithSignals's context) and the shell is never
+// left without echo.
 func EnableRawMode(in *os.File) (*TerminalState, error) {
+	state, _, err := enableRawMode(nil, in, nil)
+	return state, err
+}
+
+// enableRawMode is EnableRawMode for prompters, additionally showing the
+// cursor on out when the terminal is restored; prompters hide it while
+// drawing menus. Given a ctx, a signal restores the terminal and cancels the
+// returned context instead of being delivered again, so the prompt returns
+// and its caller unwinds normally; App.RunWithSignals still sees the signal
+// through its own handler.
+func enableRawMode(ctx context.Context, in *os.File, out io.Writer) (*TerminalState, context.Context, error) {
 	fd := int(in.Fd())
-	oldState, err := term.MakeRaw(fd)
+	oldState, err := makeRaw(fd)
 	if err != nil {
-		return nil, err
+		return nil, ctx, err
 	}
 
 	state := &TerminalState{
 		fd:       fd,
 		oldState: oldState,
-		restored: false,
+		out:      out,
+		signals:  make(chan os.Signal, 1),
+		done:     make(chan struct{}),
+	}
+	if ctx != nil {
+		ctx, state.cancel = context.WithCancel(ctx)
 	}
 
-	// Ensure we restore on interrupt signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(state.signals, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		<-sigChan
-		state.Restore()
-		os.Exit(1)
+		select {
+		case sig := <-state.signals:
+			state.Restore()
+			if state.cancel == nil {
+				resignal(sig)
+			}
+		case <-state.done:
+		}
 	}()
 
-	return state, nil
+	return state, ctx, nil
 }
 
-// Restore restores the terminal to its previous state.
+// Restore restores the terminal to its previous state, shows the cursor,
+// removes the signal handler installed by EnableRawMode and cancels the
+// prompter's context, if any. It is safe to call more than once and from a
+// deferred function while a panic unwinds.
 func (ts *TerminalState) Restore() error {
-	if ts.restored || ts.oldState == nil {
+	if ts.oldState == nil {
 		return nil
 	}
-	ts.restored = true
-	return term.Restore(ts.fd, ts.oldState)
+	ts.once.Do(func() {
+		if ts.signals != nil {
+			signal.Stop(ts.signals)
+			close(ts.done)
+		}
+		if ts.cancel != nil {
+			ts.cancel()
+		}
+		if ts.out != nil {
+			ShowCursor(ts.out)
+		}
+		ts.err = restoreTerminal(ts.fd, ts.oldState)
+	})
+	return ts.err
 }
 
 // ReadKey reads a single keypress from the terminal, returning the key code
@@ -0,0 +1,149 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/SCKelemen/clix/v2"
+	"golang.org/x/term"
+)
+
+// fakeRawMode pretends fd-backed files are terminals and counts restores.
+func fakeRawMode(t *testing.T) *int {
+	t.Helper()
+	restores := new(int)
+	origTTY, origMakeRaw, origRestore := isTTY, makeRaw, restoreTerminal
+	isTTY = func(int) bool { return true }
+	makeRaw = func(int) (*term.State, error) { return &term.State{}, nil }
+	restoreTerminal = func(int, *term.State) error {
+		*restores++
+		return nil
+	}
+	t.Cleanup(func() {
+		isTTY, makeRaw, restoreTerminal = origTTY, origMakeRaw, origRestore
+	})
+	return restores
+}
+
+func TestRawModeRestoredOnPanic(t *testing.T) {
+	restores := fakeRawMode(t)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if _, err := w.WriteString("x\r"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	prompter := TerminalPrompter{In: r, Out: out}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the validator panic to propagate")
+			}
+		}()
+		prompter.Prompt(context.Background(),
+			clix.WithLabel("Name"),
+			clix.WithValidate(func(string) error { panic("boom") }),
+		)
+	}()
+
+	if *restores != 1 {
+		t.Errorf("terminal restored %d times, want 1", *restores)
+	}
+	if !strings.HasSuffix(out.String(), "\033[?25h") {
+		t.Errorf("cursor not shown after panic, output: %q", out.String())
+	}
+}
+
+// raise sends sig to the test process, where the raw-mode handler catches it.
+func raise(t *testing.T, sig os.Signal) {
+	t.Helper()
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(sig); err != nil {
+		t.Skipf("cannot signal the test process: %v", err)
+	}
+}
+
+func TestRawModeRestoredOnSignal(t *testing.T) {
+	restores := fakeRawMode(t)
+
+	delivered := make(chan os.Signal, 1)
+	origResignal := resignal
+	resignal = func(sig os.Signal) { delivered <- sig }
+	t.Cleanup(func() { resignal = origResignal })
+
+	state, err := EnableRawMode(os.Stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raise(t, syscall.SIGINT)
+
+	if sig := <-delivered; sig != syscall.SIGINT {
+		t.Errorf("redelivered %v, want SIGINT", sig)
+	}
+	if *restores != 1 {
+		t.Errorf("restores = %d, want 1", *restores)
+	}
+	state.Restore()
+	if *restores != 1 {
+		t.Errorf("second Restore touched the terminal again")
+	}
+}
+
+func TestRawModePromptCanceledOnSignal(t *testing.T) {
+	restores := fakeRawMode(t)
+
+	origResignal := resignal
+	resignal = func(os.Signal) { t.Error("prompter signal was delivered again") }
+	t.Cleanup(func() { resignal = origResignal })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	// Catch the signals raised before the prompt installs its handler, which
+	// would otherwise terminate the test binary.
+	guard := make(chan os.Signal, 16)
+	signal.Notify(guard, syscall.SIGTERM)
+	defer signal.Stop(guard)
+
+	done := make(chan error, 1)
+	prompter := TerminalPrompter{In: r, Out: &bytes.Buffer{}}
+	go func() {
+		_, err := prompter.Prompt(context.Background(), clix.WithLabel("Name"))
+		done <- err
+	}()
+	for range 50 {
+		raise(t, syscall.SIGTERM)
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Prompt error = %v, want context.Canceled", err)
+			}
+			if *restores != 1 {
+				t.Errorf("restores = %d, want 1", *restores)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+	t.Fatal("prompt did not return after the signal")
+}
@@ -3,6 +3,7 @@ package survey
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -20,6 +21,10 @@ const NoDefaultPlaceholder = "press enter for default"
 // ErrGoBack signals the survey should return to the previous question.
 var ErrGoBack = errors.New("survey: go back to previous question")
 
+// ErrAnswerRequired is the validation error shown when a Required question
+// receives an empty answer.
+var ErrAnswerRequired = errors.New("an answer is required")
+
 // Question represents a single prompt in a survey.
 // Questions can be defined as struct literals, similar to clix.Command.
 //
@@ -63,6 +68,26 @@ type Question struct {
 	// Empty string "" means "always continue to this action" (default branch).
 	// Use helper functions like PushQuestion(), End(), or Handler() to create branches.
 	Branches map[string]Branch
+
+	// Skip, if set, is called with the answers so far (keyed by question ID)
+	// when the question comes up. If it returns true the question is not
+	// asked: SkipValue is recorded as its answer and the default branch ("")
+	// is followed.
+	//
+	// Example:
+	//
+	//	Skip: func(answers map[string]string) bool {
+	//		return answers["employed"] != "yes"
+	//	},
+	Skip func(answers map[string]string) bool
+
+	// SkipValue is the answer recorded when Skip returns true. Defaults to "".
+	SkipValue string
+
+	// Required re-asks the question when the answer is empty (after applying
+	// the request's Default), showing ErrAnswerRequired. It runs before and
+	// independently of Request.Validate.
+	Required bool
 }
 
 // Branch defines what happens after a question is answered.
@@ -166,6 +191,7 @@ type Survey struct {
 	reader         *bufio.Reader        // Shared reader to avoid bufio buffering issues
 	originalFile   *os.File             // Original *os.File if available (for TerminalPrompter raw mode)
 	isTextPrompter bool                 // Whether we're using TextPrompter (needs shared reader)
+	imported       map[string]string    // Answers loaded by ImportJSON, consumed by Run
 
 	// Undo/back functionality
 	withUndoStack bool
@@ -175,12 +201,16 @@ type Survey struct {
 	withEndCard  bool
 	endCardText  string
 	endCardTheme clix.PromptTheme // Theme for end card display
+
+	// Progress indicator
+	withProgress bool
 }
 
 // historyEntry tracks a question and its answer for undo functionality.
 type historyEntry struct {
 	question *Question
 	answer   string
+	skipped  bool // recorded by Question.Skip; going back passes over it
 }
 
 // SurveyOption configures survey behavior.
@@ -216,6 +246,20 @@ func (o undoStackOption) Apply(s *Survey) {
 	s.history = make([]historyEntry, 0)
 }
 
+// WithProgress writes a "Question 3 of 8" line before each prompt, styled
+// with the question theme's HintStyle. The total counts the questions already
+// answered plus those still reachable through static branches; when handlers,
+// loops or dynamic questions make it unknowable, only "Question 3" is shown.
+func WithProgress() SurveyOption {
+	return progressOption{}
+}
+
+type progressOption struct{}
+
+func (o progressOption) Apply(s *Survey) {
+	s.withProgress = true
+}
+
 // WithEndCard enables a confirmation prompt after the survey completes.
 // The end card shows a formatted summary of all answers with styling support.
 // Users can confirm they're satisfied with their answers, or go back to edit (if WithUndoStack is enabled).
@@ -406,6 +450,14 @@ func (s *Survey) pushQuestion(q *Question) {
 // handleGoBack restores the previous question when triggered by Escape/F12 key bindings.
 // The optional current question is re-queued if no history exists.
 func (s *Survey) handleGoBack(current *Question) {
+	// Skipped questions were never shown, so drop them on the way back;
+	// they are re-evaluated when the flow reaches them again.
+	for len(s.history) > 0 && s.history[len(s.history)-1].skipped {
+		s.history = s.history[:len(s.history)-1]
+		s.answers = s.answers[:len(s.answers)-1]
+		s.questionIDs = s.questionIDs[:len(s.questionIDs)-1]
+	}
+
 	if len(s.history) > 0 {
 		lastEntry := s.history[len(s.history)-1]
 		s.history = s.history[:len(s.history)-1]
@@ -449,16 +501,39 @@ func (s *Survey) Run() error {
 		question = s.stack[idx]
 		s.stack = s.stack[:idx]
 
+		// Questions answered in an imported session are replayed without prompting
+		if answer, ok := s.imported[question.ID]; ok {
+			delete(s.imported, question.ID)
+			s.record(question, answer)
+			continue
+		}
+
+		if question.Skip != nil && question.Skip(s.AnswersMap()) {
+			s.save(question, question.SkipValue, true)
+			if branch := question.Branches[""]; branch != nil {
+				branch.Execute(question.SkipValue, s)
+			}
+			continue
+		}
+
 		// Ensure theme is set if not already provided
 		req := question.Request
 		if req.NoDefaultPlaceholder == "" {
 			req.NoDefaultPlaceholder = NoDefaultPlaceholder
 		}
 
+		if question.Required {
+			req.Validate = requireAnswer(req.Validate)
+		}
+
 		if req.Theme.Prefix == "" && req.Theme.Error == "" && req.Theme.PrefixStyle == nil {
 			req.Theme = clix.DefaultPromptTheme
 		}
 
+		if s.withProgress {
+			s.renderProgress(question, req.Theme)
+		}
+
 		// Determine if this is the last question (stack will be empty after this)
 		// Check if there are more questions in the stack or if branches will add more
 		isLastQuestion := len(s.stack) == 0
@@ -573,36 +648,120 @@ func (s *Survey) Run() error {
 			return fmt.Errorf("prompt failed: %w", err)
 		}
 
-		// Save answer and question ID
-		s.answers = append(s.answers, answer)
-		s.questionIDs = append(s.questionIDs, question.ID)
+		s.record(question, answer)
+	}
 
-		// If undo is enabled, save to history before executing branch
-		// This happens even if branch might clear the stack - we need history for undo
-		if s.withUndoStack {
-			s.history = append(s.history, historyEntry{
-				question: question,
-				answer:   answer,
-			})
+	// Show end card if enabled
+	if s.withEndCard {
+		return s.showEndCard()
+	}
+
+	return nil
+}
+
+// requireAnswer wraps validate so empty answers are rejected first.
+func requireAnswer(validate func(string) error) func(string) error {
+	return func(answer string) error {
+		if strings.TrimSpace(answer) == "" {
+			return ErrAnswerRequired
 		}
+		if validate != nil {
+			return validate(answer)
+		}
+		return nil
+	}
+}
 
-		// Execute branch based on answer
-		// First try exact match, then fallback to empty string (always branch)
-		branch, ok := question.Branches[answer]
-		if !ok {
-			branch, ok = question.Branches[""]
+// renderProgress writes the progress line for the question about to be asked.
+func (s *Survey) renderProgress(current *Question, theme clix.PromptTheme) {
+	out := s.getOut()
+	if out == nil {
+		return
+	}
+	text := fmt.Sprintf("Question %d", len(s.answers)+1)
+	if total := s.progressTotal(current); total > 0 {
+		text = fmt.Sprintf("Question %d of %d", len(s.answers)+1, total)
+	}
+	fmt.Fprintln(out, renderText(theme.HintStyle, text))
+}
+
+// progressTotal returns the answered count plus the number of distinct
+// questions reachable from current and the stack, or 0 if the flow cannot be
+// determined statically.
+func (s *Survey) progressTotal(current *Question) int {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*Question]int)
+	count := 0
+
+	var visit func(q *Question) bool
+	visit = func(q *Question) bool {
+		switch state[q] {
+		case visiting:
+			return false // loop
+		case visited:
+			return true
+		}
+		if registered, ok := s.questions[q.ID]; !ok || registered != q {
+			return false // added by Ask
 		}
-		if ok && branch != nil {
-			branch.Execute(answer, s)
+		state[q] = visiting
+		count++
+		for _, branch := range q.Branches {
+			switch b := branch.(type) {
+			case nil, EndBranch:
+			case QuestionBranch:
+				if next, ok := s.questions[b.QuestionID]; ok && !visit(next) {
+					return false
+				}
+			default:
+				return false // handlers can ask anything
+			}
 		}
+		state[q] = visited
+		return true
 	}
 
-	// Show end card if enabled
-	if s.withEndCard {
-		return s.showEndCard()
+	for _, q := range append([]*Question{current}, s.stack...) {
+		if !visit(q) {
+			return 0
+		}
 	}
+	return len(s.answers) + count
+}
 
-	return nil
+// save records the answer to question, along with its undo history entry.
+func (s *Survey) save(question *Question, answer string, skipped bool) {
+	// Save answer and question ID
+	s.answers = append(s.answers, answer)
+	s.questionIDs = append(s.questionIDs, question.ID)
+
+	// If undo is enabled, save to history before executing branch
+	// This happens even if branch might clear the stack - we need history for undo
+	if s.withUndoStack {
+		s.history = append(s.history, historyEntry{
+			question: question,
+			answer:   answer,
+			skipped:  skipped,
+		})
+	}
+}
+
+// record saves the answer to question and executes the matching branch.
+func (s *Survey) record(question *Question, answer string) {
+	s.save(question, answer, false)
+
+	// Execute branch based on answer
+	// First try exact match, then fallback to empty string (always branch)
+	branch, ok := question.Branches[answer]
+	if !ok {
+		branch, ok = question.Branches[""]
+	}
+	if ok && branch != nil {
+		branch.Execute(answer, s)
+	}
 }
 
 // showEndCard displays an end card with a formatted summary of answers,
@@ -829,6 +988,60 @@ func (s *Survey) Answers() []string {
 	return s.answers
 }
 
+// AnswersMap returns the collected answers keyed by question ID. When a
+// question was asked more than once (e.g. in a loop), the latest answer wins.
+// Imported answers that Run has not reached yet are included.
+func (s *Survey) AnswersMap() map[string]string {
+	answers := make(map[string]string, len(s.answers)+len(s.imported))
+	for id, answer := range s.imported {
+		answers[id] = answer
+	}
+	for i, answer := range s.answers {
+		if i < len(s.questionIDs) {
+			answers[s.questionIDs[i]] = answer
+		}
+	}
+	return answers
+}
+
+// ExportJSON writes AnswersMap to w as a JSON object, so an interrupted
+// survey can be saved and resumed later with ImportJSON.
+func (s *Survey) ExportJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s.AnswersMap())
+}
+
+// ImportJSON reads answers written by ExportJSON. When Run reaches a question
+// whose ID has an imported answer, it records that answer and follows its
+// branch without prompting. Each imported answer is used once, so questions
+// asked repeatedly in a loop prompt again after the first time.
+//
+// Example:
+//
+//	if f, err := os.Open("answers.json"); err == nil {
+//		defer f.Close()
+//		if err := s.ImportJSON(f); err != nil {
+//			return err
+//		}
+//	}
+//	if err := s.Run(); err != nil {
+//		return err
+//	}
+func (s *Survey) ImportJSON(r io.Reader) error {
+	var answers map[string]string
+	if err := json.NewDecoder(r).Decode(&answers); err != nil {
+		return fmt.Errorf("import answers: %w", err)
+	}
+	if s.imported == nil {
+		s.imported = make(map[string]string, len(answers))
+	}
+	for id, answer := range answers {
+		s.imported[id] = answer
+	}
+	return nil
+}
+
 // Clear removes all remaining questions from the survey.
 func (s *Survey) Clear() {
 	s.stack = s.stack[:0]
@@ -0,0 +1,90 @@
+package survey
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func profileQuestions() []Question {
+	return []Question{
+		{
+			ID:       "name",
+			Request:  clix.PromptRequest{Label: "Name"},
+			Branches: map[string]Branch{"": PushQuestion("email")},
+		},
+		{
+			ID:       "email",
+			Request:  clix.PromptRequest{Label: "Email"},
+			Branches: map[string]Branch{"": PushQuestion("role")},
+		},
+		{
+			ID:       "role",
+			Request:  clix.PromptRequest{Label: "Role"},
+			Branches: map[string]Branch{"": End()},
+		},
+	}
+}
+
+func TestSurveyJSON(t *testing.T) {
+	t.Run("round-trips answers", func(t *testing.T) {
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("Ada\nada@example.com\nadmin\n"), Out: &bytes.Buffer{}}
+		s := NewFromQuestions(context.Background(), prompter, profileQuestions(), "name")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		var saved bytes.Buffer
+		if err := s.ExportJSON(&saved); err != nil {
+			t.Fatalf("ExportJSON returned error: %v", err)
+		}
+
+		restored := New(context.Background(), prompter)
+		if err := restored.ImportJSON(&saved); err != nil {
+			t.Fatalf("ImportJSON returned error: %v", err)
+		}
+		got := restored.AnswersMap()
+		want := map[string]string{"name": "Ada", "email": "ada@example.com", "role": "admin"}
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for id, answer := range want {
+			if got[id] != answer {
+				t.Errorf("expected %s=%q, got %q", id, answer, got[id])
+			}
+		}
+	})
+
+	t.Run("resumes a partially completed survey", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("admin\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, profileQuestions(), "name")
+		if err := s.ImportJSON(strings.NewReader(`{"name": "Ada", "email": "ada@example.com"}`)); err != nil {
+			t.Fatalf("ImportJSON returned error: %v", err)
+		}
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		answers := s.Answers()
+		if len(answers) != 3 || answers[0] != "Ada" || answers[1] != "ada@example.com" || answers[2] != "admin" {
+			t.Fatalf("expected [Ada ada@example.com admin], got %v", answers)
+		}
+		if strings.Contains(out.String(), "Name") || strings.Contains(out.String(), "Email") {
+			t.Errorf("expected imported questions to be skipped, got %q", out.String())
+		}
+		if !strings.Contains(out.String(), "Role") {
+			t.Errorf("expected the remaining question to be asked, got %q", out.String())
+		}
+	})
+
+	t.Run("rejects malformed input", func(t *testing.T) {
+		s := New(context.Background(), clix.TextPrompter{In: &bytes.Buffer{}, Out: &bytes.Buffer{}})
+		if err := s.ImportJSON(strings.NewReader(`["Ada"]`)); err == nil {
+			t.Fatal("expected an error for a JSON array")
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package survey
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func TestSurveyProgress(t *testing.T) {
+	t.Run("static survey shows answered over total", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("Ada\nada@example.com\nadmin\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, profileQuestions(), "name", WithProgress())
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		for _, want := range []string{"Question 1 of 3\n", "Question 2 of 3\n", "Question 3 of 3\n"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("expected %q in output, got %q", want, out.String())
+			}
+		}
+		if strings.Index(out.String(), "Question 1 of 3") > strings.Index(out.String(), "Name") {
+			t.Errorf("expected progress to be written before the prompt, got %q", out.String())
+		}
+	})
+
+	t.Run("dynamic survey shows only the index", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("Ada\n30\n"), Out: out}
+		s := New(context.Background(), prompter, WithProgress())
+		s.Ask(clix.PromptRequest{Label: "Name"}, func(answer string, s *Survey) {
+			s.Ask(clix.PromptRequest{Label: "Age"}, nil)
+		})
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		if !strings.Contains(out.String(), "Question 1\n") || !strings.Contains(out.String(), "Question 2\n") {
+			t.Errorf("expected index-only progress, got %q", out.String())
+		}
+		if strings.Contains(out.String(), " of ") {
+			t.Errorf("expected no total for a dynamic survey, got %q", out.String())
+		}
+	})
+
+	t.Run("uses the theme hint style", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		theme := clix.DefaultPromptTheme
+		theme.HintStyle = clix.StyleFunc(func(s ...string) string { return "<" + strings.Join(s, "") + ">" })
+		questions := []Question{{
+			ID:       "name",
+			Request:  clix.PromptRequest{Label: "Name", Theme: theme},
+			Branches: map[string]Branch{"": End()},
+		}}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("Ada\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, questions, "name", WithProgress())
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+		if !strings.Contains(out.String(), "<Question 1 of 1>") {
+			t.Errorf("expected styled progress line, got %q", out.String())
+		}
+	})
+}
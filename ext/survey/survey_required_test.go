@@ -0,0 +1,106 @@
+package survey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func requiredQuestions(validate func(string) error) []Question {
+	return []Question{
+		{
+			ID:       "name",
+			Request:  clix.PromptRequest{Label: "Name", Validate: validate},
+			Required: true,
+			Branches: map[string]Branch{"": PushQuestion("nickname")},
+		},
+		{
+			ID:       "nickname",
+			Request:  clix.PromptRequest{Label: "Nickname"},
+			Branches: map[string]Branch{"": End()},
+		},
+	}
+}
+
+func TestSurveyRequired(t *testing.T) {
+	t.Run("empty answer re-asks", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("\n  \nAda\n\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, requiredQuestions(nil), "name")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		answers := s.Answers()
+		if len(answers) != 2 || answers[0] != "Ada" || answers[1] != "" {
+			t.Fatalf("expected [Ada \"\"], got %q", answers)
+		}
+		if got := strings.Count(out.String(), ErrAnswerRequired.Error()); got != 2 {
+			t.Errorf("expected two required errors, got %d in %q", got, out.String())
+		}
+	})
+
+	t.Run("non-empty answer advances", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("Ada\nAddie\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, requiredQuestions(nil), "name")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		if got := s.AnswersMap(); got["name"] != "Ada" || got["nickname"] != "Addie" {
+			t.Fatalf("expected name=Ada nickname=Addie, got %v", got)
+		}
+		if strings.Contains(out.String(), ErrAnswerRequired.Error()) {
+			t.Errorf("expected no required error, got %q", out.String())
+		}
+	})
+
+	t.Run("validate still runs", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		validate := func(s string) error {
+			if len(s) < 2 {
+				return errors.New("too short")
+			}
+			return nil
+		}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("A\nAda\n\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, requiredQuestions(validate), "name")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+		if !strings.Contains(out.String(), "too short") {
+			t.Errorf("expected Validate error, got %q", out.String())
+		}
+	})
+
+	t.Run("going back still works", func(t *testing.T) {
+		questions := []Question{
+			{
+				ID:       "first",
+				Request:  clix.PromptRequest{Label: "First"},
+				Branches: map[string]Branch{"": PushQuestion("second")},
+			},
+			{
+				ID:       "second",
+				Request:  clix.PromptRequest{Label: "Second"},
+				Required: true,
+				Branches: map[string]Branch{"": End()},
+			},
+		}
+		prompter := &mockPrompterWithEscape{answers: []string{"a", "b", "c"}, escapeAt: 2, out: &bytes.Buffer{}}
+		s := NewFromQuestions(context.Background(), prompter, questions, "first", WithUndoStack())
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		answers := s.Answers()
+		if len(answers) != 2 || answers[0] != "b" || answers[1] != "c" {
+			t.Fatalf("expected the first question to be re-asked, got %q", answers)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package survey
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+	"github.com/SCKelemen/clix/v2/ext/validation"
+)
+
+// demoQuestions mirrors the simple survey in examples/survey.
+func demoQuestions() []Question {
+	return []Question{
+		{
+			ID: "name",
+			Request: clix.PromptRequest{
+				Label:    "What is your name?",
+				Validate: validation.All(validation.NotEmpty, validation.MinLength(2)),
+			},
+			Branches: map[string]Branch{"": PushQuestion("email")},
+		},
+		{
+			ID: "email",
+			Request: clix.PromptRequest{
+				Label:    "What is your email?",
+				Validate: validation.Email,
+			},
+			Branches: map[string]Branch{"": PushQuestion("country")},
+		},
+		{
+			ID: "country",
+			Request: clix.PromptRequest{
+				Label:   "What country are you from?",
+				Default: "United States",
+			},
+			Branches: map[string]Branch{"": PushQuestion("newsletter")},
+		},
+		{
+			ID: "newsletter",
+			Request: clix.PromptRequest{
+				Label:   "Would you like to subscribe to our newsletter?",
+				Confirm: true,
+			},
+			Branches: map[string]Branch{"y": End(), "n": End(), "": End()},
+		},
+	}
+}
+
+func TestSurveyWithScriptedPrompter(t *testing.T) {
+	p := clix.NewScriptedPrompter(
+		clix.Answer("What is your name?", "A"), // rejected by MinLength(2)
+		clix.Answer("What is your name?", "Ada"),
+		clix.Answer("What is your email?", "ada@example.com"),
+		clix.Answer("What country are you from?", ""),
+		clix.Answer("Would you like to subscribe to our newsletter?", "y"),
+		clix.Answer("Survey complete. Are you satisfied with your answers?", "yes"),
+	)
+
+	s := NewFromQuestions(context.Background(), p, demoQuestions(), "name", WithUndoStack(), WithEndCard())
+	if err := s.Run(); err != nil {
+		t.Fatalf("survey failed: %v", err)
+	}
+
+	want := map[string]string{
+		"name":       "Ada",
+		"email":      "ada@example.com",
+		"country":    "United States",
+		"newsletter": "y",
+	}
+	if got := s.AnswersMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("answers = %v, want %v", got, want)
+	}
+	if p.Remaining() != 0 {
+		t.Errorf("%d scripted responses unused", p.Remaining())
+	}
+	if len(p.Requests()) != 5 {
+		t.Errorf("prompts asked = %d, want 5", len(p.Requests()))
+	}
+	if !strings.Contains(p.Output(), "ada@example.com") {
+		t.Errorf("summary missing from output:\n%s", p.Output())
+	}
+}
+
+func TestSurveyWithScriptedPrompterGoesBack(t *testing.T) {
+	p := clix.NewScriptedPrompter(
+		clix.Answer("What is your name?", "Ada"),
+		clix.Answer("What is your email?", "ada@example.com"),
+		clix.Answer("What country are you from?", "Norway"),
+		clix.Answer("Would you like to subscribe to our newsletter?", "y"),
+		clix.Answer("Survey complete. Are you satisfied with your answers?", "n"),
+		clix.Answer("Would you like to subscribe to our newsletter?", "n"),
+		clix.Answer("Survey complete. Are you satisfied with your answers?", "y"),
+	)
+
+	s := NewFromQuestions(context.Background(), p, demoQuestions(), "name", WithUndoStack(), WithEndCard())
+	if err := s.Run(); err != nil {
+		t.Fatalf("survey failed: %v", err)
+	}
+	if got := s.AnswersMap()["newsletter"]; got != "n" {
+		t.Errorf("newsletter = %q, want the revised answer n", got)
+	}
+}
+
+func TestSurveyWithScriptedPrompterUnexpectedQuestion(t *testing.T) {
+	p := clix.NewScriptedPrompter(
+		clix.Answer("What is your name?", "Ada"),
+		clix.Answer("What country are you from?", "Norway"),
+	)
+	s := NewFromQuestions(context.Background(), p, demoQuestions(), "name")
+	if err := s.Run(); err == nil || !strings.Contains(err.Error(), "What is your email?") {
+		t.Fatalf("error = %v, want the unexpected email prompt reported", err)
+	}
+}
@@ -0,0 +1,91 @@
+package survey
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+)
+
+func employmentQuestions() []Question {
+	return []Question{
+		{
+			ID:       "employed",
+			Request:  clix.PromptRequest{Label: "Employed"},
+			Branches: map[string]Branch{"": PushQuestion("company")},
+		},
+		{
+			ID:      "company",
+			Request: clix.PromptRequest{Label: "Company"},
+			Skip: func(answers map[string]string) bool {
+				return answers["employed"] != "yes"
+			},
+			SkipValue: "n/a",
+			Branches:  map[string]Branch{"": PushQuestion("city")},
+		},
+		{
+			ID:       "city",
+			Request:  clix.PromptRequest{Label: "City"},
+			Branches: map[string]Branch{"": End()},
+		},
+	}
+}
+
+func TestSurveySkip(t *testing.T) {
+	t.Run("skip condition bypasses the question", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("no\nParis\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, employmentQuestions(), "employed")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		answers := s.AnswersMap()
+		if answers["company"] != "n/a" {
+			t.Errorf("expected skipped question to record SkipValue, got %q", answers["company"])
+		}
+		if answers["city"] != "Paris" {
+			t.Errorf("expected the default branch to be followed, got city=%q", answers["city"])
+		}
+		if strings.Contains(out.String(), "Company") {
+			t.Errorf("expected Company not to be asked, got %q", out.String())
+		}
+	})
+
+	t.Run("question is asked when skip returns false", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := clix.TextPrompter{In: bytes.NewBufferString("yes\nAcme\nParis\n"), Out: out}
+		s := NewFromQuestions(context.Background(), prompter, employmentQuestions(), "employed")
+		if err := s.Run(); err != nil {
+			t.Fatalf("survey failed: %v", err)
+		}
+
+		answers := s.AnswersMap()
+		if answers["company"] != "Acme" || answers["city"] != "Paris" {
+			t.Errorf("expected company=Acme city=Paris, got %v", answers)
+		}
+		if !strings.Contains(out.String(), "Company") {
+			t.Errorf("expected Company to be asked, got %q", out.String())
+		}
+	})
+
+	t.Run("going back passes over skipped questions", func(t *testing.T) {
+		s := NewFromQuestions(context.Background(), clix.TextPrompter{In: &bytes.Buffer{}, Out: &bytes.Buffer{}},
+			employmentQuestions(), "employed", WithUndoStack())
+		// State after answering employed=no and skipping company, while City is shown
+		s.stack = s.stack[:0]
+		s.save(s.questions["employed"], "no", false)
+		s.save(s.questions["company"], "n/a", true)
+
+		s.handleGoBack(s.questions["city"])
+
+		if len(s.stack) != 1 || s.stack[0].ID != "employed" {
+			t.Fatalf("expected to return to employed, got stack %v", s.stack)
+		}
+		if len(s.answers) != 0 {
+			t.Errorf("expected answers to be unwound, got %v", s.answers)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error is the error returned by the built-in validators. It lets callers
+// tell validation failures apart from other errors and surface hints:
+//
+//	if err := validate(value); err != nil {
+//		var verr *validation.Error
+//		if errors.As(err, &verr) && len(verr.Suggestions) > 0 {
+//			fmt.Fprintf(os.Stderr, "did you mean %s?\n", verr.Suggestions[0])
+//		}
+//	}
+type Error struct {
+	// Field names the value being validated (e.g., a flag or prompt name).
+	// Built-in validators leave it empty; use WithField to set it.
+	Field string
+
+	// Message describes why the value was rejected.
+	Message string
+
+	// Suggestions are replacement values the user may have meant.
+	Suggestions []string
+
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+// Error implements error. The message is prefixed with the field name when set.
+func (e *Error) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf formats a message like fmt.Errorf and returns it as an *Error.
+// A %w verb records the wrapped error as Err.
+func Errorf(format string, args ...any) *Error {
+	err := fmt.Errorf(format, args...)
+	return &Error{Message: err.Error(), Err: errors.Unwrap(err)}
+}
+
+// WithField returns a validator that reports failures from v against field.
+// Errors that are not an *Error are wrapped in one.
+func WithField(field string, v Validator) Validator {
+	return func(value string) error {
+		err := v(value)
+		if err == nil {
+			return nil
+		}
+		var verr *Error
+		if !errors.As(err, &verr) {
+			return &Error{Field: field, Message: err.Error(), Err: err}
+		}
+		copied := *verr
+		copied.Field = field
+		return &copied
+	}
+}
@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBuiltinValidatorsReturnError(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+	}{
+		{"Email", Email, "not-an-email"},
+		{"URL", URL, "example.com"},
+		{"IPv4", IPv4, "999.0.0.1"},
+		{"Port", Port, "0"},
+		{"MinLength", MinLength(5), "abc"},
+		{"OneOf", OneOf("a", "b"), "c"},
+		{"SemVer", SemVer, "1.2"},
+		{"IntRange", IntRange(1, 10), "11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator(tt.value)
+			var verr *Error
+			if !errors.As(err, &verr) {
+				t.Fatalf("%s(%q) error = %v (%T), want *Error", tt.name, tt.value, err, err)
+			}
+			if verr.Message == "" {
+				t.Errorf("%s(%q) returned an empty message", tt.name, tt.value)
+			}
+		})
+	}
+}
+
+func TestErrorSuggestions(t *testing.T) {
+	var verr *Error
+	validate := OneOfFold("json", "yaml")
+	if !errors.As(validate("xml"), &verr) {
+		t.Fatal("expected *Error from OneOfFold")
+	}
+	if want := []string{"json", "yaml"}; !reflect.DeepEqual(verr.Suggestions, want) {
+		t.Errorf("OneOfFold suggestions = %v, want %v", verr.Suggestions, want)
+	}
+
+	if !errors.As(URL("example.com/path"), &verr) {
+		t.Fatal("expected *Error from URL")
+	}
+	if want := []string{"https://example.com/path"}; !reflect.DeepEqual(verr.Suggestions, want) {
+		t.Errorf("URL suggestions = %v, want %v", verr.Suggestions, want)
+	}
+}
+
+func TestErrorfWrapsCause(t *testing.T) {
+	err := URL("http://[::1")
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Fatalf("expected URL error to wrap *url.Error, got %v", err)
+	}
+}
+
+func TestWithField(t *testing.T) {
+	t.Run("sets field on structured errors", func(t *testing.T) {
+		err := WithField("email", Email)("nope")
+		var verr *Error
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+		if verr.Field != "email" {
+			t.Errorf("Field = %q, want %q", verr.Field, "email")
+		}
+		if want := "email: invalid email address"; err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+	})
+
+	t.Run("wraps plain errors", func(t *testing.T) {
+		plain := errors.New("not allowed")
+		err := WithField("name", func(string) error { return plain })("x")
+		var verr *Error
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+		if verr.Field != "name" || verr.Message != "not allowed" {
+			t.Errorf("got Field=%q Message=%q", verr.Field, verr.Message)
+		}
+		if !errors.Is(err, plain) {
+			t.Error("expected wrapped error to match with errors.Is")
+		}
+	})
+
+	t.Run("passes valid values", func(t *testing.T) {
+		if err := WithField("email", Email)("user@example.com"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestCombinatorsPropagateStructuredError(t *testing.T) {
+	plain := func(string) error { return errors.New("plain failure") }
+
+	t.Run("All returns the first failure", func(t *testing.T) {
+		err := All(NotEmpty, OneOf("a", "b"), plain)("c")
+		var verr *Error
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+		if verr.Message != "must be one of: a, b" {
+			t.Errorf("Message = %q, want OneOf message", verr.Message)
+		}
+	})
+
+	t.Run("Any returns the first structured failure", func(t *testing.T) {
+		err := Any(plain, IPv4, IPv6)("not-an-ip")
+		var verr *Error
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected *Error, got %T", err)
+		}
+		if verr.Message != "invalid IPv4 address" {
+			t.Errorf("Message = %q, want IPv4 message", verr.Message)
+		}
+	})
+
+	t.Run("Any falls back to the last plain error", func(t *testing.T) {
+		err := Any(plain, plain)("x")
+		if err == nil || err.Error() != "plain failure" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
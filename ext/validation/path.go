@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// PathExists validates that a file or directory exists at the path.
+func PathExists(value string) error {
+	_, err := statPath(value)
+	return err
+}
+
+// IsDir validates that the path exists and is a directory.
+func IsDir(value string) error {
+	info, err := statPath(value)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return Errorf("not a directory: %s", value)
+	}
+	return nil
+}
+
+// IsFile validates that the path exists and is a regular file.
+func IsFile(value string) error {
+	info, err := statPath(value)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return Errorf("is a directory, not a file: %s", value)
+	}
+	if !info.Mode().IsRegular() {
+		return Errorf("not a regular file: %s", value)
+	}
+	return nil
+}
+
+// Writable validates that the path can be written to. An existing file must
+// open for writing and an existing directory must accept new files; a path
+// that does not exist yet is writable when its parent directory is, so the
+// validator suits output locations an installer is about to create.
+func Writable(value string) error {
+	if value == "" {
+		return Errorf("path cannot be empty")
+	}
+
+	info, err := os.Stat(value)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		parent := filepath.Dir(value)
+		if err := IsDir(parent); err != nil {
+			return Errorf("parent directory does not exist: %s", parent)
+		}
+		return writableDir(parent)
+	case err != nil:
+		return Errorf("cannot access path: %w", err)
+	case info.IsDir():
+		return writableDir(value)
+	}
+
+	f, err := os.OpenFile(value, os.O_WRONLY, 0)
+	if err != nil {
+		return Errorf("path is not writable: %s", value)
+	}
+	return f.Close()
+}
+
+func statPath(value string) (fs.FileInfo, error) {
+	if value == "" {
+		return nil, Errorf("path cannot be empty")
+	}
+	info, err := os.Stat(value)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, Errorf("path does not exist: %s", value)
+	}
+	if err != nil {
+		return nil, Errorf("cannot access path: %w", err)
+	}
+	return info, nil
+}
+
+// writableDir probes dir by creating and removing a temporary file, which
+// reflects ACLs and read-only mounts that permission bits alone miss.
+func writableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".clix-writable-*")
+	if err != nil {
+		return Errorf("directory is not writable: %s", dir)
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,106 @@
+package validation_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+	"github.com/SCKelemen/clix/v2/ext/validation"
+)
+
+func pathFixture(t *testing.T) (dir, file, missing string) {
+	t.Helper()
+	dir = t.TempDir()
+	file = filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(file, []byte("name: demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, file, filepath.Join(dir, "missing")
+}
+
+func TestPathValidators(t *testing.T) {
+	dir, file, missing := pathFixture(t)
+
+	tests := []struct {
+		name      string
+		validator validation.Validator
+		value     string
+		wantErr   string
+	}{
+		{"exists dir", validation.PathExists, dir, ""},
+		{"exists file", validation.PathExists, file, ""},
+		{"exists missing", validation.PathExists, missing, "path does not exist"},
+		{"exists empty", validation.PathExists, "", "path cannot be empty"},
+		{"dir", validation.IsDir, dir, ""},
+		{"dir given file", validation.IsDir, file, "not a directory"},
+		{"dir missing", validation.IsDir, missing, "path does not exist"},
+		{"file", validation.IsFile, file, ""},
+		{"file given dir", validation.IsFile, dir, "is a directory"},
+		{"file missing", validation.IsFile, missing, "path does not exist"},
+		{"writable file", validation.Writable, file, ""},
+		{"writable dir", validation.Writable, dir, ""},
+		{"writable new path", validation.Writable, missing, ""},
+		{"writable missing parent", validation.Writable, filepath.Join(missing, "out.txt"), "parent directory does not exist"},
+		{"writable empty", validation.Writable, "", "path cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator(tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWritableLeavesNoProbeFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := validation.Writable(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected %s to stay empty, found %d entries", dir, len(entries))
+	}
+}
+
+func TestPathValidatorRepromptsOnFailure(t *testing.T) {
+	dir, file, missing := pathFixture(t)
+
+	in := strings.NewReader(missing + "\n" + file + "\n" + dir + "\n")
+	var out bytes.Buffer
+	prompter := clix.TextPrompter{In: in, Out: &out}
+
+	got, err := prompter.Prompt(context.Background(), clix.PromptRequest{
+		Label:    "Install directory",
+		Validate: validation.IsDir,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != dir {
+		t.Fatalf("expected %q, got %q", dir, got)
+	}
+	if n := strings.Count(out.String(), "Install directory"); n != 3 {
+		t.Fatalf("expected the prompt to be shown 3 times, got %d:\n%s", n, out.String())
+	}
+	for _, want := range []string{"path does not exist", "not a directory"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
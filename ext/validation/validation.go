@@ -1,8 +1,10 @@
 package validation
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"net"
 	"net/url"
 	"regexp"
@@ -34,19 +36,19 @@ type Validator func(string) error
 // Email validates an RFC 5322 compliant email address.
 func Email(value string) error {
 	if value == "" {
-		return errors.New("email cannot be empty")
+		return Errorf("email cannot be empty")
 	}
 
 	// RFC 5322 email regex (simplified but covers most cases)
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	if !emailRegex.MatchString(value) {
-		return errors.New("invalid email address")
+		return Errorf("invalid email address")
 	}
 
 	// Additional check: must have a domain
 	parts := strings.Split(value, "@")
 	if len(parts) != 2 || parts[1] == "" {
-		return errors.New("invalid email address")
+		return Errorf("invalid email address")
 	}
 
 	return nil
@@ -55,20 +57,24 @@ func Email(value string) error {
 // URL validates a URL string.
 func URL(value string) error {
 	if value == "" {
-		return errors.New("URL cannot be empty")
+		return Errorf("URL cannot be empty")
 	}
 
 	u, err := url.Parse(value)
 	if err != nil {
-		return fmt.Errorf("invalid URL: %w", err)
+		return Errorf("invalid URL: %w", err)
 	}
 
 	if u.Scheme == "" {
-		return errors.New("URL must include a scheme (e.g., http://, https://)")
+		err := Errorf("URL must include a scheme (e.g., http://, https://)")
+		if u.Host == "" && u.Path != "" {
+			err.Suggestions = []string{"https://" + value}
+		}
+		return err
 	}
 
 	if u.Host == "" {
-		return errors.New("URL must include a host")
+		return Errorf("URL must include a host")
 	}
 
 	return nil
@@ -77,12 +83,12 @@ func URL(value string) error {
 // CIDR validates a CIDR notation IP address range.
 func CIDR(value string) error {
 	if value == "" {
-		return errors.New("CIDR cannot be empty")
+		return Errorf("CIDR cannot be empty")
 	}
 
 	_, _, err := net.ParseCIDR(value)
 	if err != nil {
-		return fmt.Errorf("invalid CIDR: %w", err)
+		return Errorf("invalid CIDR: %w", err)
 	}
 
 	return nil
@@ -91,16 +97,16 @@ func CIDR(value string) error {
 // IPv4 validates an IPv4 address.
 func IPv4(value string) error {
 	if value == "" {
-		return errors.New("IPv4 address cannot be empty")
+		return Errorf("IPv4 address cannot be empty")
 	}
 
 	ip := net.ParseIP(value)
 	if ip == nil {
-		return errors.New("invalid IPv4 address")
+		return Errorf("invalid IPv4 address")
 	}
 
 	if ip.To4() == nil {
-		return errors.New("not an IPv4 address")
+		return Errorf("not an IPv4 address")
 	}
 
 	return nil
@@ -109,16 +115,16 @@ func IPv4(value string) error {
 // IPv6 validates an IPv6 address.
 func IPv6(value string) error {
 	if value == "" {
-		return errors.New("IPv6 address cannot be empty")
+		return Errorf("IPv6 address cannot be empty")
 	}
 
 	ip := net.ParseIP(value)
 	if ip == nil {
-		return errors.New("invalid IPv6 address")
+		return Errorf("invalid IPv6 address")
 	}
 
 	if ip.To16() == nil || ip.To4() != nil {
-		return errors.New("not an IPv6 address")
+		return Errorf("not an IPv6 address")
 	}
 
 	return nil
@@ -127,12 +133,12 @@ func IPv6(value string) error {
 // IP validates an IPv4 or IPv6 address.
 func IP(value string) error {
 	if value == "" {
-		return errors.New("IP address cannot be empty")
+		return Errorf("IP address cannot be empty")
 	}
 
 	ip := net.ParseIP(value)
 	if ip == nil {
-		return errors.New("invalid IP address")
+		return Errorf("invalid IP address")
 	}
 
 	return nil
@@ -141,13 +147,13 @@ func IP(value string) error {
 // E164 validates an E.164 phone number (e.g., +1234567890).
 func E164(value string) error {
 	if value == "" {
-		return errors.New("phone number cannot be empty")
+		return Errorf("phone number cannot be empty")
 	}
 
 	// E.164 format: + followed by 1-15 digits
 	e164Regex := regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
 	if !e164Regex.MatchString(value) {
-		return errors.New("invalid E.164 phone number (must start with + followed by country code and number)")
+		return Errorf("invalid E.164 phone number (must start with + followed by country code and number)")
 	}
 
 	return nil
@@ -156,7 +162,7 @@ func E164(value string) error {
 // NotEmpty validates that a string is not empty (after trimming whitespace).
 func NotEmpty(value string) error {
 	if strings.TrimSpace(value) == "" {
-		return errors.New("cannot be empty")
+		return Errorf("cannot be empty")
 	}
 	return nil
 }
@@ -165,7 +171,7 @@ func NotEmpty(value string) error {
 func MinLength(min int) Validator {
 	return func(value string) error {
 		if len(value) < min {
-			return fmt.Errorf("must be at least %d characters", min)
+			return Errorf("must be at least %d characters", min)
 		}
 		return nil
 	}
@@ -175,7 +181,7 @@ func MinLength(min int) Validator {
 func MaxLength(max int) Validator {
 	return func(value string) error {
 		if len(value) > max {
-			return fmt.Errorf("must be at most %d characters", max)
+			return Errorf("must be at most %d characters", max)
 		}
 		return nil
 	}
@@ -185,7 +191,7 @@ func MaxLength(max int) Validator {
 func Length(exact int) Validator {
 	return func(value string) error {
 		if len(value) != exact {
-			return fmt.Errorf("must be exactly %d characters", exact)
+			return Errorf("must be exactly %d characters", exact)
 		}
 		return nil
 	}
@@ -196,12 +202,47 @@ func Regex(pattern string) Validator {
 	re := regexp.MustCompile(pattern)
 	return func(value string) error {
 		if !re.MatchString(value) {
-			return fmt.Errorf("must match pattern: %s", pattern)
+			return Errorf("must match pattern: %s", pattern)
+		}
+		return nil
+	}
+}
+
+// OneOf validates that a string is exactly one of the allowed values.
+// It pairs well with enum flags and select prompts that accept free text.
+func OneOf(allowed ...string) Validator {
+	set := make(map[string]struct{}, len(allowed))
+	for _, value := range allowed {
+		set[value] = struct{}{}
+	}
+	return func(value string) error {
+		if _, ok := set[value]; !ok {
+			return oneOfError(allowed)
 		}
 		return nil
 	}
 }
 
+// OneOfFold is like OneOf but compares values case-insensitively.
+func OneOfFold(allowed ...string) Validator {
+	set := make(map[string]struct{}, len(allowed))
+	for _, value := range allowed {
+		set[strings.ToLower(value)] = struct{}{}
+	}
+	return func(value string) error {
+		if _, ok := set[strings.ToLower(value)]; !ok {
+			return oneOfError(allowed)
+		}
+		return nil
+	}
+}
+
+func oneOfError(allowed []string) error {
+	err := Errorf("must be one of: %s", strings.Join(allowed, ", "))
+	err.Suggestions = append([]string(nil), allowed...)
+	return err
+}
+
 // All combines multiple validators, requiring all to pass.
 func All(validators ...Validator) Validator {
 	return func(value string) error {
@@ -215,18 +256,27 @@ func All(validators ...Validator) Validator {
 }
 
 // Any combines multiple validators, requiring at least one to pass.
+// When all of them fail, the first error carrying an *Error is returned,
+// falling back to the last error.
 func Any(validators ...Validator) Validator {
 	return func(value string) error {
-		var lastErr error
+		var firstErr, lastErr error
 		for _, validator := range validators {
-			if err := validator(value); err == nil {
+			err := validator(value)
+			if err == nil {
 				return nil
-			} else {
-				lastErr = err
 			}
+			var verr *Error
+			if firstErr == nil && errors.As(err, &verr) {
+				firstErr = err
+			}
+			lastErr = err
+		}
+		if firstErr != nil {
+			return firstErr
 		}
 		if lastErr == nil {
-			return errors.New("validation failed")
+			return Errorf("validation failed")
 		}
 		return lastErr
 	}
@@ -235,16 +285,16 @@ func Any(validators ...Validator) Validator {
 // Port validates a TCP/UDP port number (1-65535).
 func Port(value string) error {
 	if value == "" {
-		return errors.New("port cannot be empty")
+		return Errorf("port cannot be empty")
 	}
 
 	port, err := strconv.Atoi(value)
 	if err != nil {
-		return errors.New("port must be a number")
+		return Errorf("port must be a number")
 	}
 
 	if port < 1 || port > 65535 {
-		return errors.New("port must be between 1 and 65535")
+		return Errorf("port must be between 1 and 65535")
 	}
 
 	return nil
@@ -254,7 +304,7 @@ func Port(value string) error {
 // Accepts hostnames like "example.com", "subdomain.example.com", "localhost".
 func Hostname(value string) error {
 	if value == "" {
-		return errors.New("hostname cannot be empty")
+		return Errorf("hostname cannot be empty")
 	}
 
 	// RFC 1123 hostname rules:
@@ -265,24 +315,24 @@ func Hostname(value string) error {
 	// - At least one label must be present
 
 	if len(value) > 253 {
-		return errors.New("hostname cannot exceed 253 characters")
+		return Errorf("hostname cannot exceed 253 characters")
 	}
 
 	labels := strings.Split(value, ".")
 	if len(labels) == 0 {
-		return errors.New("hostname must contain at least one label")
+		return Errorf("hostname must contain at least one label")
 	}
 
 	hostnameRegex := regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?$`)
 	for _, label := range labels {
 		if len(label) == 0 {
-			return errors.New("hostname labels cannot be empty")
+			return Errorf("hostname labels cannot be empty")
 		}
 		if len(label) > 63 {
-			return errors.New("hostname labels cannot exceed 63 characters")
+			return Errorf("hostname labels cannot exceed 63 characters")
 		}
 		if !hostnameRegex.MatchString(label) {
-			return errors.New("hostname contains invalid characters or format")
+			return Errorf("hostname contains invalid characters or format")
 		}
 	}
 
@@ -293,13 +343,97 @@ func Hostname(value string) error {
 // Accepts both uppercase and lowercase UUIDs.
 func UUID(value string) error {
 	if value == "" {
-		return errors.New("UUID cannot be empty")
+		return Errorf("UUID cannot be empty")
 	}
 
 	// UUID format: 8-4-4-4-12 hexadecimal digits
 	uuidRegex := regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 	if !uuidRegex.MatchString(value) {
-		return errors.New("invalid UUID format (expected: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)")
+		return Errorf("invalid UUID format (expected: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)")
+	}
+
+	return nil
+}
+
+// MAC validates a hardware (MAC) address such as "00:1a:2b:3c:4d:5e".
+// Colon, hyphen and dot (Cisco-style "001a.2b3c.4d5e") separators are accepted,
+// as are the EUI-64 and 20-octet InfiniBand forms.
+func MAC(value string) error {
+	if value == "" {
+		return Errorf("MAC address cannot be empty")
+	}
+
+	if _, err := net.ParseMAC(value); err != nil {
+		return Errorf("invalid MAC address (expected: xx:xx:xx:xx:xx:xx)")
+	}
+
+	return nil
+}
+
+// SemVer validates a semantic version as defined by https://semver.org
+// (e.g., "1.2.3", "2.0.0-rc.1+build.5"). A leading "v" is accepted.
+func SemVer(value string) error {
+	if value == "" {
+		return Errorf("version cannot be empty")
+	}
+
+	semverRegex := regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	if !semverRegex.MatchString(value) {
+		return Errorf("invalid semantic version (expected: MAJOR.MINOR.PATCH, e.g. 1.2.3)")
+	}
+
+	return nil
+}
+
+// JSON validates that a string is well-formed JSON.
+func JSON(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return Errorf("JSON cannot be empty")
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return Errorf("invalid JSON: %w", err)
+	}
+
+	return nil
+}
+
+// Base64 validates standard base64 (RFC 4648) with padding. URL-safe and
+// unpadded encodings are rejected; use Any with Regex for those variants.
+func Base64(value string) error {
+	if value == "" {
+		return Errorf("base64 value cannot be empty")
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		return Errorf("invalid base64 encoding")
+	}
+
+	return nil
+}
+
+// Hex validates a hexadecimal string with an even number of digits
+// (e.g., "deadBEEF"). An optional "0x" prefix is accepted.
+func Hex(value string) error {
+	if value == "" {
+		return Errorf("hex value cannot be empty")
+	}
+
+	digits := value
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
+	}
+	if digits == "" {
+		return Errorf("invalid hex value (no digits after 0x prefix)")
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		if len(digits)%2 != 0 {
+			return Errorf("invalid hex value (odd number of digits)")
+		}
+		return Errorf("invalid hex value (must contain only 0-9 and a-f)")
 	}
 
 	return nil
@@ -308,12 +442,12 @@ func UUID(value string) error {
 // Integer validates that a string can be parsed as an integer (int).
 func Integer(value string) error {
 	if value == "" {
-		return errors.New("integer cannot be empty")
+		return Errorf("integer cannot be empty")
 	}
 
 	_, err := strconv.Atoi(value)
 	if err != nil {
-		return errors.New("must be a valid integer")
+		return Errorf("must be a valid integer")
 	}
 
 	return nil
@@ -322,12 +456,12 @@ func Integer(value string) error {
 // Int64 validates that a string can be parsed as an int64.
 func Int64(value string) error {
 	if value == "" {
-		return errors.New("integer cannot be empty")
+		return Errorf("integer cannot be empty")
 	}
 
 	_, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		return errors.New("must be a valid 64-bit integer")
+		return Errorf("must be a valid 64-bit integer")
 	}
 
 	return nil
@@ -336,12 +470,12 @@ func Int64(value string) error {
 // Float64 validates that a string can be parsed as a float64.
 func Float64(value string) error {
 	if value == "" {
-		return errors.New("float cannot be empty")
+		return Errorf("float cannot be empty")
 	}
 
 	_, err := strconv.ParseFloat(value, 64)
 	if err != nil {
-		return errors.New("must be a valid floating-point number")
+		return Errorf("must be a valid floating-point number")
 	}
 
 	return nil
@@ -351,16 +485,16 @@ func Float64(value string) error {
 func IntRange(min, max int) Validator {
 	return func(value string) error {
 		if value == "" {
-			return errors.New("integer cannot be empty")
+			return Errorf("integer cannot be empty")
 		}
 
 		val, err := strconv.Atoi(value)
 		if err != nil {
-			return errors.New("must be a valid integer")
+			return Errorf("must be a valid integer")
 		}
 
 		if val < min || val > max {
-			return fmt.Errorf("must be between %d and %d", min, max)
+			return Errorf("must be between %d and %d", min, max)
 		}
 
 		return nil
@@ -371,16 +505,16 @@ func IntRange(min, max int) Validator {
 func FloatRange(min, max float64) Validator {
 	return func(value string) error {
 		if value == "" {
-			return errors.New("float cannot be empty")
+			return Errorf("float cannot be empty")
 		}
 
 		val, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			return errors.New("must be a valid floating-point number")
+			return Errorf("must be a valid floating-point number")
 		}
 
 		if val < min || val > max {
-			return fmt.Errorf("must be between %g and %g", min, max)
+			return Errorf("must be between %g and %g", min, max)
 		}
 
 		return nil
@@ -0,0 +1,51 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SCKelemen/clix/v2"
+	"github.com/SCKelemen/clix/v2/ext/validation"
+)
+
+func TestPortValidatesIntFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr string
+	}{
+		{"valid port", []string{"--port", "8080"}, 8080, ""},
+		{"port out of range", []string{"--port", "70000"}, 0, "invalid value for --port"},
+		{"untouched default is not validated", nil, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := clix.NewFlagSet("test")
+			var port int
+			fs.IntVar(clix.IntVarOptions{
+				FlagOptions: clix.FlagOptions{
+					Name:     "port",
+					Validate: validation.Port,
+				},
+				Default: "0",
+				Value:   &port,
+			})
+
+			_, err := fs.Parse(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if port != tt.want {
+				t.Errorf("expected port %d, got %d", tt.want, port)
+			}
+		})
+	}
+}
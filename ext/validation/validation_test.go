@@ -566,3 +566,229 @@ func TestFloatRange(t *testing.T) {
 		})
 	}
 }
+
+func TestMAC(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid colon separated", "00:1a:2b:3c:4d:5e", false},
+		{"valid hyphen separated", "00-1A-2B-3C-4D-5E", false},
+		{"valid dot separated", "001a.2b3c.4d5e", false},
+		{"valid EUI-64", "00:1a:2b:ff:fe:3c:4d:5e", false},
+		{"empty MAC", "", true},
+		{"too short", "00:1a:2b:3c:4d", true},
+		{"invalid digit", "00:1a:2b:3c:4d:5g", true},
+		{"mixed separators", "00:1a-2b:3c-4d:5e", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := MAC(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MAC(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSemVer(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid version", "1.2.3", false},
+		{"valid with v prefix", "v1.2.3", false},
+		{"valid zero version", "0.0.0", false},
+		{"valid pre-release", "2.0.0-rc.1", false},
+		{"valid build metadata", "1.0.0+build.5", false},
+		{"valid pre-release and build", "1.0.0-alpha.beta+exp.sha.5114f85", false},
+		{"empty version", "", true},
+		{"missing patch", "1.2", true},
+		{"leading zero", "01.2.3", true},
+		{"leading zero in pre-release", "1.2.3-01", true},
+		{"empty pre-release", "1.2.3-", true},
+		{"not a version", "latest", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SemVer(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SemVer(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid object", `{"name": "clix", "tags": ["cli"]}`, false},
+		{"valid array", `[1, 2, 3]`, false},
+		{"valid string", `"hello"`, false},
+		{"valid number", `42`, false},
+		{"valid null", `null`, false},
+		{"empty JSON", "", true},
+		{"whitespace only", "   ", true},
+		{"unterminated object", `{"name": "clix"`, true},
+		{"trailing comma", `[1, 2,]`, true},
+		{"unquoted key", `{name: "clix"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := JSON(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSON(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBase64(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid base64", "aGVsbG8=", false},
+		{"valid without padding needed", "aGVsbG8h", false},
+		{"valid with plus and slash", "+/+/", false},
+		{"empty base64", "", true},
+		{"missing padding", "aGVsbG8", true},
+		{"URL-safe alphabet", "-_-_", true},
+		{"invalid characters", "hello world", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Base64(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Base64(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid lowercase", "deadbeef", false},
+		{"valid mixed case", "DeadBEEF", false},
+		{"valid with 0x prefix", "0xff00", false},
+		{"valid with 0X prefix", "0XFF00", false},
+		{"empty hex", "", true},
+		{"prefix only", "0x", true},
+		{"odd number of digits", "abc", true},
+		{"invalid characters", "xyz1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Hex(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Hex(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatValidatorsCompose(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     string
+		wantErr   bool
+	}{
+		{"All SemVer and MaxLength passes", All(SemVer, MaxLength(10)), "1.2.3", false},
+		{"All SemVer and MaxLength fails length", All(SemVer, MaxLength(5)), "1.2.3-rc.1", true},
+		{"Any Hex or Base64 accepts hex", Any(Hex, Base64), "deadbeef", false},
+		{"Any Hex or Base64 accepts base64", Any(Hex, Base64), "aGVsbG8=", false},
+		{"Any Hex or Base64 rejects both", Any(Hex, Base64), "not valid!", true},
+		{"Any MAC or IP accepts MAC", Any(MAC, IP), "00:1a:2b:3c:4d:5e", false},
+		{"All JSON and MinLength passes", All(JSON, MinLength(2)), "{}", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validator(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	validator := OneOf("json", "yaml", "text")
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"allowed value", "json", false},
+		{"another allowed value", "text", false},
+		{"not allowed", "xml", true},
+		{"case differs", "JSON", true},
+		{"empty value", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OneOf(...)(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOneOfFold(t *testing.T) {
+	validator := OneOfFold("json", "YAML")
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"exact match", "json", false},
+		{"upper case input", "JSON", false},
+		{"lower case input for upper case value", "yaml", false},
+		{"mixed case", "YaMl", false},
+		{"not allowed", "xml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OneOfFold(...)(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOneOfErrorListsAllowedValues(t *testing.T) {
+	for name, validator := range map[string]Validator{
+		"OneOf":     OneOf("json", "yaml", "text"),
+		"OneOfFold": OneOfFold("json", "yaml", "text"),
+	} {
+		err := validator("xml")
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+		if want := "must be one of: json, yaml, text"; err.Error() != want {
+			t.Errorf("%s: error = %q, want %q", name, err.Error(), want)
+		}
+	}
+}
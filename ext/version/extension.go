@@ -3,6 +3,7 @@ package version
 import (
 	"fmt"
 	"runtime"
+	"runtime/debug"
 
 	"github.com/SCKelemen/clix/v2"
 )
@@ -12,7 +13,8 @@ import (
 //
 // The extension adds:
 //   - cli version - Show detailed version information (includes commit, date, Go version)
-//   - cli --version / -v - Show simple version info inline
+//   - cli --version / -v - Show simple version info inline (name and version
+//     only; structured when --format selects e.g. json)
 //
 // Example:
 //
@@ -40,6 +42,52 @@ type Extension struct {
 	// Date is the build date (optional).
 	// Only shown in the "version" command, not in the --version flag.
 	Date string
+
+	// BuildInfo fills Version, Commit and Date from the build information Go
+	// embeds in the binary (see runtime/debug.ReadBuildInfo) when they are
+	// empty. Binaries installed with "go install module@version" then report
+	// their module version, and binaries built from a VCS checkout report the
+	// revision and commit time, without any -ldflags.
+	BuildInfo bool
+}
+
+// FromBuildInfo returns an Extension whose version, commit and date come from
+// the binary's embedded build information.
+//
+// Example:
+//
+//	app.AddExtension(version.FromBuildInfo())
+//
+//	// Or keep values injected with -ldflags and only fill the gaps:
+//	app.AddExtension(version.Extension{Version: version, BuildInfo: true})
+func FromBuildInfo() Extension {
+	return Extension{BuildInfo: true}
+}
+
+// readBuildInfo is replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// fillFromBuildInfo sets the empty fields from the embedded build information.
+func (e *Extension) fillFromBuildInfo() {
+	info, ok := readBuildInfo()
+	if !ok {
+		return
+	}
+	if e.Version == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		e.Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if e.Commit == "" {
+				e.Commit = setting.Value
+			}
+		case "vcs.time":
+			if e.Date == "" {
+				e.Date = setting.Value
+			}
+		}
+	}
 }
 
 // Extend implements clix.Extension.
@@ -48,14 +96,25 @@ func (e Extension) Extend(app *clix.App) error {
 		return nil
 	}
 
-	// Add global --version flag that shows version info
-	app.Flags().BoolVar(clix.BoolVarOptions{
-		FlagOptions: clix.FlagOptions{
-			Name:  "version",
-			Short: "v",
-			Usage: "Show version information",
-		},
-	})
+	if e.BuildInfo {
+		e.fillFromBuildInfo()
+	}
+
+	// Add global --version flag that shows version info. Apps often use -v
+	// for --verbose, so the short name is only claimed when it is free.
+	if flags := app.Flags(); flags.Lookup("version") == nil {
+		short := "v"
+		if flags.LookupShort(short) != nil {
+			short = ""
+		}
+		flags.BoolVar(clix.BoolVarOptions{
+			FlagOptions: clix.FlagOptions{
+				Name:  "version",
+				Short: short,
+				Usage: "Show version information",
+			},
+		})
+	}
 
 	// Store version info in app so Run can access it for --version flag
 	app.Version = e.Version
@@ -95,11 +154,6 @@ func renderVersion(app *clix.App, version, commit, date string) error {
 		version = "dev"
 	}
 
-	format := clix.FormatText
-	if v, ok := app.Flags().String("format"); ok && v != "" {
-		format = v
-	}
-
 	// Build version data structure
 	versionData := map[string]interface{}{
 		"name":    app.Name,
@@ -119,6 +173,6 @@ func renderVersion(app *clix.App, version, commit, date string) error {
 		versionData["date"] = date
 	}
 
-	// Format according to --format flag
-	return clix.FormatData(app.Out, versionData, format)
+	// Format according to --format flag (and any formats added with RegisterFormat)
+	return app.FormatOutput(versionData)
 }
@@ -3,6 +3,11 @@ package version
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime/debug"
 	"strings"
 	"testing"
 
@@ -232,6 +237,33 @@ func TestVersionExtension(t *testing.T) {
 			t.Errorf("expected version in YAML, got: %s", outputStr)
 		}
 	})
+
+	t.Run("app that owns -v keeps it", func(t *testing.T) {
+		app := clix.NewApp("test")
+		var verbose bool
+		app.Flags().BoolVar(clix.WithFlagName("verbose"), clix.WithFlagShort("v"), clix.WithBoolValue(&verbose))
+		app.Root.Run = func(ctx *clix.Context) error { return nil }
+		app.AddExtension(Extension{Version: "1.2.3"})
+
+		var output bytes.Buffer
+		app.Out = &output
+		if err := app.Run(context.Background(), []string{"-v"}); err != nil {
+			t.Fatalf("run with -v failed: %v", err)
+		}
+		if !verbose {
+			t.Error("expected -v to set the app's --verbose flag")
+		}
+		if output.Len() != 0 {
+			t.Errorf("expected -v not to print the version, got: %s", output.String())
+		}
+
+		if err := app.Run(context.Background(), []string{"--version"}); err != nil {
+			t.Fatalf("--version failed: %v", err)
+		}
+		if !strings.Contains(output.String(), "test version 1.2.3") {
+			t.Errorf("expected --version to still work, got: %s", output.String())
+		}
+	})
 }
 
 func findChildInTest(cmd *clix.Command, name string) *clix.Command {
@@ -245,3 +277,156 @@ func findChildInTest(cmd *clix.Command, name string) *clix.Command {
 	}
 	return nil
 }
+
+func TestFromBuildInfo(t *testing.T) {
+	fakeBuildInfo := func(info *debug.BuildInfo) {
+		t.Helper()
+		original := readBuildInfo
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return info, true }
+		t.Cleanup(func() { readBuildInfo = original })
+	}
+	info := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/tool", Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "0123abcd"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+		},
+	}
+
+	t.Run("fills fields from build info", func(t *testing.T) {
+		fakeBuildInfo(info)
+		app := clix.NewApp("test")
+		app.AddExtension(FromBuildInfo())
+
+		var output bytes.Buffer
+		app.Out = &output
+		if err := app.Run(context.Background(), []string{"version"}); err != nil {
+			t.Fatalf("version command failed: %v", err)
+		}
+
+		if app.Version != "v1.4.0" {
+			t.Errorf("expected app.Version v1.4.0, got %q", app.Version)
+		}
+		outputStr := output.String()
+		for _, want := range []string{"version = v1.4.0", "commit = 0123abcd", "date = 2024-05-01T10:00:00Z"} {
+			if !strings.Contains(outputStr, want) {
+				t.Errorf("expected %q in output, got: %s", want, outputStr)
+			}
+		}
+	})
+
+	t.Run("explicit fields take precedence", func(t *testing.T) {
+		fakeBuildInfo(info)
+		app := clix.NewApp("test")
+		app.AddExtension(Extension{Version: "2.0.0", BuildInfo: true})
+
+		var output bytes.Buffer
+		app.Out = &output
+		if err := app.Run(context.Background(), []string{"version"}); err != nil {
+			t.Fatalf("version command failed: %v", err)
+		}
+
+		outputStr := output.String()
+		if !strings.Contains(outputStr, "version = 2.0.0") {
+			t.Errorf("expected explicit version, got: %s", outputStr)
+		}
+		if !strings.Contains(outputStr, "commit = 0123abcd") {
+			t.Errorf("expected commit from build info, got: %s", outputStr)
+		}
+	})
+
+	t.Run("development builds keep the dev version", func(t *testing.T) {
+		fakeBuildInfo(&debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+		app := clix.NewApp("test")
+		app.AddExtension(FromBuildInfo())
+
+		var output bytes.Buffer
+		app.Out = &output
+		if err := app.Run(context.Background(), []string{"version"}); err != nil {
+			t.Fatalf("version command failed: %v", err)
+		}
+		if !strings.Contains(output.String(), "version = dev") {
+			t.Errorf("expected dev version, got: %s", output.String())
+		}
+	})
+}
+
+func TestVersionStructuredOutput(t *testing.T) {
+	newApp := func(output *bytes.Buffer) *clix.App {
+		app := clix.NewApp("test")
+		app.AddExtension(format.Extension{})
+		app.AddExtension(Extension{Version: "1.2.3", Commit: "abc123"})
+		app.Out = output
+		return app
+	}
+
+	t.Run("version --format json", func(t *testing.T) {
+		var output bytes.Buffer
+		app := newApp(&output)
+		if err := app.Run(context.Background(), []string{"version", "--format", "json"}); err != nil {
+			t.Fatalf("version command failed: %v", err)
+		}
+
+		var got map[string]interface{}
+		if err := json.Unmarshal(output.Bytes(), &got); err != nil {
+			t.Fatalf("expected JSON output, got %q: %v", output.String(), err)
+		}
+		if got["version"] != "1.2.3" || got["commit"] != "abc123" {
+			t.Errorf("unexpected JSON fields: %v", got)
+		}
+	})
+
+	t.Run("--version --format json", func(t *testing.T) {
+		var output bytes.Buffer
+		app := newApp(&output)
+		if err := app.Run(context.Background(), []string{"--version", "--format", "json"}); err != nil {
+			t.Fatalf("--version failed: %v", err)
+		}
+
+		var got map[string]string
+		if err := json.Unmarshal(output.Bytes(), &got); err != nil {
+			t.Fatalf("expected JSON output, got %q: %v", output.String(), err)
+		}
+		if want := map[string]string{"name": "test", "version": "1.2.3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("--version short-circuits commands", func(t *testing.T) {
+		var output bytes.Buffer
+		app := newApp(&output)
+		ran := false
+		deploy := clix.NewCommand("deploy")
+		deploy.Run = func(ctx *clix.Context) error {
+			ran = true
+			return nil
+		}
+		app.Root.AddCommand(deploy)
+
+		if err := app.Run(context.Background(), []string{"deploy", "--version"}); err != nil {
+			t.Fatalf("--version failed: %v", err)
+		}
+		if ran {
+			t.Error("expected --version to skip the command")
+		}
+		if got := output.String(); got != "test version 1.2.3\n" {
+			t.Errorf("unexpected output: %q", got)
+		}
+	})
+
+	t.Run("version honors registered formats", func(t *testing.T) {
+		var output bytes.Buffer
+		app := newApp(&output)
+		app.RegisterFormat("short", func(w io.Writer, data interface{}) error {
+			_, err := fmt.Fprintf(w, "v%s\n", data.(map[string]interface{})["version"])
+			return err
+		})
+		if err := app.Run(context.Background(), []string{"version", "--format", "short"}); err != nil {
+			t.Fatalf("version command failed: %v", err)
+		}
+		if got := output.String(); got != "v1.2.3\n" {
+			t.Errorf("unexpected output: %q", got)
+		}
+	})
+}
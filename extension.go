@@ -1,5 +1,7 @@
 package clix
 
+import "errors"
+
 // Extension is the interface that optional "batteries-included" features implement.
 // Extensions allow features to be added to an App without requiring imports in
 // the core package, keeping simple applications lightweight.
@@ -27,6 +29,26 @@ type Extension interface {
 	Extend(app *App) error
 }
 
+// AfterRunner is an optional interface for extensions that need to clean up
+// once a command has finished, such as flushing telemetry or closing files
+// opened in Extend. App.Run calls AfterRun on every registered extension that
+// implements it, in reverse registration order, after the command's handler
+// and hooks have returned. It is called whether or not the command failed;
+// runErr is the command's error, or nil on success.
+//
+// AfterRun is only called for commands that were executed. Help, --version and
+// errors that occur before the command starts (such as flag parsing errors)
+// skip it.
+//
+// Example:
+//
+//	func (e *Telemetry) AfterRun(ctx *clix.Context, runErr error) error {
+//		return e.client.Flush(ctx)
+//	}
+type AfterRunner interface {
+	AfterRun(ctx *Context, runErr error) error
+}
+
 // AddExtension registers an extension with the application. Extensions are
 // applied lazily when the app runs, or can be applied immediately by calling
 // ApplyExtensions().
@@ -37,24 +59,47 @@ func (a *App) AddExtension(ext Extension) {
 	a.extensions = append(a.extensions, ext)
 }
 
-// ApplyExtensions processes all registered extensions in order. This is
-// typically called automatically during Run(), but can be called manually
-// for testing or early initialization.
+// ApplyExtensions processes all registered extensions in order. Run calls it
+// automatically, so AddExtension followed by Run is all most apps need; call
+// it manually for testing or early initialization (e.g. to inspect the
+// commands an extension adds before running).
 // Extensions are applied exactly once using sync.Once for thread-safety.
+// If an extension fails, the same error is returned from every later call
+// (and therefore from every Run) rather than running with a partially
+// extended app.
 func (a *App) ApplyExtensions() error {
 	if len(a.extensions) == 0 {
 		return nil
 	}
 
-	var extErr error
 	a.extensionsOnce.Do(func() {
 		for _, ext := range a.extensions {
 			if err := ext.Extend(a); err != nil {
-				extErr = err
+				a.extensionsErr = err
 				return
 			}
 		}
+		a.extensionsApplied = true
 	})
 
-	return extErr
+	return a.extensionsErr
+}
+
+// afterRun calls AfterRun on the extensions that implement AfterRunner, last
+// registered first. Teardown errors are joined with runErr.
+func (a *App) afterRun(ctx *Context, runErr error) error {
+	var errs []error
+	for i := len(a.extensions) - 1; i >= 0; i-- {
+		runner, ok := a.extensions[i].(AfterRunner)
+		if !ok {
+			continue
+		}
+		if err := runner.AfterRun(ctx, runErr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return runErr
+	}
+	return errors.Join(append([]error{runErr}, errs...)...)
 }
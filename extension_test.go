@@ -3,6 +3,8 @@ package clix
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -82,3 +84,151 @@ type extensionFunc func(*App) error
 func (f extensionFunc) Extend(app *App) error {
 	return f(app)
 }
+
+// afterRunExtension records AfterRun calls for testing.
+type afterRunExtension struct {
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (e afterRunExtension) Extend(app *App) error { return nil }
+
+func (e afterRunExtension) AfterRun(ctx *Context, runErr error) error {
+	*e.calls = append(*e.calls, e.name+":"+fmt.Sprint(runErr))
+	return e.err
+}
+
+func TestExtensionAfterRun(t *testing.T) {
+	newApp := func(runErr error, calls *[]string, exts ...afterRunExtension) *App {
+		app := NewApp("test")
+		app.configLoaded = true
+		app.Root.Run = func(ctx *Context) error {
+			*calls = append(*calls, "run")
+			return runErr
+		}
+		for _, ext := range exts {
+			app.AddExtension(ext)
+		}
+		// Extensions without AfterRun are skipped.
+		app.AddExtension(extensionFunc(func(a *App) error { return nil }))
+		return app
+	}
+
+	t.Run("runs in reverse order after success", func(t *testing.T) {
+		var calls []string
+		app := newApp(nil, &calls,
+			afterRunExtension{name: "first", calls: &calls},
+			afterRunExtension{name: "second", calls: &calls},
+		)
+
+		if err := app.Run(context.Background(), []string{}); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		want := []string{"run", "second:<nil>", "first:<nil>"}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("runs after failure with the command error", func(t *testing.T) {
+		var calls []string
+		runErr := errors.New("boom")
+		app := newApp(runErr, &calls, afterRunExtension{name: "ext", calls: &calls})
+
+		err := app.Run(context.Background(), []string{})
+		if err != runErr {
+			t.Fatalf("expected command error unchanged, got %v", err)
+		}
+		want := []string{"run", "ext:boom"}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+	})
+
+	t.Run("aggregates teardown errors", func(t *testing.T) {
+		var calls []string
+		runErr := errors.New("boom")
+		flushErr := errors.New("flush failed")
+		closeErr := errors.New("close failed")
+		app := newApp(runErr, &calls,
+			afterRunExtension{name: "first", calls: &calls, err: closeErr},
+			afterRunExtension{name: "second", calls: &calls, err: flushErr},
+		)
+
+		err := app.Run(context.Background(), []string{})
+		for _, want := range []error{runErr, flushErr, closeErr} {
+			if !errors.Is(err, want) {
+				t.Errorf("expected %v in %v", want, err)
+			}
+		}
+		if len(calls) != 3 {
+			t.Errorf("expected every teardown to run, got %v", calls)
+		}
+	})
+
+	t.Run("teardown error is returned after success", func(t *testing.T) {
+		var calls []string
+		flushErr := errors.New("flush failed")
+		app := newApp(nil, &calls, afterRunExtension{name: "ext", calls: &calls, err: flushErr})
+
+		if err := app.Run(context.Background(), []string{}); !errors.Is(err, flushErr) {
+			t.Errorf("expected teardown error, got %v", err)
+		}
+	})
+}
+
+func TestExtensionCommandRunsWithoutApplyExtensions(t *testing.T) {
+	app := NewApp("test")
+	app.configLoaded = true
+
+	ran := false
+	app.AddExtension(extensionFunc(func(a *App) error {
+		cmd := NewCommand("greet")
+		cmd.Run = func(ctx *Context) error {
+			ran = true
+			return nil
+		}
+		a.Root.AddCommand(cmd)
+		return nil
+	}))
+
+	if err := app.Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected extension command to run")
+	}
+
+	// A second Run and an explicit ApplyExtensions must not add the command again.
+	if err := app.ApplyExtensions(); err != nil {
+		t.Fatalf("ApplyExtensions failed: %v", err)
+	}
+	if err := app.Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("second Run failed: %v", err)
+	}
+	count := 0
+	for _, child := range app.Root.Children {
+		if child.Name == "greet" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected greet to be added once, found %d", count)
+	}
+}
+
+func TestExtensionErrorIsSticky(t *testing.T) {
+	app := NewApp("test")
+	app.configLoaded = true
+	app.Root.Run = func(ctx *Context) error { return nil }
+
+	testErr := errors.New("extension error")
+	app.AddExtension(extensionFunc(func(a *App) error { return testErr }))
+
+	for i := 0; i < 2; i++ {
+		if err := app.Run(context.Background(), []string{}); err != testErr {
+			t.Fatalf("run %d: expected %v, got %v", i+1, testErr, err)
+		}
+	}
+}
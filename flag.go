@@ -1,6 +1,8 @@
 package clix
 
 import (
+	"fmt"
+	"os"
 	"time"
 )
 
@@ -36,12 +38,15 @@ type FlagSet struct {
 	flags  []*Flag
 	index  map[string]*Flag
 	strict bool // If true, unknown flags cause errors instead of being treated as positionals
+
+	interspersed bool // If true, flags may appear after positional arguments
 }
 
 // NewFlagSet initialises an empty flag set.
-// By default, strict mode is enabled, so unknown flags cause errors.
+// By default, strict mode is enabled, so unknown flags cause errors, and flags
+// may be interspersed with positional arguments.
 func NewFlagSet(name string) *FlagSet {
-	return &FlagSet{name: name, index: make(map[string]*Flag), strict: true}
+	return &FlagSet{name: name, index: make(map[string]*Flag), strict: true, interspersed: true}
 }
 
 // SetStrict enables or disables strict mode. When strict mode is enabled (the default),
@@ -56,12 +61,32 @@ func (fs *FlagSet) Strict() bool {
 	return fs.strict
 }
 
+// SetInterspersed controls whether flags may follow positional arguments.
+// When enabled (the default), "cmd file --verbose" parses --verbose as a flag.
+// When disabled, parsing stops at the first positional argument and everything
+// from there on is returned verbatim, POSIX-style. This is useful for wrapper
+// commands that forward their arguments to another tool. On a command's flag
+// set it covers app flags too: in "app exec ls -h --format json", App.Run
+// hands "ls -h --format json" to exec untouched.
+func (fs *FlagSet) SetInterspersed(interspersed bool) {
+	fs.interspersed = interspersed
+}
+
+// Interspersed returns whether flags may follow positional arguments.
+func (fs *FlagSet) Interspersed() bool {
+	return fs.interspersed
+}
+
 // Flag describes a single CLI flag.
 // Flags are created via FlagSet methods (StringVar, BoolVar, etc.).
 type Flag struct {
 	// Name is the long flag name (e.g., "project" for --project).
 	Name string
 
+	// Aliases are additional long names that refer to the same flag
+	// (e.g., "colour" for --colour alongside --color).
+	Aliases []string
+
 	// Short is the shorthand flag name (e.g., "p" for -p).
 	Short string
 
@@ -72,6 +97,10 @@ type Flag struct {
 	// If empty, defaults to APP_KEY format based on EnvPrefix.
 	EnvVar string
 
+	// EnvVars are additional environment variable names consulted, in order,
+	// after EnvVar. The first variable that is set wins.
+	EnvVars []string
+
 	// Default is the default value for this flag (as a string).
 	Default string
 
@@ -84,15 +113,98 @@ type Flag struct {
 	// Positional allows this flag to be set by position in addition to by name.
 	Positional bool
 
+	// Variadic makes a positional flag consume every remaining argument.
+	Variadic bool
+
 	// Validate is an optional function that validates the raw string value
 	// after it has been successfully parsed by Value.Set.
 	Validate func(string) error
 
+	// ValidateCtx is an optional function that validates the value against the
+	// rest of the command line once every flag and positional has been resolved.
+	ValidateCtx func(ctx *Context, value string) error
+
+	// Deprecated is the deprecation message printed when the flag is set on
+	// the command line. Empty means the flag is not deprecated.
+	Deprecated string
+
+	// DeprecatedReplacement is the name of the flag that receives this flag's
+	// value when the deprecated flag is used.
+	DeprecatedReplacement string
+
+	// Hidden omits the flag from help output while still allowing it to be parsed.
+	Hidden bool
+
+	// Category groups the flag under its own heading in help output.
+	// Empty means the flag is listed under the default FLAGS heading.
+	Category string
+
+	// Complete optionally returns completion candidates for the flag's value
+	// (or, for positional flags, its argument position).
+	Complete func(ctx *Context, toComplete string) []string
+
 	// Value is the flag value implementation.
 	Value Value
 
-	set    bool // Internal: tracks if flag was explicitly set (any source)
-	cliSet bool // Internal: tracks if flag was set via CLI argument (not env/config/default)
+	set     bool   // Internal: tracks if flag was explicitly set (any source)
+	cliSet  bool   // Internal: tracks if flag was set via CLI argument (not env/config/default)
+	source  Source // Internal: where the value came from when set is true
+	initial string // Internal: value at registration, restored by reset when Default is empty
+}
+
+// reset clears the flag's explicit-set state and restores its default value.
+// Flags without a Default are restored to the value they held at registration.
+// The restored value is one Set accepted before, so a Set error here can only
+// come from a custom Value that rejects it now; the flag then keeps its current
+// value, and the error is left to surface when the flag is next set.
+func (f *Flag) reset() {
+	f.clearState()
+	if r, ok := f.Value.(valueResetter); ok {
+		r.resetValue()
+		return
+	}
+	value := f.Default
+	if value == "" {
+		value = f.initial
+	}
+	_ = f.Value.Set(value)
+}
+
+// clearState forgets where the flag's value came from without touching the
+// value itself.
+func (f *Flag) clearState() {
+	f.set = false
+	f.cliSet = false
+	f.source = SourceDefault
+}
+
+// valueResetter is implemented by values that cannot be restored through Set,
+// such as slices, where Set appends rather than replaces.
+type valueResetter interface {
+	resetValue()
+}
+
+// markCLISet records that the flag was given on the command line.
+func (f *Flag) markCLISet() {
+	f.set = true
+	f.cliSet = true
+	f.source = SourceCommandFlag
+}
+
+// lookupEnv returns the value of the first environment variable that is set
+// among EnvVar and EnvVars, together with that variable's name.
+func (f *Flag) lookupEnv() (value, name string, ok bool) {
+	if f.EnvVar != "" {
+		if value, ok := os.LookupEnv(f.EnvVar); ok {
+			return value, f.EnvVar, true
+		}
+	}
+	for _, name := range f.EnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, name, true
+		}
+	}
+	return "", "", false
 }
 
 // Value mirrors flag.Value but adds helpers for boolean flags.
@@ -106,12 +218,26 @@ type boolFlag interface {
 	SetBool(bool) error
 }
 
+// DefaultSetter is an optional interface for custom values registered with
+// FlagSet.Var. When implemented, DefaultValue is applied via Set at
+// registration and recorded as the flag's Default, so it participates in
+// Reset and precedence resolution like the built-in flag types.
+type DefaultSetter interface {
+	DefaultValue() string
+}
+
 // FlagOptions contains common configuration for all flag types.
 // This struct is embedded in all *VarOptions types to provide a unified API.
 type FlagOptions struct {
 	// Name is the long flag name (e.g., "project" for --project).
 	Name string
 
+	// Aliases are optional additional long names for the flag. Each alias is
+	// accepted on the command line (e.g., --colour for a flag named "color")
+	// and resolves to the same value. Help shows the canonical Name with the
+	// aliases in parentheses.
+	Aliases []string
+
 	// Short is the optional shorthand flag name (e.g., "p" for -p).
 	Short string
 
@@ -139,13 +265,150 @@ type FlagOptions struct {
 	// Positional allows this flag to be set by position in addition to by name.
 	// Positional flags are assigned left-to-right in registration order.
 	// Both forms work: `cmd <value>` and `cmd --flag <value>`.
+	// Omitted trailing positionals resolve from env, config or Default like any
+	// other flag; they never shift the positions of the ones before them.
 	// Boolean flags cannot be positional.
 	Positional bool
 
+	// Variadic makes a positional flag collect all remaining arguments, calling
+	// Value.Set once per argument. Only the last positional flag of a set may
+	// be variadic. Pair it with StringSliceVar to keep every value:
+	//
+	//	cmd.Flags.StringSliceVar(clix.StringSliceVarOptions{
+	//		FlagOptions: clix.FlagOptions{Name: "files", Positional: true, Variadic: true},
+	//		Value:       &files,
+	//	})
+	Variadic bool
+
 	// Validate is an optional function that validates the raw string value
 	// after it has been successfully parsed by Value.Set. If non-nil, it is
-	// called with the raw input string; returning a non-nil error rejects the value.
+	// called with the raw input string; returning a non-nil error rejects the value
+	// with "invalid value for --name: <err>". Values from the command line, env
+	// and config are validated; untouched defaults are not.
 	Validate func(string) error
+
+	// ValidateCtx is like Validate but runs after all flags and positional
+	// arguments have been collected (including interactive prompts), just
+	// before the command's hooks run. It receives the command Context, so it
+	// can compare the value with other flags, e.g. an end date that must
+	// follow a start date:
+	//
+	//	ValidateCtx: func(ctx *clix.Context, value string) error {
+	//		start, _ := ctx.String("start")
+	//		if value < start {
+	//			return fmt.Errorf("must not be before %s", start)
+	//		}
+	//		return nil
+	//	}
+	//
+	// Like Validate, it only runs for flags that were given a value.
+	ValidateCtx func(ctx *Context, value string) error
+
+	// Deprecated marks the flag as deprecated. When the flag is set on the
+	// command line, App.Run prints "flag --name is deprecated: <Deprecated>"
+	// to app.Err. Values hydrated from env, config or defaults never warn.
+	Deprecated string
+
+	// Hidden keeps the flag out of help output. Hidden flags are still parsed
+	// normally, which makes them useful for internal or experimental options.
+	Hidden bool
+
+	// Category groups related flags under a shared heading in help output
+	// (e.g., "Network" renders as a NETWORK section). Uncategorized flags are
+	// listed first under FLAGS; categories follow in declaration order.
+	Category string
+
+	// Complete returns dynamic shell completion candidates for the flag's
+	// value, such as project IDs fetched at runtime. Candidates should start
+	// with toComplete; the shell filters them further. For positional flags it
+	// also completes the matching argument position.
+	Complete func(ctx *Context, toComplete string) []string
+
+	// DeprecatedReplacement optionally names the flag that replaces this one.
+	// When the deprecated flag is set on the command line, its value is also
+	// forwarded to the replacement flag (unless that flag was set explicitly).
+	DeprecatedReplacement string
+}
+
+// newFlag builds a Flag from the options shared by every flag type.
+func newFlag(opts FlagOptions, def string, value Value) *Flag {
+	return &Flag{
+		Name:                  opts.Name,
+		Aliases:               opts.Aliases,
+		Short:                 opts.Short,
+		Usage:                 opts.Usage,
+		EnvVar:                opts.EnvVar,
+		EnvVars:               opts.EnvVars,
+		Default:               def,
+		Required:              opts.Required,
+		Prompt:                opts.Prompt,
+		Positional:            opts.Positional,
+		Variadic:              opts.Variadic,
+		Validate:              opts.Validate,
+		ValidateCtx:           opts.ValidateCtx,
+		Deprecated:            opts.Deprecated,
+		DeprecatedReplacement: opts.DeprecatedReplacement,
+		Hidden:                opts.Hidden,
+		Category:              opts.Category,
+		Complete:              opts.Complete,
+		Value:                 value,
+	}
+}
+
+// ApplyFlag implements FlagOption so FlagOptions can be passed directly to
+// FlagSet.Var or combined with functional options. Only non-zero fields are applied.
+func (o FlagOptions) ApplyFlag(fo *FlagOptions) {
+	if o.Name != "" {
+		fo.Name = o.Name
+	}
+	if len(o.Aliases) > 0 {
+		fo.Aliases = o.Aliases
+	}
+	if o.Short != "" {
+		fo.Short = o.Short
+	}
+	if o.Usage != "" {
+		fo.Usage = o.Usage
+	}
+	if o.EnvVar != "" {
+		fo.EnvVar = o.EnvVar
+	}
+	if len(o.EnvVars) > 0 {
+		fo.EnvVars = o.EnvVars
+	}
+	if o.Required {
+		fo.Required = true
+	}
+	if o.Prompt != "" {
+		fo.Prompt = o.Prompt
+	}
+	if o.Positional {
+		fo.Positional = true
+	}
+	if o.Variadic {
+		fo.Variadic = true
+	}
+	if o.Validate != nil {
+		fo.Validate = o.Validate
+	}
+	if o.ValidateCtx != nil {
+		fo.ValidateCtx = o.ValidateCtx
+	}
+	if o.Deprecated != "" {
+		fo.Deprecated = o.Deprecated
+	}
+	if o.Hidden {
+		fo.Hidden = true
+	}
+	if o.DeprecatedReplacement != "" {
+		fo.DeprecatedReplacement = o.DeprecatedReplacement
+	}
+	if o.Category != "" {
+		fo.Category = o.Category
+	}
+	if o.Complete != nil {
+		fo.Complete = o.Complete
+	}
 }
 
 // StringVarOptions describes the configuration for adding a string flag.
@@ -189,6 +452,11 @@ type StringVarOptions struct {
 	Default string
 	// Value is a pointer to the variable that will store the flag value.
 	Value *string
+	// FromFile enables @-syntax: a value such as "@/path/to/key" is replaced
+	// by the contents of that file, with trailing newlines trimmed. Validate
+	// checks the contents rather than the path. Useful for secrets and long
+	// inputs that are awkward on the command line.
+	FromFile bool
 }
 
 // ApplyFlag implements FlagOption so StringVarOptions can be used directly.
@@ -252,23 +520,17 @@ func (fs *FlagSet) StringVar(opts ...FlagOption) {
 			stringOpts.Value = v.value
 		case stringDefaultOption:
 			stringOpts.Default = string(v)
+		case stringFromFileOption:
+			stringOpts.FromFile = bool(v)
 		default:
 			opt.ApplyFlag(&stringOpts.FlagOptions)
 		}
 	}
-	value := &StringValue{target: stringOpts.Value}
-	flag := &Flag{
-		Name:       stringOpts.Name,
-		Short:      stringOpts.Short,
-		Usage:      stringOpts.Usage,
-		EnvVar:     stringOpts.EnvVar,
-		Default:    stringOpts.Default,
-		Required:   stringOpts.Required,
-		Prompt:     stringOpts.Prompt,
-		Positional: stringOpts.Positional,
-		Validate:   stringOpts.Validate,
-		Value:      value,
+	if stringOpts.Value == nil {
+		stringOpts.Value = new(string)
 	}
+	value := &StringValue{target: stringOpts.Value, fromFile: stringOpts.FromFile}
+	flag := newFlag(stringOpts.FlagOptions, stringOpts.Default, value)
 	fs.addFlag(flag)
 	if stringOpts.Default != "" {
 		_ = value.Set(stringOpts.Default)
@@ -283,12 +545,18 @@ type stringValueOption struct {
 // stringDefaultOption is an internal type for string flag defaults.
 type stringDefaultOption string
 
+// stringFromFileOption is an internal type enabling @file values.
+type stringFromFileOption bool
+
 // ApplyFlag implements FlagOption for stringValueOption.
 func (o stringValueOption) ApplyFlag(*FlagOptions) {}
 
 // ApplyFlag implements FlagOption for stringDefaultOption.
 func (o stringDefaultOption) ApplyFlag(*FlagOptions) {}
 
+// ApplyFlag implements FlagOption for stringFromFileOption.
+func (o stringFromFileOption) ApplyFlag(*FlagOptions) {}
+
 // DurationVarOptions describes the configuration for adding a duration flag.
 // This struct implements FlagOption, so it can be used alongside functional options.
 //
@@ -407,18 +675,11 @@ func (fs *FlagSet) BoolVar(opts ...FlagOption) {
 			opt.ApplyFlag(&boolOpts.FlagOptions)
 		}
 	}
-	value := &BoolValue{target: boolOpts.Value}
-	flag := &Flag{
-		Name:       boolOpts.Name,
-		Short:      boolOpts.Short,
-		Usage:      boolOpts.Usage,
-		EnvVar:     boolOpts.EnvVar,
-		Required:   boolOpts.Required,
-		Prompt:     boolOpts.Prompt,
-		Positional: boolOpts.Positional,
-		Validate:   boolOpts.Validate,
-		Value:      value,
+	if boolOpts.Value == nil {
+		boolOpts.Value = new(bool)
 	}
+	value := &BoolValue{target: boolOpts.Value}
+	flag := newFlag(boolOpts.FlagOptions, "", value)
 	fs.addFlag(flag)
 }
 
@@ -438,19 +699,11 @@ func (fs *FlagSet) DurationVar(opts ...FlagOption) {
 			opt.ApplyFlag(&durationOpts.FlagOptions)
 		}
 	}
-	value := &DurationValue{target: durationOpts.Value}
-	flag := &Flag{
-		Name:       durationOpts.Name,
-		Short:      durationOpts.Short,
-		Usage:      durationOpts.Usage,
-		EnvVar:     durationOpts.EnvVar,
-		Default:    durationOpts.Default,
-		Required:   durationOpts.Required,
-		Prompt:     durationOpts.Prompt,
-		Positional: durationOpts.Positional,
-		Validate:   durationOpts.Validate,
-		Value:      value,
+	if durationOpts.Value == nil {
+		durationOpts.Value = new(time.Duration)
 	}
+	value := &DurationValue{target: durationOpts.Value}
+	flag := newFlag(durationOpts.FlagOptions, durationOpts.Default, value)
 	fs.addFlag(flag)
 	if durationOpts.Default != "" {
 		_ = value.Set(durationOpts.Default)
@@ -526,19 +779,11 @@ func (fs *FlagSet) IntVar(opts ...FlagOption) {
 			opt.ApplyFlag(&intOpts.FlagOptions)
 		}
 	}
-	value := &IntValue{target: intOpts.Value}
-	flag := &Flag{
-		Name:       intOpts.Name,
-		Short:      intOpts.Short,
-		Usage:      intOpts.Usage,
-		EnvVar:     intOpts.EnvVar,
-		Default:    intOpts.Default,
-		Required:   intOpts.Required,
-		Prompt:     intOpts.Prompt,
-		Positional: intOpts.Positional,
-		Validate:   intOpts.Validate,
-		Value:      value,
+	if intOpts.Value == nil {
+		intOpts.Value = new(int)
 	}
+	value := &IntValue{target: intOpts.Value}
+	flag := newFlag(intOpts.FlagOptions, intOpts.Default, value)
 	fs.addFlag(flag)
 	if intOpts.Default != "" {
 		_ = value.Set(intOpts.Default)
@@ -614,25 +859,347 @@ func (fs *FlagSet) Int64Var(opts ...FlagOption) {
 			opt.ApplyFlag(&int64Opts.FlagOptions)
 		}
 	}
-	value := &Int64Value{target: int64Opts.Value}
-	flag := &Flag{
-		Name:       int64Opts.Name,
-		Short:      int64Opts.Short,
-		Usage:      int64Opts.Usage,
-		EnvVar:     int64Opts.EnvVar,
-		Default:    int64Opts.Default,
-		Required:   int64Opts.Required,
-		Prompt:     int64Opts.Prompt,
-		Positional: int64Opts.Positional,
-		Validate:   int64Opts.Validate,
-		Value:      value,
+	if int64Opts.Value == nil {
+		int64Opts.Value = new(int64)
 	}
+	value := &Int64Value{target: int64Opts.Value}
+	flag := newFlag(int64Opts.FlagOptions, int64Opts.Default, value)
 	fs.addFlag(flag)
 	if int64Opts.Default != "" {
 		_ = value.Set(int64Opts.Default)
 	}
 }
 
+// BytesVarOptions describes the configuration for adding a byte-size flag.
+// Values accept decimal (KB, MB, GB, TB) and binary (KiB, MiB, GiB, TiB)
+// suffixes, or a bare number of bytes.
+// This struct implements FlagOption, so it can be used alongside functional options.
+//
+// Example:
+//
+//	var limit int64
+//	// Struct-based (primary API)
+//	cmd.Flags.BytesVar(clix.BytesVarOptions{
+//		FlagOptions: clix.FlagOptions{
+//			Name:  "max-upload",
+//			Usage: "Maximum upload size",
+//		},
+//		Default: "10MB",
+//		Value: &limit,
+//	})
+//
+//	// Functional options
+//	cmd.Flags.BytesVar(
+//		WithFlagName("max-upload"),
+//		WithFlagUsage("Maximum upload size"),
+//		WithBytesValue(&limit),
+//		WithBytesDefault("10MB"),
+//	)
+type BytesVarOptions struct {
+	FlagOptions
+	// Default is the default value as a string (e.g., "10MB" or "2GiB").
+	Default string
+	// Value is a pointer to the variable that will store the size in bytes.
+	Value *int64
+}
+
+// ApplyFlag implements FlagOption so BytesVarOptions can be used directly.
+func (o BytesVarOptions) ApplyFlag(fo *FlagOptions) {
+	if o.Name != "" {
+		fo.Name = o.Name
+	}
+	if o.Short != "" {
+		fo.Short = o.Short
+	}
+	if o.Usage != "" {
+		fo.Usage = o.Usage
+	}
+	if o.EnvVar != "" {
+		fo.EnvVar = o.EnvVar
+	}
+	if len(o.EnvVars) > 0 {
+		fo.EnvVars = o.EnvVars
+	}
+	if o.Positional {
+		fo.Positional = true
+	}
+}
+
+// BytesVar registers a byte-size flag. Accepts either a BytesVarOptions struct
+// (primary API) or functional options (convenience layer).
+func (fs *FlagSet) BytesVar(opts ...FlagOption) {
+	var bytesOpts BytesVarOptions
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case BytesVarOptions:
+			bytesOpts = v
+		case bytesValueOption:
+			bytesOpts.Value = v.value
+		case bytesDefaultOption:
+			bytesOpts.Default = string(v)
+		default:
+			opt.ApplyFlag(&bytesOpts.FlagOptions)
+		}
+	}
+	if bytesOpts.Value == nil {
+		bytesOpts.Value = new(int64)
+	}
+	value := &BytesValue{target: bytesOpts.Value}
+	flag := newFlag(bytesOpts.FlagOptions, bytesOpts.Default, value)
+	fs.addFlag(flag)
+	if bytesOpts.Default != "" {
+		_ = value.Set(bytesOpts.Default)
+	}
+}
+
+// TimestampVarOptions describes the configuration for adding a timestamp flag.
+// This struct implements FlagOption, so it can be used alongside functional options.
+//
+// Example:
+//
+//	var since time.Time
+//	// Struct-based (primary API)
+//	cmd.Flags.TimestampVar(clix.TimestampVarOptions{
+//		FlagOptions: clix.FlagOptions{
+//			Name:  "since",
+//			Usage: "Only show entries after this time",
+//		},
+//		Layouts: []string{time.RFC3339, time.DateOnly},
+//		Value: &since,
+//	})
+//
+//	// Functional options
+//	cmd.Flags.TimestampVar(
+//		WithFlagName("since"),
+//		WithTimestampLayouts(time.RFC3339, time.DateOnly),
+//		WithTimestampValue(&since),
+//	)
+type TimestampVarOptions struct {
+	FlagOptions
+	// Default is the default value as a string in one of Layouts.
+	Default string
+	// Layouts are the time layouts tried in order when parsing a value.
+	// Defaults to time.RFC3339. The first layout is used to render the value.
+	Layouts []string
+	// Value is a pointer to the variable that will store the flag value.
+	Value *time.Time
+}
+
+// ApplyFlag implements FlagOption so TimestampVarOptions can be used directly.
+func (o TimestampVarOptions) ApplyFlag(fo *FlagOptions) {
+	if o.Name != "" {
+		fo.Name = o.Name
+	}
+	if o.Short != "" {
+		fo.Short = o.Short
+	}
+	if o.Usage != "" {
+		fo.Usage = o.Usage
+	}
+	if o.EnvVar != "" {
+		fo.EnvVar = o.EnvVar
+	}
+	if len(o.EnvVars) > 0 {
+		fo.EnvVars = o.EnvVars
+	}
+	if o.Positional {
+		fo.Positional = true
+	}
+}
+
+// TimestampVar registers a time.Time flag. Accepts either a TimestampVarOptions
+// struct (primary API) or functional options (convenience layer).
+func (fs *FlagSet) TimestampVar(opts ...FlagOption) {
+	var timestampOpts TimestampVarOptions
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case TimestampVarOptions:
+			timestampOpts = v
+		case timestampValueOption:
+			timestampOpts.Value = v.value
+		case timestampDefaultOption:
+			timestampOpts.Default = string(v)
+		case timestampLayoutsOption:
+			timestampOpts.Layouts = []string(v)
+		default:
+			opt.ApplyFlag(&timestampOpts.FlagOptions)
+		}
+	}
+	layouts := timestampOpts.Layouts
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	if timestampOpts.Value == nil {
+		timestampOpts.Value = new(time.Time)
+	}
+	value := &TimestampValue{target: timestampOpts.Value, layouts: layouts}
+	flag := newFlag(timestampOpts.FlagOptions, timestampOpts.Default, value)
+	fs.addFlag(flag)
+	if timestampOpts.Default != "" {
+		_ = value.Set(timestampOpts.Default)
+	}
+}
+
+// UintVarOptions describes the configuration for adding a uint flag.
+// This struct implements FlagOption, so it can be used alongside functional options.
+//
+// Example:
+//
+//	var workers uint
+//	// Struct-based (primary API)
+//	cmd.Flags.UintVar(clix.UintVarOptions{
+//		FlagOptions: clix.FlagOptions{
+//			Name:  "workers",
+//			Usage: "Number of workers",
+//		},
+//		Default: "4",
+//		Value: &workers,
+//	})
+//
+//	// Functional options
+//	cmd.Flags.UintVar(
+//		WithFlagName("workers"),
+//		WithFlagUsage("Number of workers"),
+//		WithUintValue(&workers),
+//		WithUintDefault("4"),
+//	)
+type UintVarOptions struct {
+	FlagOptions
+	// Default is the default value as a string (e.g., "4").
+	Default string
+	// Value is a pointer to the variable that will store the flag value.
+	Value *uint
+}
+
+// ApplyFlag implements FlagOption so UintVarOptions can be used directly.
+func (o UintVarOptions) ApplyFlag(fo *FlagOptions) {
+	if o.Name != "" {
+		fo.Name = o.Name
+	}
+	if o.Short != "" {
+		fo.Short = o.Short
+	}
+	if o.Usage != "" {
+		fo.Usage = o.Usage
+	}
+	if o.EnvVar != "" {
+		fo.EnvVar = o.EnvVar
+	}
+	if len(o.EnvVars) > 0 {
+		fo.EnvVars = o.EnvVars
+	}
+	if o.Positional {
+		fo.Positional = true
+	}
+}
+
+// UintVar registers a uint flag. Accepts either a UintVarOptions struct
+// (primary API) or functional options (convenience layer).
+func (fs *FlagSet) UintVar(opts ...FlagOption) {
+	var uintOpts UintVarOptions
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case UintVarOptions:
+			uintOpts = v
+		case uintValueOption:
+			uintOpts.Value = v.value
+		case uintDefaultOption:
+			uintOpts.Default = string(v)
+		default:
+			opt.ApplyFlag(&uintOpts.FlagOptions)
+		}
+	}
+	if uintOpts.Value == nil {
+		uintOpts.Value = new(uint)
+	}
+	value := &UintValue{target: uintOpts.Value}
+	flag := newFlag(uintOpts.FlagOptions, uintOpts.Default, value)
+	fs.addFlag(flag)
+	if uintOpts.Default != "" {
+		_ = value.Set(uintOpts.Default)
+	}
+}
+
+// Float32VarOptions describes the configuration for adding a float32 flag.
+// This struct implements FlagOption, so it can be used alongside functional options.
+//
+// Example:
+//
+//	var ratio float32
+//	// Struct-based (primary API)
+//	cmd.Flags.Float32Var(clix.Float32VarOptions{
+//		FlagOptions: clix.FlagOptions{
+//			Name:  "ratio",
+//			Usage: "Sampling ratio",
+//		},
+//		Default: "0.25",
+//		Value: &ratio,
+//	})
+//
+//	// Functional options
+//	cmd.Flags.Float32Var(
+//		WithFlagName("ratio"),
+//		WithFlagUsage("Sampling ratio"),
+//		WithFloat32Value(&ratio),
+//		WithFloat32Default("0.25"),
+//	)
+type Float32VarOptions struct {
+	FlagOptions
+	// Default is the default value as a string (e.g., "0.25").
+	Default string
+	// Value is a pointer to the variable that will store the flag value.
+	Value *float32
+}
+
+// ApplyFlag implements FlagOption so Float32VarOptions can be used directly.
+func (o Float32VarOptions) ApplyFlag(fo *FlagOptions) {
+	if o.Name != "" {
+		fo.Name = o.Name
+	}
+	if o.Short != "" {
+		fo.Short = o.Short
+	}
+	if o.Usage != "" {
+		fo.Usage = o.Usage
+	}
+	if o.EnvVar != "" {
+		fo.EnvVar = o.EnvVar
+	}
+	if len(o.EnvVars) > 0 {
+		fo.EnvVars = o.EnvVars
+	}
+	if o.Positional {
+		fo.Positional = true
+	}
+}
+
+// Float32Var registers a float32 flag. Accepts either a Float32VarOptions struct
+// (primary API) or functional options (convenience layer).
+func (fs *FlagSet) Float32Var(opts ...FlagOption) {
+	var float32Opts Float32VarOptions
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case Float32VarOptions:
+			float32Opts = v
+		case float32ValueOption:
+			float32Opts.Value = v.value
+		case float32DefaultOption:
+			float32Opts.Default = string(v)
+		default:
+			opt.ApplyFlag(&float32Opts.FlagOptions)
+		}
+	}
+	if float32Opts.Value == nil {
+		float32Opts.Value = new(float32)
+	}
+	value := &Float32Value{target: float32Opts.Value}
+	flag := newFlag(float32Opts.FlagOptions, float32Opts.Default, value)
+	fs.addFlag(flag)
+	if float32Opts.Default != "" {
+		_ = value.Set(float32Opts.Default)
+	}
+}
+
 // Float64VarOptions describes the configuration for adding a float64 flag.
 // This struct implements FlagOption, so it can be used alongside functional options.
 //
@@ -702,25 +1269,46 @@ func (fs *FlagSet) Float64Var(opts ...FlagOption) {
 			opt.ApplyFlag(&float64Opts.FlagOptions)
 		}
 	}
-	value := &Float64Value{target: float64Opts.Value}
-	flag := &Flag{
-		Name:       float64Opts.Name,
-		Short:      float64Opts.Short,
-		Usage:      float64Opts.Usage,
-		EnvVar:     float64Opts.EnvVar,
-		Default:    float64Opts.Default,
-		Required:   float64Opts.Required,
-		Prompt:     float64Opts.Prompt,
-		Positional: float64Opts.Positional,
-		Validate:   float64Opts.Validate,
-		Value:      value,
+	if float64Opts.Value == nil {
+		float64Opts.Value = new(float64)
 	}
+	value := &Float64Value{target: float64Opts.Value}
+	flag := newFlag(float64Opts.FlagOptions, float64Opts.Default, value)
 	fs.addFlag(flag)
 	if float64Opts.Default != "" {
 		_ = value.Set(float64Opts.Default)
 	}
 }
 
+// Var registers a flag backed by a custom Value implementation, such as a
+// semantic version or log level type. Accepts a FlagOptions struct and/or
+// functional options describing the flag.
+//
+// If value implements DefaultSetter, its default is applied and recorded.
+// Values that also implement SetBool(bool) error are treated as boolean flags,
+// so they can be set with a bare --flag and no argument.
+//
+//	var level LogLevel
+//	cmd.Flags.Var(&level, clix.FlagOptions{
+//		Name:  "log-level",
+//		Usage: "Log level (debug, info, warn, error)",
+//	})
+func (fs *FlagSet) Var(value Value, opts ...FlagOption) {
+	var flagOpts FlagOptions
+	for _, opt := range opts {
+		opt.ApplyFlag(&flagOpts)
+	}
+	var def string
+	if ds, ok := value.(DefaultSetter); ok {
+		def = ds.DefaultValue()
+	}
+	flag := newFlag(flagOpts, def, value)
+	fs.addFlag(flag)
+	if def != "" {
+		_ = value.Set(def)
+	}
+}
+
 func (fs *FlagSet) addFlag(flag *Flag) {
 	if flag.Name == "" {
 		panic("flag requires a name")
@@ -732,26 +1320,91 @@ func (fs *FlagSet) addFlag(flag *Flag) {
 		if _, ok := flag.Value.(boolFlag); ok {
 			panic("boolean flag cannot be positional: " + flag.Name)
 		}
+		for _, existing := range fs.flags {
+			if existing.Positional && existing.Variadic {
+				panic(fmt.Sprintf("positional flag %s registered after variadic flag %s", flag.Name, existing.Name))
+			}
+		}
+	} else if flag.Variadic {
+		panic("variadic flag must be positional: " + flag.Name)
 	}
 	if fs.index == nil {
 		fs.index = make(map[string]*Flag)
 	}
+	fs.checkConflicts(flag)
+	flag.initial = flag.Value.String()
 	fs.flags = append(fs.flags, flag)
 	fs.index["--"+flag.Name] = flag
+	for _, alias := range flag.Aliases {
+		fs.index["--"+alias] = flag
+	}
 	if flag.Short != "" {
 		fs.index["-"+flag.Short] = flag
 	}
 }
 
+// checkConflicts panics if the long name, an alias or the short name of flag
+// is already registered in the set. Silently overwriting the index would make
+// one of the two flags unreachable.
+func (fs *FlagSet) checkConflicts(flag *Flag) {
+	keys := []string{"--" + flag.Name}
+	for _, alias := range flag.Aliases {
+		keys = append(keys, "--"+alias)
+	}
+	if flag.Short != "" {
+		keys = append(keys, "-"+flag.Short)
+	}
+	for _, key := range keys {
+		if existing, ok := fs.index[key]; ok {
+			panic(fmt.Sprintf("flag redefined: %s (registering --%s, already used by --%s)", key, flag.Name, existing.Name))
+		}
+	}
+}
+
+// Lookup returns the flag registered under name, which may be its long name
+// or one of its aliases, or nil if there is none. Registering a name that is
+// already taken panics, so extensions that add flags to a set they do not own
+// should check Lookup and LookupShort first.
+func (fs *FlagSet) Lookup(name string) *Flag {
+	return fs.lookup(name)
+}
+
+// LookupShort returns the flag registered under the single-character short
+// name, or nil if there is none.
+func (fs *FlagSet) LookupShort(short string) *Flag {
+	if short == "" {
+		return nil
+	}
+	return fs.index["-"+short]
+}
+
+// lookup finds a flag by its long name or one of its aliases.
 func (fs *FlagSet) lookup(name string) *Flag {
 	for _, flag := range fs.flags {
 		if flag.Name == name {
 			return flag
 		}
 	}
+	for _, flag := range fs.flags {
+		for _, alias := range flag.Aliases {
+			if alias == name {
+				return flag
+			}
+		}
+	}
 	return nil
 }
 
+// Reset clears the explicitly-set state of every flag and restores each flag's
+// default value. App.Run calls Reset on every command's flag set at the start
+// of each invocation so state from a previous run does not leak into the next.
+// Flags without a Default are restored to the value they held at registration.
+func (fs *FlagSet) Reset() {
+	for _, flag := range fs.flags {
+		flag.reset()
+	}
+}
+
 // Flags returns all registered flags.
 func (fs *FlagSet) Flags() []*Flag {
 	return append([]*Flag(nil), fs.flags...)
@@ -774,6 +1427,16 @@ func WithFlagUsage(usage string) FlagOption {
 	return flagUsageOption(usage)
 }
 
+// WithFlagAliases sets additional long names for the flag.
+func WithFlagAliases(aliases ...string) FlagOption {
+	return flagAliasesOption(aliases)
+}
+
+// WithFlagCategory sets the help heading the flag is grouped under.
+func WithFlagCategory(category string) FlagOption {
+	return flagCategoryOption(category)
+}
+
 // WithFlagEnvVar sets the flag environment variable name.
 func WithFlagEnvVar(envVar string) FlagOption {
 	return flagEnvVarOption(envVar)
@@ -799,11 +1462,43 @@ func WithFlagPositional() FlagOption {
 	return flagPositionalOption(true)
 }
 
+// WithFlagVariadic makes a positional flag consume all remaining arguments.
+func WithFlagVariadic() FlagOption {
+	return flagVariadicOption(true)
+}
+
 // WithFlagValidate sets a custom validation function for the flag value.
 func WithFlagValidate(fn func(string) error) FlagOption {
 	return flagValidateOption{fn: fn}
 }
 
+// WithFlagValidateCtx sets a validation function that runs once all flags and
+// positional arguments have been collected. See FlagOptions.ValidateCtx.
+func WithFlagValidateCtx(fn func(ctx *Context, value string) error) FlagOption {
+	return flagValidateCtxOption{fn: fn}
+}
+
+// WithFlagComplete sets a function that supplies dynamic completion
+// candidates for the flag value.
+func WithFlagComplete(fn func(ctx *Context, toComplete string) []string) FlagOption {
+	return flagCompleteOption{fn: fn}
+}
+
+// WithFlagDeprecated marks the flag as deprecated with the given message.
+func WithFlagDeprecated(message string) FlagOption {
+	return flagDeprecatedOption(message)
+}
+
+// WithFlagDeprecatedReplacement names the flag that replaces a deprecated flag.
+func WithFlagDeprecatedReplacement(name string) FlagOption {
+	return flagDeprecatedReplacementOption(name)
+}
+
+// WithFlagHidden hides the flag from help output.
+func WithFlagHidden() FlagOption {
+	return flagHiddenOption(true)
+}
+
 // WithStringValue sets the string flag value pointer.
 func WithStringValue(value *string) FlagOption {
 	return stringValueOption{value: value}
@@ -814,6 +1509,11 @@ func WithStringDefault(defaultValue string) FlagOption {
 	return stringDefaultOption(defaultValue)
 }
 
+// WithStringFromFile enables @-syntax so "@path" reads the value from a file.
+func WithStringFromFile() FlagOption {
+	return stringFromFileOption(true)
+}
+
 // WithBoolValue sets the bool flag value pointer.
 func WithBoolValue(value *bool) FlagOption {
 	return boolValueOption{value: value}
@@ -839,6 +1539,51 @@ func WithInt64Default(defaultValue string) FlagOption {
 	return int64DefaultOption(defaultValue)
 }
 
+// WithBytesValue sets the byte-size flag value pointer.
+func WithBytesValue(value *int64) FlagOption {
+	return bytesValueOption{value: value}
+}
+
+// WithBytesDefault sets the byte-size flag default value (e.g., "10MB").
+func WithBytesDefault(defaultValue string) FlagOption {
+	return bytesDefaultOption(defaultValue)
+}
+
+// WithTimestampValue sets the timestamp flag value pointer.
+func WithTimestampValue(value *time.Time) FlagOption {
+	return timestampValueOption{value: value}
+}
+
+// WithTimestampDefault sets the timestamp flag default value.
+func WithTimestampDefault(defaultValue string) FlagOption {
+	return timestampDefaultOption(defaultValue)
+}
+
+// WithTimestampLayouts sets the layouts tried, in order, when parsing a timestamp.
+func WithTimestampLayouts(layouts ...string) FlagOption {
+	return timestampLayoutsOption(layouts)
+}
+
+// WithUintValue sets the uint flag value pointer.
+func WithUintValue(value *uint) FlagOption {
+	return uintValueOption{value: value}
+}
+
+// WithUintDefault sets the uint flag default value.
+func WithUintDefault(defaultValue string) FlagOption {
+	return uintDefaultOption(defaultValue)
+}
+
+// WithFloat32Value sets the float32 flag value pointer.
+func WithFloat32Value(value *float32) FlagOption {
+	return float32ValueOption{value: value}
+}
+
+// WithFloat32Default sets the float32 flag default value.
+func WithFloat32Default(defaultValue string) FlagOption {
+	return float32DefaultOption(defaultValue)
+}
+
 // WithFloat64Value sets the float64 flag value pointer.
 func WithFloat64Value(value *float64) FlagOption {
 	return float64ValueOption{value: value}
@@ -879,6 +1624,18 @@ func (o flagUsageOption) ApplyFlag(fo *FlagOptions) {
 	fo.Usage = string(o)
 }
 
+type flagAliasesOption []string
+
+func (o flagAliasesOption) ApplyFlag(fo *FlagOptions) {
+	fo.Aliases = []string(o)
+}
+
+type flagCategoryOption string
+
+func (o flagCategoryOption) ApplyFlag(fo *FlagOptions) {
+	fo.Category = string(o)
+}
+
 type flagEnvVarOption string
 
 func (o flagEnvVarOption) ApplyFlag(fo *FlagOptions) {
@@ -909,6 +1666,12 @@ func (o flagPositionalOption) ApplyFlag(fo *FlagOptions) {
 	fo.Positional = bool(o)
 }
 
+type flagVariadicOption bool
+
+func (o flagVariadicOption) ApplyFlag(fo *FlagOptions) {
+	fo.Variadic = bool(o)
+}
+
 type flagValidateOption struct {
 	fn func(string) error
 }
@@ -917,6 +1680,40 @@ func (o flagValidateOption) ApplyFlag(fo *FlagOptions) {
 	fo.Validate = o.fn
 }
 
+type flagValidateCtxOption struct {
+	fn func(ctx *Context, value string) error
+}
+
+func (o flagValidateCtxOption) ApplyFlag(fo *FlagOptions) {
+	fo.ValidateCtx = o.fn
+}
+
+type flagCompleteOption struct {
+	fn func(ctx *Context, toComplete string) []string
+}
+
+func (o flagCompleteOption) ApplyFlag(fo *FlagOptions) {
+	fo.Complete = o.fn
+}
+
+type flagDeprecatedOption string
+
+func (o flagDeprecatedOption) ApplyFlag(fo *FlagOptions) {
+	fo.Deprecated = string(o)
+}
+
+type flagDeprecatedReplacementOption string
+
+func (o flagDeprecatedReplacementOption) ApplyFlag(fo *FlagOptions) {
+	fo.DeprecatedReplacement = string(o)
+}
+
+type flagHiddenOption bool
+
+func (o flagHiddenOption) ApplyFlag(fo *FlagOptions) {
+	fo.Hidden = bool(o)
+}
+
 type boolValueOption struct {
 	value *bool
 }
@@ -943,6 +1740,50 @@ type int64DefaultOption string
 
 func (o int64DefaultOption) ApplyFlag(*FlagOptions) {}
 
+type bytesValueOption struct {
+	value *int64
+}
+
+func (o bytesValueOption) ApplyFlag(*FlagOptions) {}
+
+type bytesDefaultOption string
+
+func (o bytesDefaultOption) ApplyFlag(*FlagOptions) {}
+
+type timestampValueOption struct {
+	value *time.Time
+}
+
+func (o timestampValueOption) ApplyFlag(*FlagOptions) {}
+
+type timestampDefaultOption string
+
+func (o timestampDefaultOption) ApplyFlag(*FlagOptions) {}
+
+type timestampLayoutsOption []string
+
+func (o timestampLayoutsOption) ApplyFlag(*FlagOptions) {}
+
+type uintValueOption struct {
+	value *uint
+}
+
+func (o uintValueOption) ApplyFlag(*FlagOptions) {}
+
+type uintDefaultOption string
+
+func (o uintDefaultOption) ApplyFlag(*FlagOptions) {}
+
+type float32ValueOption struct {
+	value *float32
+}
+
+func (o float32ValueOption) ApplyFlag(*FlagOptions) {}
+
+type float32DefaultOption string
+
+func (o float32DefaultOption) ApplyFlag(*FlagOptions) {}
+
 type float64ValueOption struct {
 	value *float64
 }
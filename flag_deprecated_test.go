@@ -0,0 +1,81 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func newDeprecatedFlagApp(t *testing.T) (*App, *bytes.Buffer, *string, *string) {
+	t.Helper()
+	app := NewApp("demo")
+	app.configLoaded = true
+	var errOut bytes.Buffer
+	app.Err = &errOut
+
+	var oldValue, newValue string
+	cmd := NewCommand("deploy")
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{
+			Name:                  "old",
+			Deprecated:            `use "--new"`,
+			DeprecatedReplacement: "new",
+		},
+		Default: "fallback",
+		Value:   &oldValue,
+	})
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{Name: "new"},
+		Value:       &newValue,
+	})
+	cmd.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(cmd)
+	return app, &errOut, &oldValue, &newValue
+}
+
+func TestDeprecatedFlagWarnsOnExplicitUse(t *testing.T) {
+	app, errOut, _, newValue := newDeprecatedFlagApp(t)
+
+	if err := app.Run(context.Background(), []string{"deploy", "--old", "v1"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	want := `flag --old is deprecated: use "--new"`
+	if got := strings.Count(errOut.String(), want); got != 1 {
+		t.Fatalf("expected warning exactly once, got %d in %q", got, errOut.String())
+	}
+	if *newValue != "v1" {
+		t.Errorf("expected value to be forwarded to --new, got %q", *newValue)
+	}
+}
+
+func TestDeprecatedFlagDoesNotOverrideExplicitReplacement(t *testing.T) {
+	app, _, _, newValue := newDeprecatedFlagApp(t)
+
+	if err := app.Run(context.Background(), []string{"deploy", "--new", "v2", "--old", "v1"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if *newValue != "v2" {
+		t.Errorf("expected explicit --new to win, got %q", *newValue)
+	}
+}
+
+func TestDeprecatedFlagSilentOnDefaultAndConfig(t *testing.T) {
+	app, errOut, oldValue, _ := newDeprecatedFlagApp(t)
+
+	if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if *oldValue != "fallback" {
+		t.Errorf("expected default to be applied, got %q", *oldValue)
+	}
+
+	app.Config.Set("old", "from-config")
+	if err := app.Run(context.Background(), []string{"deploy"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Fatalf("expected no deprecation warning, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,67 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors identifying why a flag could not be parsed. Errors returned
+// by FlagSet.Parse and App.Run wrap one of them in a *FlagError:
+//
+//	err := app.Run(ctx, args)
+//	if errors.Is(err, clix.ErrUnknownFlag) {
+//		// show usage
+//	}
+var (
+	// ErrUnknownFlag reports a flag that is not defined on the command.
+	ErrUnknownFlag = errors.New("unknown flag")
+	// ErrMissingValue reports a non-boolean flag given without a value.
+	ErrMissingValue = errors.New("flag requires a value")
+	// ErrInvalidValue reports a value rejected by the flag's type or Validate.
+	ErrInvalidValue = errors.New("invalid flag value")
+)
+
+// FlagError describes a flag that could not be parsed or validated. It
+// matches its Kind with errors.Is and unwraps to the underlying cause.
+type FlagError struct {
+	// Flag is the flag's name without dashes. For unknown flags it is the
+	// token as typed, e.g. "--colour".
+	Flag string
+	// Kind is ErrUnknownFlag, ErrMissingValue or ErrInvalidValue.
+	Kind error
+	// Err is the underlying error for invalid values, if any.
+	Err error
+
+	msg string
+}
+
+func (e *FlagError) Error() string {
+	return e.msg
+}
+
+// Is reports whether target is the error's Kind.
+func (e *FlagError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+func unknownFlagError(token, suggestion string) *FlagError {
+	msg := "unknown flag: " + token
+	if suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %s?)", suggestion)
+	}
+	return &FlagError{Flag: token, Kind: ErrUnknownFlag, msg: msg}
+}
+
+func missingValueError(name, msg string) *FlagError {
+	return &FlagError{Flag: name, Kind: ErrMissingValue, msg: msg}
+}
+
+// invalidValueError reports err for the flag described by label, such as
+// "--port" or "positional argument file".
+func invalidValueError(name, label string, err error) *FlagError {
+	return &FlagError{Flag: name, Kind: ErrInvalidValue, Err: err, msg: fmt.Sprintf("invalid value for %s: %v", label, err)}
+}
@@ -0,0 +1,74 @@
+package clix
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParseErrorsAreTyped(t *testing.T) {
+	newSet := func() *FlagSet {
+		fs := NewFlagSet("test")
+		fs.strict = true
+		var port int
+		var verbose bool
+		fs.IntVar(IntVarOptions{FlagOptions: FlagOptions{Name: "port", Short: "p"}, Value: &port})
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose", Short: "v"}, Value: &verbose})
+		return fs
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		kind     error
+		flag     string
+		wantText string
+	}{
+		{name: "unknown flag", args: []string{"--prot", "80"}, kind: ErrUnknownFlag, flag: "--prot", wantText: "unknown flag: --prot (did you mean --port?)"},
+		{name: "missing value", args: []string{"--port"}, kind: ErrMissingValue, flag: "port", wantText: "flag --port requires a value"},
+		{name: "missing value in short group", args: []string{"-vpv"}, kind: ErrMissingValue, flag: "port", wantText: "flag -p in -vpv requires a value and must be last in the group"},
+		{name: "missing value for last short flag", args: []string{"-vp"}, kind: ErrMissingValue, flag: "port", wantText: "flag -p requires a value"},
+		{name: "invalid value", args: []string{"--port", "eighty"}, kind: ErrInvalidValue, flag: "port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newSet().Parse(tt.args)
+			if !errors.Is(err, tt.kind) {
+				t.Fatalf("error = %v, want %v", err, tt.kind)
+			}
+			var flagErr *FlagError
+			if !errors.As(err, &flagErr) {
+				t.Fatalf("error %T is not a *FlagError", err)
+			}
+			if flagErr.Flag != tt.flag {
+				t.Errorf("Flag = %q, want %q", flagErr.Flag, tt.flag)
+			}
+			if tt.wantText != "" && err.Error() != tt.wantText {
+				t.Errorf("message = %q, want %q", err.Error(), tt.wantText)
+			}
+			for _, other := range []error{ErrUnknownFlag, ErrMissingValue, ErrInvalidValue} {
+				if other != tt.kind && errors.Is(err, other) {
+					t.Errorf("error also matches %v", other)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidValueUnwrapsCause(t *testing.T) {
+	fs := NewFlagSet("test")
+	var port int
+	fs.IntVar(IntVarOptions{FlagOptions: FlagOptions{Name: "port", Positional: true}, Value: &port})
+
+	_, err := fs.MapPositionals([]string{"eighty"})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("error = %v, want ErrInvalidValue", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("error %v does not unwrap to strconv.ErrSyntax", err)
+	}
+	var flagErr *FlagError
+	if !errors.As(err, &flagErr) || flagErr.Flag != "port" {
+		t.Errorf("FlagError = %+v, want Flag port", flagErr)
+	}
+}
@@ -3,6 +3,7 @@ package clix
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -138,3 +139,56 @@ func TestNumberVarWithConfigAndEnv(t *testing.T) {
 		t.Errorf("expected port from flag 5000, got %d", port)
 	}
 }
+
+func TestUintVar(t *testing.T) {
+	var workers uint
+	fs := NewFlagSet("test")
+	fs.UintVar(UintVarOptions{
+		FlagOptions: FlagOptions{Name: "workers"},
+		Default:     "4",
+		Value:       &workers,
+	})
+
+	if workers != 4 {
+		t.Errorf("expected default workers 4, got %d", workers)
+	}
+	if _, err := fs.Parse([]string{"--workers", "16"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if val, ok := fs.Uint("workers"); !ok || val != 16 {
+		t.Errorf("Uint returned %d, %v, expected 16, true", val, ok)
+	}
+
+	for _, input := range []string{"-1", "18446744073709551616", "many"} {
+		if _, err := fs.Parse([]string{"--workers", input}); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+	if _, err := fs.Parse([]string{"--workers", "18446744073709551616"}); err == nil || !strings.Contains(err.Error(), "out of range for uint") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+}
+
+func TestFloat32Var(t *testing.T) {
+	var ratio float32
+	fs := NewFlagSet("test")
+	fs.Float32Var(
+		WithFlagName("ratio"),
+		WithFloat32Value(&ratio),
+		WithFloat32Default("0.25"),
+	)
+
+	if ratio != 0.25 {
+		t.Errorf("expected default ratio 0.25, got %v", ratio)
+	}
+	if _, err := fs.Parse([]string{"--ratio", "0.75"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if val, ok := fs.Float32("ratio"); !ok || val != 0.75 {
+		t.Errorf("Float32 returned %v, %v, expected 0.75, true", val, ok)
+	}
+
+	if _, err := fs.Parse([]string{"--ratio", "1e40"}); err == nil || !strings.Contains(err.Error(), "out of range for float32") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+}
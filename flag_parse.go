@@ -3,18 +3,27 @@ package clix
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // Parse processes the provided arguments against the flag set, consuming flags
 // and returning remaining positional arguments. Flags can appear in multiple formats:
 //
 //   - Long form: --flag=value or --flag value
-//   - Short form: -f=value or -f value
+//   - Short form: -f=value, -f value or -fvalue (e.g. -p8080)
 //   - Boolean flags: --flag or -f (no value needed, sets to true)
+//   - Bundled short flags: -abc is equivalent to -a -b -c. Only the last flag
+//     in a bundle may take a value (e.g. -abo out.txt or -abo=out.txt).
 //   - End of flags: -- (everything after is treated as positional)
 //
-// By default, unknown flags cause errors. Use SetStrict(false) to allow unknown
-// flags to be treated as positional arguments instead.
+// Flags may appear before or after positional arguments unless
+// SetInterspersed(false) is used, in which case parsing stops at the first
+// positional argument.
+//
+// By default, unknown flags cause errors. When an unknown long flag is within
+// two edits of a registered name, the error suggests it (e.g. "did you mean
+// --verbose?"). Use SetStrict(false) to allow unknown flags to be treated as
+// positional arguments instead.
 func (fs *FlagSet) Parse(args []string) ([]string, error) {
 	rest := args
 	var positionals []string
@@ -27,6 +36,10 @@ func (fs *FlagSet) Parse(args []string) ([]string, error) {
 		}
 
 		if !strings.HasPrefix(current, "-") || current == "-" {
+			if !fs.interspersed {
+				positionals = append(positionals, rest...)
+				break
+			}
 			positionals = append(positionals, current)
 			rest = rest[1:]
 			continue
@@ -36,8 +49,23 @@ func (fs *FlagSet) Parse(args []string) ([]string, error) {
 
 		flag, ok := fs.index[name]
 		if !ok {
+			if flag, attached, ok := fs.attachedShort(current); ok {
+				if err := fs.setValue(flag, attached); err != nil {
+					return nil, err
+				}
+				rest = rest[1:]
+				continue
+			}
+			if bundle := fs.shortBundle(name); bundle != nil {
+				var err error
+				rest, err = fs.parseShortBundle(name, bundle, value, hasValue, rest[1:])
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
 			if fs.strict {
-				return nil, fmt.Errorf("unknown flag: %s", name)
+				return nil, unknownFlagError(name, fs.suggestFlag(name))
 			}
 			positionals = append(positionals, current)
 			rest = rest[1:]
@@ -48,32 +76,190 @@ func (fs *FlagSet) Parse(args []string) ([]string, error) {
 
 		if !hasValue {
 			if bf, ok := flag.Value.(boolFlag); ok {
-				if err := bf.SetBool(true); err != nil {
+				if err := fs.setBool(flag, bf); err != nil {
 					return nil, err
 				}
-				flag.set = true
-				flag.cliSet = true
 				continue
 			}
 
 			if len(rest) == 0 {
-				return nil, fmt.Errorf("flag %s requires a value", name)
+				return nil, missingValueError(flag.Name, fmt.Sprintf("flag %s requires a value", name))
 			}
 			value = rest[0]
 			rest = rest[1:]
 		}
 
-		if err := flag.Value.Set(value); err != nil {
-			return nil, fmt.Errorf("invalid value for %s: %w", flag.Name, err)
+		if err := fs.setValue(flag, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return positionals, nil
+}
+
+// parseLeading parses only the flags before the first positional argument,
+// as if interspersing were disabled, and returns that argument together with
+// everything after it.
+func (fs *FlagSet) parseLeading(args []string) ([]string, error) {
+	leading := *fs
+	leading.interspersed = false
+	return leading.Parse(args)
+}
+
+// attachedShort recognizes a single-dash token whose first letter is a
+// registered non-boolean short flag, such as "-p8080", and returns that flag
+// with the rest of the token as its value. Tokens starting with a boolean
+// short flag are left to shortBundle.
+func (fs *FlagSet) attachedShort(token string) (*Flag, string, bool) {
+	if strings.HasPrefix(token, "--") || len(token) < 3 {
+		return nil, "", false
+	}
+	letter, size := utf8.DecodeRuneInString(token[1:])
+	flag, ok := fs.index["-"+string(letter)]
+	if !ok {
+		return nil, "", false
+	}
+	if _, isBool := flag.Value.(boolFlag); isBool {
+		return nil, "", false
+	}
+	return flag, token[1+size:], true
+}
+
+// shortBundle resolves a single-dash token such as "-abc" into the short flags
+// it bundles. It returns nil when the token is not a bundle or when any of its
+// letters is not a registered short flag.
+func (fs *FlagSet) shortBundle(name string) []*Flag {
+	if strings.HasPrefix(name, "--") || len(name) < 3 {
+		return nil
+	}
+	letters := name[1:]
+	bundle := make([]*Flag, 0, len(letters))
+	for _, r := range letters {
+		flag, ok := fs.index["-"+string(r)]
+		if !ok {
+			return nil
+		}
+		bundle = append(bundle, flag)
+	}
+	return bundle
+}
+
+// parseShortBundle applies each flag in a bundle. Every flag except the last
+// must be boolean; the last may consume a value from "=value" or the next
+// argument. It returns the arguments left after the bundle.
+func (fs *FlagSet) parseShortBundle(token string, bundle []*Flag, value string, hasValue bool, rest []string) ([]string, error) {
+	for i, flag := range bundle {
+		last := i == len(bundle)-1
+		bf, isBool := flag.Value.(boolFlag)
+		if !last {
+			if !isBool {
+				return nil, missingValueError(flag.Name, fmt.Sprintf("flag -%s in %s requires a value and must be last in the group", flag.Short, token))
+			}
+			if err := fs.setBool(flag, bf); err != nil {
+				return nil, err
+			}
+			continue
 		}
-		if flag.Validate != nil {
-			if err := flag.Validate(value); err != nil {
-				return nil, fmt.Errorf("invalid value for %s: %w", flag.Name, err)
+
+		if isBool && !hasValue {
+			if err := fs.setBool(flag, bf); err != nil {
+				return nil, err
 			}
+			continue
+		}
+		if !hasValue {
+			if len(rest) == 0 {
+				return nil, missingValueError(flag.Name, fmt.Sprintf("flag -%s requires a value", flag.Short))
+			}
+			value = rest[0]
+			rest = rest[1:]
+		}
+		if err := fs.setValue(flag, value); err != nil {
+			return nil, err
 		}
-		flag.set = true
-		flag.cliSet = true
 	}
+	return rest, nil
+}
 
-	return positionals, nil
+// setBool marks a boolean flag as true from the command line.
+func (fs *FlagSet) setBool(flag *Flag, bf boolFlag) error {
+	if err := bf.SetBool(true); err != nil {
+		return err
+	}
+	flag.markCLISet()
+	return nil
+}
+
+// setValue parses a command-line value for flag and runs its Validate hook.
+func (fs *FlagSet) setValue(flag *Flag, value string) error {
+	if err := flag.Value.Set(value); err != nil {
+		return invalidValueError(flag.Name, "--"+flag.Name, err)
+	}
+	if flag.Validate != nil {
+		if err := flag.Validate(parsedValue(flag, value)); err != nil {
+			return invalidValueError(flag.Name, "--"+flag.Name, err)
+		}
+	}
+	flag.markCLISet()
+	return nil
+}
+
+// parsedValue returns the text flag stands for once value has been Set, which
+// is what Validate checks and what an unset flag's default resolves to: the
+// contents a WithStringFromFile flag read for "@path", and value itself for
+// every other flag.
+func parsedValue(flag *Flag, value string) string {
+	if s, ok := flag.Value.(*StringValue); ok && s.fromFile {
+		return s.String()
+	}
+	return value
+}
+
+// maxSuggestionDistance is the largest edit distance for which an unknown flag
+// gets a "did you mean" suggestion.
+const maxSuggestionDistance = 2
+
+// suggestFlag returns the visible long flag (including aliases) closest to
+// name, or "" if name is not a long flag or nothing is close enough. Hidden
+// flags are never suggested. When App.Run parses a command, fs also holds the
+// inherited persistent flags and the app flags, so those are candidates too.
+func (fs *FlagSet) suggestFlag(name string) string {
+	if !strings.HasPrefix(name, "--") {
+		return ""
+	}
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, flag := range fs.flags {
+		if flag.Hidden {
+			continue
+		}
+		candidates := append([]string{flag.Name}, flag.Aliases...)
+		for _, candidate := range candidates {
+			if d := levenshtein(name[2:], candidate); d < bestDistance {
+				best, bestDistance = "--"+candidate, d
+			}
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
 }
@@ -1,7 +1,5 @@
 package clix
 
-import "fmt"
-
 // PositionalFlags returns flags marked Positional: true in registration order.
 func (fs *FlagSet) PositionalFlags() []*Flag {
 	var out []*Flag
@@ -16,8 +14,8 @@ func (fs *FlagSet) PositionalFlags() []*Flag {
 // MapPositionals assigns leftover positional args to Positional flags
 // in registration order. Flags already set via --flag (cliSet == true)
 // are skipped. Successfully mapped flags get cliSet = true and set = true
-// so three-way mode detection works correctly. Excess unmapped args are
-// returned.
+// so three-way mode detection works correctly. A Variadic flag consumes
+// every remaining arg. Excess unmapped args are returned.
 func (fs *FlagSet) MapPositionals(args []string) ([]string, error) {
 	positionals := fs.PositionalFlags()
 	argIdx := 0
@@ -28,17 +26,29 @@ func (fs *FlagSet) MapPositionals(args []string) ([]string, error) {
 		if f.cliSet {
 			continue
 		}
-		if err := f.Value.Set(args[argIdx]); err != nil {
-			return nil, fmt.Errorf("invalid value for positional argument %s: %w", f.Name, err)
+		end := argIdx + 1
+		if f.Variadic {
+			end = len(args)
 		}
-		if f.Validate != nil {
-			if err := f.Validate(args[argIdx]); err != nil {
-				return nil, fmt.Errorf("invalid value for positional argument %s: %w", f.Name, err)
+		for _, arg := range args[argIdx:end] {
+			if err := setPositional(f, arg); err != nil {
+				return nil, err
 			}
 		}
-		f.set = true
-		f.cliSet = true
-		argIdx++
+		f.markCLISet()
+		argIdx = end
 	}
 	return args[argIdx:], nil
 }
+
+func setPositional(f *Flag, arg string) error {
+	if err := f.Value.Set(arg); err != nil {
+		return invalidValueError(f.Name, "positional argument "+f.Name, err)
+	}
+	if f.Validate != nil {
+		if err := f.Validate(parsedValue(f, arg)); err != nil {
+			return invalidValueError(f.Name, "positional argument "+f.Name, err)
+		}
+	}
+	return nil
+}
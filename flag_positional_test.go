@@ -1,7 +1,12 @@
 package clix
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"testing"
 )
@@ -387,3 +392,114 @@ func TestBooleanPositionalPanics(t *testing.T) {
 		Value:       &b,
 	})
 }
+
+func TestRequiredPositionalPrompting(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	newApp := func(in io.Reader) (*App, *string, *bytes.Buffer) {
+		var name string
+		out := &bytes.Buffer{}
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Out = out
+		app.Prompter = TextPrompter{In: in, Out: out}
+
+		cmd := NewCommand("greet")
+		cmd.Flags.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{
+				Name:       "name",
+				Prompt:     "Who to greet",
+				Required:   true,
+				Positional: true,
+				Validate: func(s string) error {
+					if len(s) < 2 {
+						return fmt.Errorf("too short")
+					}
+					return nil
+				},
+			},
+			Value: &name,
+		})
+		cmd.Run = func(*Context) error { return nil }
+		app.Root.AddCommand(cmd)
+		return app, &name, out
+	}
+
+	t.Run("fills the argument interactively", func(t *testing.T) {
+		app, name, out := newApp(strings.NewReader("a\nalice\n"))
+		if err := app.Run(context.Background(), []string{"greet"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if *name != "alice" {
+			t.Errorf("name = %q, want alice", *name)
+		}
+		if got := out.String(); !strings.Contains(got, "Who to greet") || !strings.Contains(got, "too short") {
+			t.Errorf("prompt output = %q, want the label and a validation retry", got)
+		}
+	})
+
+	t.Run("auto mode errors when input is not a terminal", func(t *testing.T) {
+		devNull, err := os.Open(os.DevNull)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer devNull.Close()
+
+		app, _, _ := newApp(devNull)
+		err = app.Run(context.Background(), []string{"greet"})
+		if !errors.Is(err, ErrNonInteractive) {
+			t.Fatalf("error = %v, want ErrNonInteractive", err)
+		}
+	})
+}
+
+func TestOptionalPositionalDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	type got struct{ name, title, team, ctxTitle, ctxTeam string }
+	run := func(t *testing.T, args ...string) got {
+		t.Helper()
+		var g got
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Out = &bytes.Buffer{}
+		app.Err = &bytes.Buffer{}
+
+		cmd := NewCommand("hire")
+		cmd.Flags.StringVar(WithFlagName("name"), WithFlagPositional(), WithFlagRequired(), WithStringValue(&g.name))
+		cmd.Flags.StringVar(WithFlagName("title"), WithFlagPositional(), WithStringDefault("Developer"), WithStringValue(&g.title))
+		cmd.Flags.StringVar(WithFlagName("team"), WithFlagPositional(), WithStringDefault("core"), WithStringValue(&g.team))
+		cmd.Run = func(ctx *Context) error {
+			g.ctxTitle, _ = ctx.String("title")
+			g.ctxTeam, _ = ctx.String("team")
+			return nil
+		}
+		app.Root.AddCommand(cmd)
+
+		if err := app.Run(context.Background(), append([]string{"hire"}, args...)); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return g
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want got
+	}{
+		{"all omitted", []string{"ada"}, got{"ada", "Developer", "core", "Developer", "core"}},
+		{"trailing omitted", []string{"ada", "Manager"}, got{"ada", "Manager", "core", "Manager", "core"}},
+		{"all given", []string{"ada", "Manager", "infra"}, got{"ada", "Manager", "infra", "Manager", "infra"}},
+		// A positional given as a flag is skipped, so the next arg fills the
+		// following position rather than shifting onto it.
+		{"middle given as flag", []string{"ada", "--title", "Lead", "infra"}, got{"ada", "Lead", "infra", "Lead", "infra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if g := run(t, tt.args...); g != tt.want {
+				t.Errorf("got %+v, want %+v", g, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package clix
+
+import "strings"
+
+// StringSliceVarOptions describes the configuration for adding a string slice
+// flag. Every occurrence of the flag (or, for variadic positionals, every
+// argument) appends one value; the first value given replaces the default.
+// This struct implements FlagOption, so it can be used alongside functional options.
+//
+// Example:
+//
+//	var files []string
+//	cmd.Flags.StringSliceVar(clix.StringSliceVarOptions{
+//		FlagOptions: clix.FlagOptions{
+//			Name:       "files",
+//			Usage:      "Files to process",
+//			Positional: true,
+//			Variadic:   true,
+//		},
+//		Value: &files,
+//	})
+type StringSliceVarOptions struct {
+	FlagOptions
+	// Default is the value used when the flag is not provided.
+	Default []string
+	// Value is a pointer to the slice that will store the flag values.
+	Value *[]string
+}
+
+// ApplyFlag implements FlagOption so StringSliceVarOptions can be used directly.
+func (o StringSliceVarOptions) ApplyFlag(fo *FlagOptions) {
+	o.FlagOptions.ApplyFlag(fo)
+}
+
+// StringSliceVar registers a string slice flag. Accepts either a
+// StringSliceVarOptions struct (primary API) or functional options.
+//
+//	cmd.Flags.StringSliceVar(
+//		clix.WithFlagName("tag"),
+//		clix.WithStringSliceValue(&tags),
+//	)
+func (fs *FlagSet) StringSliceVar(opts ...FlagOption) {
+	var sliceOpts StringSliceVarOptions
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case StringSliceVarOptions:
+			sliceOpts = v
+		case stringSliceValueOption:
+			sliceOpts.Value = v.value
+		case stringSliceDefaultOption:
+			sliceOpts.Default = []string(v)
+		default:
+			opt.ApplyFlag(&sliceOpts.FlagOptions)
+		}
+	}
+	target := sliceOpts.Value
+	if target == nil {
+		target = new([]string)
+	}
+	value := &StringSliceValue{target: target, def: append([]string(nil), sliceOpts.Default...)}
+	value.resetValue()
+	fs.addFlag(newFlag(sliceOpts.FlagOptions, "", value))
+}
+
+// WithStringSliceValue sets the slice that receives a string slice flag's values.
+func WithStringSliceValue(value *[]string) FlagOption {
+	return stringSliceValueOption{value: value}
+}
+
+// WithStringSliceDefault sets the default values of a string slice flag.
+func WithStringSliceDefault(values ...string) FlagOption {
+	return stringSliceDefaultOption(values)
+}
+
+// stringSliceValueOption is an internal type for string slice flag values.
+type stringSliceValueOption struct {
+	value *[]string
+}
+
+// stringSliceDefaultOption is an internal type for string slice flag defaults.
+type stringSliceDefaultOption []string
+
+// ApplyFlag implements FlagOption for stringSliceValueOption.
+func (o stringSliceValueOption) ApplyFlag(*FlagOptions) {}
+
+// ApplyFlag implements FlagOption for stringSliceDefaultOption.
+func (o stringSliceDefaultOption) ApplyFlag(*FlagOptions) {}
+
+// StringSliceValue implements Value for string slice flags. Set appends; the
+// first call after registration or a reset replaces the default values.
+type StringSliceValue struct {
+	target   *[]string
+	def      []string
+	explicit bool
+}
+
+func (s *StringSliceValue) Set(value string) error {
+	if !s.explicit {
+		*s.target = nil
+		s.explicit = true
+	}
+	*s.target = append(*s.target, value)
+	return nil
+}
+
+func (s *StringSliceValue) String() string {
+	return strings.Join(*s.target, ",")
+}
+
+func (s *StringSliceValue) resetValue() {
+	*s.target = append([]string(nil), s.def...)
+	s.explicit = false
+}
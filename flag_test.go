@@ -1,6 +1,8 @@
 package clix
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -164,3 +166,258 @@ func TestFlagSetParseMissingValue(t *testing.T) {
 		t.Fatalf("unexpected error message: %v", err)
 	}
 }
+
+func TestFlagSetReset(t *testing.T) {
+	fs := NewFlagSet("test")
+	var name, region string
+	var verbose bool
+	fs.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "name"}, Default: "world", Value: &name})
+	fs.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "region"}, Value: &region})
+	fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose"}, Value: &verbose})
+
+	if _, err := fs.Parse([]string{"--name", "alice", "--region", "eu", "--verbose"}); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if !fs.AnyCLISet() {
+		t.Fatalf("expected flags to be marked as set")
+	}
+
+	fs.Reset()
+
+	if fs.AnyCLISet() {
+		t.Errorf("expected Reset to clear set state")
+	}
+	if name != "world" {
+		t.Errorf("expected name to be restored to default, got %q", name)
+	}
+	if region != "" {
+		t.Errorf("expected region to be restored to its initial value, got %q", region)
+	}
+	if verbose {
+		t.Errorf("expected verbose to be restored to false")
+	}
+	if v, ok := fs.Bool("verbose"); !ok || v {
+		t.Errorf("expected Bool to report false after reset, got %v, %v", v, ok)
+	}
+}
+
+func TestFlagValidateRunsForHydratedValues(t *testing.T) {
+	newApp := func() (*App, *bool) {
+		app := NewApp("demo")
+		app.configLoaded = true
+		var port int
+		cmd := NewCommand("serve")
+		cmd.Flags.IntVar(IntVarOptions{
+			FlagOptions: FlagOptions{
+				Name:   "port",
+				EnvVar: "DEMO_TEST_PORT",
+				Validate: func(s string) error {
+					if s == "0" {
+						return fmt.Errorf("port must be non-zero")
+					}
+					return nil
+				},
+			},
+			Default: "0",
+			Value:   &port,
+		})
+		ran := false
+		cmd.Run = func(ctx *Context) error {
+			ran = true
+			return nil
+		}
+		app.Root.AddCommand(cmd)
+		return app, &ran
+	}
+
+	t.Run("untouched default is not validated", func(t *testing.T) {
+		app, ran := newApp()
+		if err := app.Run(context.Background(), []string{"serve"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !*ran {
+			t.Fatal("expected command to run")
+		}
+	})
+
+	t.Run("env value is validated", func(t *testing.T) {
+		app, ran := newApp()
+		t.Setenv("DEMO_TEST_PORT", "0")
+		err := app.Run(context.Background(), []string{"serve"})
+		if err == nil || !strings.Contains(err.Error(), "invalid value for --port from environment variable DEMO_TEST_PORT: port must be non-zero") {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+		if *ran {
+			t.Fatal("expected command not to run")
+		}
+	})
+
+	t.Run("config value that fails Set is reported", func(t *testing.T) {
+		app, _ := newApp()
+		app.Config.Set("port", "eighty")
+		err := app.Run(context.Background(), []string{"serve"})
+		if err == nil || !strings.Contains(err.Error(), "invalid value for --port from config file") {
+			t.Fatalf("expected parse error, got %v", err)
+		}
+	})
+
+	t.Run("command line overrides invalid env value", func(t *testing.T) {
+		app, ran := newApp()
+		t.Setenv("DEMO_TEST_PORT", "0")
+		if err := app.Run(context.Background(), []string{"serve", "--port", "8080"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !*ran {
+			t.Fatal("expected command to run")
+		}
+	})
+}
+
+func TestFlagAliases(t *testing.T) {
+	for _, arg := range []string{"--color", "--colour", "--colr"} {
+		t.Run(arg, func(t *testing.T) {
+			app := NewApp("test")
+			app.configLoaded = true
+			cmd := NewCommand("paint")
+			var color string
+			cmd.Flags.StringVar(StringVarOptions{
+				FlagOptions: FlagOptions{Name: "color", Aliases: []string{"colour", "colr"}},
+				Value:       &color,
+			})
+			var viaName, viaAlias string
+			cmd.Run = func(ctx *Context) error {
+				viaName, _ = ctx.String("color")
+				viaAlias, _ = ctx.String("colour")
+				return nil
+			}
+			app.Root.AddCommand(cmd)
+
+			if err := app.Run(context.Background(), []string{"paint", arg, "red"}); err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if color != "red" || viaName != "red" || viaAlias != "red" {
+				t.Errorf("expected red everywhere, got value=%q name=%q alias=%q", color, viaName, viaAlias)
+			}
+		})
+	}
+}
+
+func TestFlagSetParseSuggestsUnknownFlags(t *testing.T) {
+	newSet := func() *FlagSet {
+		fs := NewFlagSet("test")
+		var verbose bool
+		var output string
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose"}, Value: &verbose})
+		fs.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "output"}, Value: &output})
+		return fs
+	}
+
+	t.Run("near miss suggests", func(t *testing.T) {
+		_, err := newSet().Parse([]string{"--verbse"})
+		if err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+		if want := "unknown flag: --verbse (did you mean --verbose?)"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("hidden flags are not suggested", func(t *testing.T) {
+		fs := newSet()
+		var token string
+		fs.StringVar(WithFlagName("token"), WithFlagHidden(), WithStringValue(&token))
+		_, err := fs.Parse([]string{"--tokne"})
+		if err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+		if want := "unknown flag: --tokne"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("app flags are suggested on commands", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		app := NewApp("tool")
+		app.configLoaded = true
+		app.Out = &bytes.Buffer{}
+		var format string
+		app.Flags().StringVar(WithFlagName("format"), WithStringValue(&format))
+		cmd := NewCommand("list")
+		cmd.Run = func(*Context) error { return nil }
+		app.Root.AddCommand(cmd)
+
+		err := app.Run(context.Background(), []string{"list", "--fromat", "json"})
+		if err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+		if want := "did you mean --format?"; !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	})
+
+	t.Run("far miss does not suggest", func(t *testing.T) {
+		_, err := newSet().Parse([]string{"--quiet"})
+		if err == nil {
+			t.Fatal("expected unknown flag error")
+		}
+		if want := "unknown flag: --quiet"; err.Error() != want {
+			t.Errorf("expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("non-strict passes through", func(t *testing.T) {
+		fs := newSet()
+		fs.SetStrict(false)
+		rest, err := fs.Parse([]string{"--verbse"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(rest) != 1 || rest[0] != "--verbse" {
+			t.Errorf("expected unknown flag as positional, got %v", rest)
+		}
+	})
+}
+
+func TestFlagSetRejectsDuplicateFlags(t *testing.T) {
+	expectPanic := func(t *testing.T, want string, register func(fs *FlagSet)) {
+		t.Helper()
+		fs := NewFlagSet("test")
+		var project string
+		fs.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{Name: "project", Short: "p", Aliases: []string{"proj"}},
+			Value:       &project,
+		})
+		defer func() {
+			t.Helper()
+			r := recover()
+			if r == nil {
+				t.Fatal("expected panic for duplicate flag")
+			}
+			if msg := fmt.Sprint(r); !strings.Contains(msg, want) {
+				t.Errorf("expected panic mentioning %q, got %q", want, msg)
+			}
+		}()
+		register(fs)
+	}
+
+	t.Run("duplicate long name", func(t *testing.T) {
+		expectPanic(t, "flag redefined: --project", func(fs *FlagSet) {
+			var other string
+			fs.StringVar(WithFlagName("project"), WithStringValue(&other))
+		})
+	})
+
+	t.Run("duplicate short name", func(t *testing.T) {
+		expectPanic(t, "flag redefined: -p (registering --port, already used by --project)", func(fs *FlagSet) {
+			var port int
+			fs.IntVar(WithFlagName("port"), WithFlagShort("p"), WithIntegerValue(&port))
+		})
+	})
+
+	t.Run("alias collides with name", func(t *testing.T) {
+		expectPanic(t, "flag redefined: --proj", func(fs *FlagSet) {
+			var other string
+			fs.StringVar(WithFlagName("proj"), WithStringValue(&other))
+		})
+	})
+}
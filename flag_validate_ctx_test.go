@@ -0,0 +1,93 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestFlagValidateCtx(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "rejects second argument based on the first",
+			args:    []string{"report", "2024-03-01", "2024-02-01"},
+			wantErr: "invalid value for positional argument end: must not be before start date 2024-03-01",
+		},
+		{name: "accepts valid arguments", args: []string{"report", "2024-01-01", "2024-02-01"}},
+		{
+			name:    "sees values given by name",
+			args:    []string{"report", "--end", "2024-01-01", "--start", "2024-06-01"},
+			wantErr: "invalid value for positional argument end: must not be before start date 2024-06-01",
+		},
+		{name: "skips flags without a value", args: []string{"report", "2024-01-01"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, "rangetest")
+			ran := false
+			cmd := NewCommand("report", WithCommandRun(func(*Context) error {
+				ran = true
+				return nil
+			}))
+			cmd.Flags.StringVar(WithFlagName("start"), WithFlagPositional())
+			cmd.Flags.StringVar(
+				WithFlagName("end"),
+				WithFlagPositional(),
+				WithFlagValidateCtx(func(ctx *Context, value string) error {
+					first, _ := ctx.String("start")
+					if value < first {
+						return fmt.Errorf("must not be before start date %s", first)
+					}
+					return nil
+				}),
+			)
+			app.Root.AddCommand(cmd)
+
+			err := app.Run(context.Background(), tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !ran {
+					t.Error("expected command to run")
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if ran {
+				t.Error("command should not run when validation fails")
+			}
+		})
+	}
+
+	t.Run("named flag error uses the flag name", func(t *testing.T) {
+		app := newTestApp(t, "ctxtest")
+
+		cmd := NewCommand("copy")
+		var src, dst string
+		cmd.Flags.StringVar(WithFlagName("src"), WithStringValue(&src))
+		cmd.Flags.StringVar(
+			WithFlagName("dst"),
+			WithStringValue(&dst),
+			WithFlagValidateCtx(func(ctx *Context, value string) error {
+				if other, _ := ctx.String("src"); other == value {
+					return fmt.Errorf("must differ from --src")
+				}
+				return nil
+			}),
+		)
+		cmd.Run = func(ctx *Context) error { return nil }
+		app.Root.AddCommand(cmd)
+
+		err := app.Run(context.Background(), []string{"copy", "--src", "a", "--dst", "a"})
+		if err == nil || err.Error() != "invalid value for --dst: must differ from --src" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
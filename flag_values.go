@@ -1,16 +1,30 @@
 package clix
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // StringValue implements Value for string flags.
 type StringValue struct {
-	target *string
+	target   *string
+	fromFile bool
 }
 
 func (s *StringValue) Set(value string) error {
+	if s.fromFile && strings.HasPrefix(value, "@") {
+		path := strings.TrimPrefix(value, "@")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading value from %s: %w", path, err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
 	if s.target != nil {
 		*s.target = value
 	}
@@ -130,6 +144,215 @@ func (i *Int64Value) String() string {
 	return strconv.FormatInt(*i.target, 10)
 }
 
+// BytesValue implements Value for byte-size flags such as "10MB" or "2GiB".
+type BytesValue struct {
+	target *int64
+}
+
+func (b *BytesValue) Set(value string) error {
+	parsed, err := parseBytes(value)
+	if err != nil {
+		return err
+	}
+	if b.target != nil {
+		*b.target = parsed
+	}
+	return nil
+}
+
+// String renders the size using the largest unit that represents it exactly,
+// e.g. "10MB", "2GiB" or "1500B".
+func (b *BytesValue) String() string {
+	if b.target == nil {
+		return "0B"
+	}
+	return formatBytes(*b.target)
+}
+
+// byteUnits lists the supported size suffixes, largest first.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// parseBytes parses a size such as "512", "10MB", "1.5GiB" into bytes.
+// Suffixes are case-insensitive; unknown suffixes are rejected, as are sizes
+// that do not fit in an int64.
+func parseBytes(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	number, suffix := s[:end], strings.TrimSpace(s[end:])
+	if number == "" {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+
+	multiplier := int64(1)
+	if suffix != "" {
+		found := false
+		for _, unit := range byteUnits {
+			if strings.EqualFold(suffix, unit.suffix) {
+				multiplier, found = unit.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid size %q: unknown unit %q", value, suffix)
+		}
+	}
+
+	if whole, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if whole > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("size %q is out of range", value)
+		}
+		return whole * multiplier, nil
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits.
+	size := f * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is out of range", value)
+	}
+	return int64(size), nil // fractions of a byte are truncated
+}
+
+// formatBytes renders n with the largest unit that divides it evenly.
+func formatBytes(n int64) string {
+	if n == 0 {
+		return "0B"
+	}
+	for _, unit := range byteUnits {
+		if n%unit.size == 0 {
+			return strconv.FormatInt(n/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
+
+// TimestampValue implements Value for time.Time flags. Set tries each layout
+// in order; String renders the value using the first layout.
+type TimestampValue struct {
+	target  *time.Time
+	layouts []string
+}
+
+// Set parses value with the configured layouts. An empty value clears the
+// timestamp to the zero time.
+func (t *TimestampValue) Set(value string) error {
+	if value == "" {
+		if t.target != nil {
+			*t.target = time.Time{}
+		}
+		return nil
+	}
+	parsed, err := parseTimestamp(value, t.layouts)
+	if err != nil {
+		return err
+	}
+	if t.target != nil {
+		*t.target = parsed
+	}
+	return nil
+}
+
+func (t *TimestampValue) String() string {
+	if t.target == nil || t.target.IsZero() {
+		return ""
+	}
+	return t.target.Format(t.formatLayout())
+}
+
+func (t *TimestampValue) formatLayout() string {
+	if len(t.layouts) == 0 {
+		return time.RFC3339
+	}
+	return t.layouts[0]
+}
+
+// parseTimestamp parses value with the first matching layout, defaulting to
+// RFC3339 when no layouts are given.
+func parseTimestamp(value string, layouts []string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp (tried layouts: %s)", value, strings.Join(layouts, ", "))
+}
+
+// UintValue implements Value for uint flags.
+type UintValue struct {
+	target *uint
+}
+
+func (u *UintValue) Set(value string) error {
+	parsed, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		if strings.HasPrefix(strings.TrimSpace(value), "-") {
+			return fmt.Errorf("%q must be a non-negative integer", value)
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("%q is out of range for uint", value)
+		}
+		return err
+	}
+	if u.target != nil {
+		*u.target = uint(parsed)
+	}
+	return nil
+}
+
+func (u *UintValue) String() string {
+	if u.target == nil {
+		return "0"
+	}
+	return strconv.FormatUint(uint64(*u.target), 10)
+}
+
+// Float32Value implements Value for float32 flags.
+type Float32Value struct {
+	target *float32
+}
+
+func (f *Float32Value) Set(value string) error {
+	parsed, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("%q is out of range for float32", value)
+		}
+		return err
+	}
+	if f.target != nil {
+		*f.target = float32(parsed)
+	}
+	return nil
+}
+
+func (f *Float32Value) String() string {
+	if f.target == nil {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(*f.target), 'g', -1, 32)
+}
+
 // Float64Value implements Value for float64 flags.
 type Float64Value struct {
 	target *float64
@@ -167,20 +390,22 @@ func (fs *FlagSet) String(name string) (string, bool) {
 
 // Bool fetches a boolean flag value.
 // Returns the flag's value and whether it was found.
-// For boolean flags, if the flag was explicitly set on the command line,
-// returns true regardless of the target pointer (handles help flags without targets).
+// Boolean flags without a target pointer (such as help flags) report true
+// once they have been set.
 func (fs *FlagSet) Bool(name string) (bool, bool) {
 	flag := fs.lookup(name)
 	if flag == nil {
 		return false, false
 	}
-	// Boolean flags set via command line are always true
+	// A bound variable holds the value from whichever source set it
+	if value, ok := flag.Value.(*BoolValue); ok && value.target != nil {
+		return value.Bool(), true
+	}
 	if flag.set {
 		return true, true
 	}
-	// Not set, check if there's a target with a default value
-	if value, ok := flag.Value.(*BoolValue); ok {
-		return value.Bool(), true
+	if _, ok := flag.Value.(*BoolValue); ok {
+		return false, true
 	}
 	return false, false
 }
@@ -215,6 +440,36 @@ func (fs *FlagSet) Int64(name string) (int64, bool) {
 	return 0, false
 }
 
+// Bytes fetches a byte-size flag value in bytes.
+func (fs *FlagSet) Bytes(name string) (int64, bool) {
+	flag := fs.lookup(name)
+	if flag == nil {
+		return 0, false
+	}
+	if value, ok := flag.Value.(*BytesValue); ok {
+		if value.target == nil {
+			return 0, false
+		}
+		return *value.target, true
+	}
+	return 0, false
+}
+
+// Timestamp fetches a timestamp flag value.
+func (fs *FlagSet) Timestamp(name string) (time.Time, bool) {
+	flag := fs.lookup(name)
+	if flag == nil {
+		return time.Time{}, false
+	}
+	if value, ok := flag.Value.(*TimestampValue); ok {
+		if value.target == nil {
+			return time.Time{}, false
+		}
+		return *value.target, true
+	}
+	return time.Time{}, false
+}
+
 // AnyCLISet reports whether any flag was explicitly set via CLI arguments.
 // This distinguishes "user passed flags on the command line" from
 // "flags were resolved from env/config/defaults only".
@@ -250,6 +505,36 @@ func (fs *FlagSet) RequiredFlags() []*Flag {
 	return required
 }
 
+// Uint fetches a uint flag value.
+func (fs *FlagSet) Uint(name string) (uint, bool) {
+	flag := fs.lookup(name)
+	if flag == nil {
+		return 0, false
+	}
+	if value, ok := flag.Value.(*UintValue); ok {
+		if value.target == nil {
+			return 0, false
+		}
+		return *value.target, true
+	}
+	return 0, false
+}
+
+// Float32 fetches a float32 flag value.
+func (fs *FlagSet) Float32(name string) (float32, bool) {
+	flag := fs.lookup(name)
+	if flag == nil {
+		return 0, false
+	}
+	if value, ok := flag.Value.(*Float32Value); ok {
+		if value.target == nil {
+			return 0, false
+		}
+		return *value.target, true
+	}
+	return 0, false
+}
+
 // Float64 fetches a float64 flag value.
 func (fs *FlagSet) Float64(name string) (float64, bool) {
 	flag := fs.lookup(name)
@@ -0,0 +1,422 @@
+package clix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStringFlagFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(path, []byte("s3cr3t\n\n"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	t.Run("reads file contents and trims trailing newlines", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var key string
+		fs.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{Name: "key"},
+			Value:       &key,
+			FromFile:    true,
+		})
+
+		if _, err := fs.Parse([]string{"--key", "@" + path}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if key != "s3cr3t" {
+			t.Errorf("expected key from file, got %q", key)
+		}
+	})
+
+	t.Run("preserves internal whitespace", func(t *testing.T) {
+		multi := filepath.Join(dir, "multi.txt")
+		if err := os.WriteFile(multi, []byte("line one\nline two\r\n"), 0o600); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+		fs := NewFlagSet("test")
+		var body string
+		fs.StringVar(WithFlagName("body"), WithStringValue(&body), WithStringFromFile())
+
+		if _, err := fs.Parse([]string{"--body=@" + multi}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if body != "line one\nline two" {
+			t.Errorf("expected internal newline to be preserved, got %q", body)
+		}
+	})
+
+	t.Run("missing file returns error", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var key string
+		fs.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{Name: "key"},
+			Value:       &key,
+			FromFile:    true,
+		})
+
+		_, err := fs.Parse([]string{"--key", "@" + filepath.Join(dir, "missing.txt")})
+		if err == nil {
+			t.Fatal("expected error for missing file")
+		}
+		if !strings.Contains(err.Error(), "invalid value for --key") || !strings.Contains(err.Error(), "missing.txt") {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("context getters see file contents from env and config", func(t *testing.T) {
+		t.Setenv("DEMO_KEY", "@"+path)
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Config.Set("token", "@"+path)
+
+		var key, token string
+		cmd := NewCommand("login")
+		cmd.Flags.StringVar(WithFlagName("key"), WithStringValue(&key), WithStringFromFile())
+		cmd.Flags.StringVar(WithFlagName("token"), WithStringValue(&token), WithStringFromFile())
+		var got []string
+		cmd.Run = func(ctx *Context) error {
+			for _, name := range []string{"key", "token"} {
+				value, _ := ctx.String(name)
+				got = append(got, value)
+			}
+			return nil
+		}
+		app.Root.AddCommand(cmd)
+
+		if err := app.Run(context.Background(), []string{"login"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if key != "s3cr3t" || token != "s3cr3t" {
+			t.Errorf("expected bound values from file, got key=%q token=%q", key, token)
+		}
+		if len(got) != 2 || got[0] != "s3cr3t" || got[1] != "s3cr3t" {
+			t.Errorf("expected ctx.String to return the file contents, got %q", got)
+		}
+	})
+
+	t.Run("validates file contents from env and config", func(t *testing.T) {
+		t.Setenv("DEMO_KEY", "@"+path)
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.Config.Set("token", "@"+path)
+
+		var key, token string
+		var checked []string
+		validate := func(v string) error {
+			checked = append(checked, v)
+			if strings.HasPrefix(v, "@") {
+				return fmt.Errorf("not a key: %q", v)
+			}
+			return nil
+		}
+		cmd := NewCommand("login")
+		cmd.Flags.StringVar(WithFlagName("key"), WithStringValue(&key), WithStringFromFile(), WithFlagValidate(validate))
+		cmd.Flags.StringVar(WithFlagName("token"), WithStringValue(&token), WithStringFromFile(), WithFlagValidate(validate))
+		cmd.Run = func(*Context) error { return nil }
+		app.Root.AddCommand(cmd)
+
+		if err := app.Run(context.Background(), []string{"login"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if len(checked) != 2 || checked[0] != "s3cr3t" || checked[1] != "s3cr3t" {
+			t.Errorf("expected Validate to see the file contents, got %q", checked)
+		}
+	})
+
+	t.Run("validates file contents", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var key string
+		var checked []string
+		fs.StringVar(StringVarOptions{
+			FlagOptions: FlagOptions{Name: "key", Validate: func(v string) error {
+				checked = append(checked, v)
+				if strings.HasPrefix(v, "@") {
+					return fmt.Errorf("not a key: %q", v)
+				}
+				return nil
+			}},
+			Value:    &key,
+			FromFile: true,
+		})
+
+		if _, err := fs.Parse([]string{"--key", "@" + path}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if len(checked) != 1 || checked[0] != "s3cr3t" {
+			t.Errorf("expected Validate to see the file contents, got %q", checked)
+		}
+	})
+
+	t.Run("@ is literal when FromFile is disabled", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var handle string
+		fs.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "handle"}, Value: &handle})
+
+		if _, err := fs.Parse([]string{"--handle", "@octocat"}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if handle != "@octocat" {
+			t.Errorf("expected literal value, got %q", handle)
+		}
+	})
+}
+
+// logLevel is a custom enum-like Value used to exercise FlagSet.Var.
+type logLevel string
+
+func (l *logLevel) Set(value string) error {
+	switch value {
+	case "debug", "info", "warn", "error":
+		*l = logLevel(value)
+		return nil
+	}
+	return fmt.Errorf("unknown log level %q", value)
+}
+
+func (l *logLevel) String() string { return string(*l) }
+
+func (l *logLevel) DefaultValue() string { return "info" }
+
+// toggle is a custom bool-like Value used to exercise FlagSet.Var.
+type toggle struct{ on bool }
+
+func (t *toggle) Set(value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	t.on = parsed
+	return nil
+}
+
+func (t *toggle) SetBool(value bool) error {
+	t.on = value
+	return nil
+}
+
+func (t *toggle) String() string { return strconv.FormatBool(t.on) }
+
+func TestFlagSetVar(t *testing.T) {
+	t.Run("custom value parses and applies default", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var level logLevel
+		fs.Var(&level, FlagOptions{Name: "log-level", Short: "l", Usage: "Log level"})
+
+		if level != "info" {
+			t.Fatalf("expected default to be applied, got %q", level)
+		}
+		if flag := fs.lookup("log-level"); flag == nil || flag.Default != "info" {
+			t.Fatalf("expected default to be recorded on the flag")
+		}
+
+		if _, err := fs.Parse([]string{"-l", "debug"}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if level != "debug" {
+			t.Errorf("expected debug, got %q", level)
+		}
+		if got, ok := fs.String("log-level"); !ok || got != "debug" {
+			t.Errorf("String returned %q, %v", got, ok)
+		}
+
+		fs.Reset()
+		if level != "info" {
+			t.Errorf("expected reset to restore default, got %q", level)
+		}
+	})
+
+	t.Run("custom value rejects invalid input", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var level logLevel
+		fs.Var(&level, WithFlagName("log-level"))
+
+		_, err := fs.Parse([]string{"--log-level", "loud"})
+		if err == nil || !strings.Contains(err.Error(), `unknown log level "loud"`) {
+			t.Fatalf("expected invalid value error, got %v", err)
+		}
+	})
+
+	t.Run("bool-like custom value needs no argument", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var feature toggle
+		fs.Var(&feature, WithFlagName("feature"))
+
+		rest, err := fs.Parse([]string{"--feature", "pos"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !feature.on {
+			t.Errorf("expected feature to be enabled")
+		}
+		if len(rest) != 1 || rest[0] != "pos" {
+			t.Errorf("expected positional to remain, got %v", rest)
+		}
+	})
+}
+
+func TestBytesValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+		str   string
+	}{
+		{"512", 512, "512B"},
+		{"1500B", 1500, "1500B"},
+		{"10MB", 10_000_000, "10MB"},
+		{"2GiB", 2 << 30, "2GiB"},
+		{"1KB", 1000, "1KB"},
+		{"1KiB", 1024, "1KiB"},
+		{"1.5GiB", 3 << 29, "1536MiB"},
+		{"4 mib", 4 << 20, "4MiB"},
+		{"8388607TiB", 8388607 << 40, "8388607TiB"},
+		{"1.5B", 1, "1B"},
+		{"0.5KiB", 512, "512B"},
+		{"0.0001KB", 0, "0B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var size int64
+			v := &BytesValue{target: &size}
+			if err := v.Set(tt.input); err != nil {
+				t.Fatalf("Set(%q) failed: %v", tt.input, err)
+			}
+			if size != tt.want {
+				t.Errorf("Set(%q) = %d, want %d", tt.input, size, tt.want)
+			}
+			if got := v.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "MB", "10XB", "-5MB", "ten"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			var size int64
+			if err := (&BytesValue{target: &size}).Set(input); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+
+	for _, input := range []string{"8388608TiB", "10000000TB", "8388608.5TiB", "9223372036854775808"} {
+		t.Run("out of range "+input, func(t *testing.T) {
+			var size int64
+			err := (&BytesValue{target: &size}).Set(input)
+			if err == nil || !strings.Contains(err.Error(), "out of range") {
+				t.Errorf("expected out of range error for %q, got %v (size %d)", input, err, size)
+			}
+		})
+	}
+}
+
+func TestBytesVarContextAccessor(t *testing.T) {
+	app := NewApp("test")
+	app.configLoaded = true
+	var limit int64
+	app.Root.Flags.BytesVar(
+		WithFlagName("max-upload"),
+		WithBytesValue(&limit),
+		WithBytesDefault("10MB"),
+	)
+	var got int64
+	app.Root.Run = func(ctx *Context) error {
+		got, _ = ctx.Bytes("max-upload")
+		return nil
+	}
+
+	if err := app.Run(context.Background(), []string{}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if limit != 10_000_000 || got != 10_000_000 {
+		t.Errorf("expected default 10MB, got value=%d ctx=%d", limit, got)
+	}
+
+	if err := app.Run(context.Background(), []string{"--max-upload", "2GiB"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if limit != 2<<30 || got != 2<<30 {
+		t.Errorf("expected 2GiB, got value=%d ctx=%d", limit, got)
+	}
+}
+
+func TestTimestampVar(t *testing.T) {
+	t.Run("RFC3339 by default", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var since time.Time
+		fs.TimestampVar(WithFlagName("since"), WithTimestampValue(&since))
+		if _, err := fs.Parse([]string{"--since", "2024-01-02T15:04:05Z"}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		want := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+		if !since.Equal(want) {
+			t.Errorf("expected %v, got %v", want, since)
+		}
+	})
+
+	t.Run("custom layouts tried in order", func(t *testing.T) {
+		app := NewApp("test")
+		app.configLoaded = true
+		var since time.Time
+		app.Root.Flags.TimestampVar(TimestampVarOptions{
+			FlagOptions: FlagOptions{Name: "since"},
+			Layouts:     []string{time.RFC3339, time.DateOnly},
+			Value:       &since,
+		})
+		var got time.Time
+		app.Root.Run = func(ctx *Context) error {
+			got, _ = ctx.Timestamp("since")
+			return nil
+		}
+		if err := app.Run(context.Background(), []string{"--since", "2024-03-15"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+		if !since.Equal(want) || !got.Equal(want) {
+			t.Errorf("expected %v, got value=%v ctx=%v", want, since, got)
+		}
+	})
+
+	t.Run("persistent flag layouts apply to config values", func(t *testing.T) {
+		app := NewApp("test")
+		app.configLoaded = true
+		app.Config.Set("since", "2024-03-15")
+		logs := NewCommand("logs")
+		logs.PersistentFlags.TimestampVar(WithFlagName("since"), WithTimestampLayouts(time.DateOnly))
+		tail := NewCommand("tail")
+		var got time.Time
+		var ok bool
+		tail.Run = func(ctx *Context) error {
+			got, ok = ctx.Timestamp("since")
+			return nil
+		}
+		logs.AddCommand(tail)
+		app.Root.AddCommand(logs)
+
+		if err := app.Run(context.Background(), []string{"logs", "tail"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if want := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+			t.Errorf("expected %v, got %v (found %t)", want, got, ok)
+		}
+	})
+
+	t.Run("parse failure lists layouts", func(t *testing.T) {
+		fs := NewFlagSet("test")
+		var since time.Time
+		fs.TimestampVar(WithFlagName("since"), WithTimestampLayouts(time.RFC3339, time.DateOnly), WithTimestampValue(&since))
+		_, err := fs.Parse([]string{"--since", "yesterday"})
+		if err == nil {
+			t.Fatal("expected parse error")
+		}
+		if !strings.Contains(err.Error(), time.RFC3339) || !strings.Contains(err.Error(), time.DateOnly) {
+			t.Errorf("expected attempted layouts in error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package clix
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVariadicPositionalCollectsRemainingArgs(t *testing.T) {
+	var dest string
+	var files []string
+	app := newTestApp(t, "tool")
+	cmd := NewCommand("copy")
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{Name: "dest", Positional: true},
+		Value:       &dest,
+	})
+	cmd.Flags.StringSliceVar(StringSliceVarOptions{
+		FlagOptions: FlagOptions{Name: "files", Positional: true, Variadic: true},
+		Value:       &files,
+	})
+	cmd.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"copy", "out", "a.txt", "b.txt", "c.txt"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if dest != "out" {
+		t.Errorf("dest = %q, want out", dest)
+	}
+	if want := []string{"a.txt", "b.txt", "c.txt"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %q, want %q", files, want)
+	}
+
+	// A second run starts from an empty slice instead of appending.
+	if err := app.Run(context.Background(), []string{"copy", "out", "d.txt"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := []string{"d.txt"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files after second run = %q, want %q", files, want)
+	}
+}
+
+func TestVariadicPositionalZeroArgs(t *testing.T) {
+	var files []string
+	app := newTestApp(t, "tool")
+	cmd := NewCommand("copy", WithCommandRun(func(*Context) error { return nil }))
+	cmd.Flags.StringSliceVar(WithFlagName("files"), WithFlagPositional(), WithFlagVariadic(), WithStringSliceValue(&files), WithStringSliceDefault("default.txt"))
+	app.Root.AddCommand(cmd)
+	if err := app.Run(context.Background(), []string{"copy"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := []string{"default.txt"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %q, want %q", files, want)
+	}
+}
+
+func TestVariadicPositionalIsPrompted(t *testing.T) {
+	var files []string
+	app := newTestApp(t, "tool")
+	cmd := NewCommand("copy", WithCommandRun(func(*Context) error { return nil }))
+	cmd.Flags.StringSliceVar(WithFlagName("files"), WithFlagRequired(), WithFlagPositional(), WithFlagVariadic(), WithStringSliceValue(&files))
+	app.Root.AddCommand(cmd)
+	app.Prompter = prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		return "prompted.txt", nil
+	})
+
+	if err := app.Run(context.Background(), []string{"copy"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := []string{"prompted.txt"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %q, want %q", files, want)
+	}
+}
+
+func TestCommandArgCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "too few", args: []string{"copy"}, wantErr: "tool copy expects at least 1 argument, got 0"},
+		{name: "too many", args: []string{"copy", "a", "b", "c"}, wantErr: "tool copy expects at most 2 arguments, got 3"},
+		{name: "within range", args: []string{"copy", "a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, "tool")
+			cmd := NewCommand("copy", WithCommandArgs(1, 2), WithCommandRun(func(*Context) error { return nil }))
+			cmd.Flags.StringSliceVar(WithFlagName("files"), WithFlagPositional(), WithFlagVariadic())
+			app.Root.AddCommand(cmd)
+			ran := false
+			cmd.PreRun = func(*Context) error {
+				ran = true
+				return nil
+			}
+
+			err := app.Run(context.Background(), tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Run: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if ran {
+				t.Error("hooks ran despite argument count error")
+			}
+		})
+	}
+}
+
+func TestVariadicRegistrationPanics(t *testing.T) {
+	t.Run("not positional", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(r.(string), "must be positional") {
+				t.Fatalf("panic = %v", r)
+			}
+		}()
+		fs := NewFlagSet("test")
+		fs.StringSliceVar(WithFlagName("files"), WithFlagVariadic())
+	})
+
+	t.Run("positional after variadic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(r.(string), "after variadic flag files") {
+				t.Fatalf("panic = %v", r)
+			}
+		}()
+		fs := NewFlagSet("test")
+		fs.StringSliceVar(WithFlagName("files"), WithFlagPositional(), WithFlagVariadic())
+		fs.StringVar(WithFlagName("dest"), WithFlagPositional())
+	})
+}
+
+func TestVariadicUsageLine(t *testing.T) {
+	app := newTestApp(t, "tool")
+	cmd := NewCommand("copy")
+	cmd.Flags.StringSliceVar(WithFlagName("files"), WithFlagRequired(), WithFlagPositional(), WithFlagVariadic())
+	app.Root.AddCommand(cmd)
+	if got := (HelpRenderer{App: app}).buildUsageLine(cmd); !strings.HasSuffix(got, "<files...>") {
+		t.Errorf("usage = %q, want suffix <files...>", got)
+	}
+}
+
+func TestStringSliceHydratesCommaLists(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		setup func(t *testing.T, app *App)
+	}{
+		{"env", func(t *testing.T, app *App) { t.Setenv("TOOL_TAGS", "a, b") }},
+		{"config", func(t *testing.T, app *App) { app.Config.Set("tags", "a,b") }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var tags []string
+			var resolved string
+			app := newTestApp(t, "tool")
+			cmd := NewCommand("tag")
+			cmd.Flags.StringSliceVar(WithFlagName("tags"), WithStringSliceValue(&tags))
+			cmd.Run = func(ctx *Context) error {
+				resolved, _ = ctx.String("tags")
+				return nil
+			}
+			app.Root.AddCommand(cmd)
+			tc.setup(t, app)
+
+			if err := app.Run(context.Background(), []string{"tag"}); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if want := []string{"a", "b"}; !reflect.DeepEqual(tags, want) {
+				t.Errorf("tags = %q, want %q", tags, want)
+			}
+			if resolved != "a,b" {
+				t.Errorf("ctx.String(tags) = %q, want a,b", resolved)
+			}
+		})
+	}
+}
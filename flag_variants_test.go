@@ -1,6 +1,9 @@
 package clix
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -109,3 +112,267 @@ func TestFlagVariants(t *testing.T) {
 	})
 }
 
+func TestFlagBundledShorts(t *testing.T) {
+	newSet := func(a, b *bool, out *string) *FlagSet {
+		fs := NewFlagSet("test")
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "all", Short: "a"}, Value: a})
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "brief", Short: "b"}, Value: b})
+		fs.StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "output", Short: "o"}, Value: out})
+		return fs
+	}
+
+	t.Run("all-boolean bundle", func(t *testing.T) {
+		var a, b bool
+		var out string
+		fs := newSet(&a, &b, &out)
+
+		rest, err := fs.Parse([]string{"-ab", "pos"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !a || !b {
+			t.Errorf("expected a and b to be true, got a=%v b=%v", a, b)
+		}
+		if len(rest) != 1 || rest[0] != "pos" {
+			t.Errorf("expected [pos] remaining, got %v", rest)
+		}
+	})
+
+	t.Run("trailing value-taking flag", func(t *testing.T) {
+		var a, b bool
+		var out string
+		fs := newSet(&a, &b, &out)
+
+		rest, err := fs.Parse([]string{"-abo", "out.txt"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !a || !b || out != "out.txt" {
+			t.Errorf("expected a, b and output=out.txt, got a=%v b=%v output=%q", a, b, out)
+		}
+		if len(rest) != 0 {
+			t.Errorf("expected no remaining args, got %v", rest)
+		}
+
+		out = ""
+		if _, err := fs.Parse([]string{"-ao=file.txt"}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if out != "file.txt" {
+			t.Errorf("expected output='file.txt', got %q", out)
+		}
+	})
+
+	t.Run("trailing value-taking flag without value", func(t *testing.T) {
+		var a, b bool
+		var out string
+		fs := newSet(&a, &b, &out)
+
+		_, err := fs.Parse([]string{"-abo"})
+		if err == nil || !strings.Contains(err.Error(), "flag -o requires a value") {
+			t.Fatalf("expected missing value error, got %v", err)
+		}
+	})
+
+	t.Run("value-taking flag in middle of bundle", func(t *testing.T) {
+		var a, b bool
+		var out string
+		fs := newSet(&a, &b, &out)
+
+		_, err := fs.Parse([]string{"-aob"})
+		if err == nil {
+			t.Fatal("expected error for value-taking flag in middle of bundle")
+		}
+		if !strings.Contains(err.Error(), "-o in -aob requires a value and must be last") {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("unknown letter in bundle", func(t *testing.T) {
+		var a, b bool
+		var out string
+		fs := newSet(&a, &b, &out)
+
+		if _, err := fs.Parse([]string{"-axb"}); err == nil {
+			t.Fatal("expected unknown flag error in strict mode")
+		}
+
+		fs.SetStrict(false)
+		rest, err := fs.Parse([]string{"-axb"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if a || b {
+			t.Errorf("expected no flags to be set from an unresolved bundle")
+		}
+		if len(rest) != 1 || rest[0] != "-axb" {
+			t.Errorf("expected bundle to pass through as positional, got %v", rest)
+		}
+	})
+}
+
+func TestFlagAttachedShortValues(t *testing.T) {
+	newSet := func(port *int, verbose *bool) *FlagSet {
+		fs := NewFlagSet("test")
+		fs.IntVar(IntVarOptions{FlagOptions: FlagOptions{Name: "port", Short: "p"}, Value: port})
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose", Short: "v"}, Value: verbose})
+		return fs
+	}
+
+	for _, args := range [][]string{{"-p8080"}, {"-p", "8080"}, {"-p=8080"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var port int
+			var verbose bool
+			rest, err := newSet(&port, &verbose).Parse(args)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if port != 8080 {
+				t.Errorf("expected port 8080, got %d", port)
+			}
+			if len(rest) != 0 {
+				t.Errorf("expected no positionals, got %v", rest)
+			}
+		})
+	}
+
+	t.Run("boolean bundle is not an attached value", func(t *testing.T) {
+		var port int
+		var verbose bool
+		if _, err := newSet(&port, &verbose).Parse([]string{"-vp", "9000"}); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !verbose || port != 9000 {
+			t.Errorf("expected verbose and port 9000, got verbose=%v port=%d", verbose, port)
+		}
+	})
+
+	t.Run("invalid attached value", func(t *testing.T) {
+		var port int
+		var verbose bool
+		_, err := newSet(&port, &verbose).Parse([]string{"-pabc"})
+		if err == nil || !strings.Contains(err.Error(), "invalid value for --port") {
+			t.Errorf("expected invalid value error, got %v", err)
+		}
+	})
+}
+
+func TestFlagSetInterspersed(t *testing.T) {
+	newSet := func(verbose *bool) *FlagSet {
+		fs := NewFlagSet("test")
+		fs.BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose", Short: "v"}, Value: verbose})
+		return fs
+	}
+
+	t.Run("interspersed by default", func(t *testing.T) {
+		var verbose bool
+		fs := newSet(&verbose)
+		if !fs.Interspersed() {
+			t.Fatal("expected interspersed to be the default")
+		}
+		rest, err := fs.Parse([]string{"file.txt", "--verbose"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !verbose {
+			t.Error("expected --verbose after positional to be parsed")
+		}
+		if len(rest) != 1 || rest[0] != "file.txt" {
+			t.Errorf("expected [file.txt], got %v", rest)
+		}
+	})
+
+	t.Run("stops at first positional when disabled", func(t *testing.T) {
+		var verbose bool
+		fs := newSet(&verbose)
+		fs.SetInterspersed(false)
+		rest, err := fs.Parse([]string{"-v", "git", "--verbose", "--unknown", "status"})
+		if err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+		if !verbose {
+			t.Error("expected leading -v to be parsed")
+		}
+		want := []string{"git", "--verbose", "--unknown", "status"}
+		if strings.Join(rest, " ") != strings.Join(want, " ") {
+			t.Errorf("expected %v, got %v", want, rest)
+		}
+	})
+}
+
+func TestRunPassesArgsThroughNonInterspersedCommand(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := NewApp("tool")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	var format string
+	app.Flags().StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "format"}, Value: &format})
+
+	var got []string
+	exec := NewCommand("exec")
+	exec.Flags.SetInterspersed(false)
+	exec.Flags.StringSliceVar(StringSliceVarOptions{
+		FlagOptions: FlagOptions{Name: "command", Positional: true, Variadic: true},
+		Value:       &got,
+	})
+	exec.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(exec)
+
+	for _, args := range [][]string{
+		{"exec", "ls", "-h"},
+		{"exec", "ls", "--help", "foo"},
+		{"exec", "ls", "--format", "json"},
+	} {
+		got, format = nil, ""
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if want := args[1:]; strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("Run(%q) passed %q, want %q", args, got, want)
+		}
+		if format != "" {
+			t.Errorf("Run(%q) set --format to %q", args, format)
+		}
+	}
+	if out := app.Out.(*bytes.Buffer).String(); out != "" {
+		t.Errorf("expected no help output, got %q", out)
+	}
+
+	// Root flags in front of the first positional are still parsed.
+	if err := app.Run(context.Background(), []string{"exec", "--format", "json", "ls", "-h"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if format != "json" || strings.Join(got, " ") != "ls -h" {
+		t.Errorf("format = %q, args = %q", format, got)
+	}
+}
+
+func TestRunParsesRootFlagsAroundCommandNames(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := NewApp("tool")
+	app.configLoaded = true
+	var verbose bool
+	app.Flags().BoolVar(BoolVarOptions{FlagOptions: FlagOptions{Name: "verbose", Short: "v"}, Value: &verbose})
+
+	var path string
+	add := NewCommand("add")
+	add.Run = func(ctx *Context) error {
+		path = ctx.CommandPathString()
+		return nil
+	}
+	app.Root.AddCommand(NewGroup("remote", "Manage remotes", add))
+
+	for _, args := range [][]string{
+		{"-v", "remote", "add"},
+		{"remote", "-v", "add"},
+		{"remote", "add", "--verbose"},
+	} {
+		verbose, path = false, ""
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if !verbose || path != "tool remote add" {
+			t.Errorf("Run(%q): verbose = %v, path = %q", args, verbose, path)
+		}
+	}
+}
@@ -17,11 +17,79 @@ func FormatData(w io.Writer, data interface{}, format string) error {
 		return formatJSON(w, data)
 	case FormatYAML:
 		return formatYAML(w, data)
+	case FormatTable:
+		return formatTable(w, data)
+	case FormatCSV:
+		return formatDelimited(w, data, FormatCSV, ',')
+	case FormatTSV:
+		return formatDelimited(w, data, FormatTSV, '\t')
 	default:
 		return formatText(w, data)
 	}
 }
 
+// FormatFunc encodes v to w in a custom output format.
+type FormatFunc func(w io.Writer, v interface{}) error
+
+// RegisterFormat adds a named output format to the app, such as "ndjson".
+// Registered formats are consulted before the built-in ones, so registering
+// "json" or "table" replaces the built-in encoder. Names are case-insensitive.
+//
+// Example:
+//
+//	app.RegisterFormat("ndjson", func(w io.Writer, v interface{}) error {
+//		enc := json.NewEncoder(w)
+//		for _, item := range v.([]Item) {
+//			if err := enc.Encode(item); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+func (a *App) RegisterFormat(name string, fn FormatFunc) {
+	if a.formats == nil {
+		a.formats = make(map[string]FormatFunc)
+	}
+	a.formats[strings.ToLower(name)] = fn
+}
+
+// HasFormat reports whether name is a built-in or registered output format.
+func (a *App) HasFormat(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := a.formats[name]; ok {
+		return true
+	}
+	switch name {
+	case FormatJSON, FormatYAML, FormatText, FormatTable, FormatCSV, FormatTSV:
+		return true
+	}
+	return false
+}
+
+// FormatOutput writes data in the format selected by the --format flag
+// (registered by ext/format), falling back to FormatText when the flag is
+// absent or empty. Formats added with RegisterFormat take precedence over the
+// built-in ones. Output goes to the --output file while a command runs, or to
+// a.Out otherwise (see Context.OutputWriter).
+func (a *App) FormatOutput(data interface{}) error {
+	format := a.outputFormat()
+	if fn, ok := a.formats[strings.ToLower(format)]; ok {
+		return checkBrokenPipe(fn(a.outputWriter(), data))
+	}
+	return checkBrokenPipe(FormatData(a.outputWriter(), data, format))
+}
+
+// outputFormat returns the value of the --format flag, or FormatText when it
+// is absent or empty.
+func (a *App) outputFormat() string {
+	if flags := a.Flags(); flags != nil {
+		if v, ok := flags.String("format"); ok && v != "" {
+			return v
+		}
+	}
+	return FormatText
+}
+
 // formatJSON formats data as JSON with indentation.
 func formatJSON(w io.Writer, data interface{}) error {
 	enc := json.NewEncoder(w)
@@ -59,8 +127,8 @@ func formatText(w io.Writer, data interface{}) error {
 		}
 		return formatTextList(w, list)
 	default:
-		fmt.Fprintf(w, "%v\n", v)
-		return nil
+		_, err := fmt.Fprintf(w, "%v\n", v)
+		return err
 	}
 }
 
@@ -73,14 +141,18 @@ func formatTextMap(w io.Writer, m map[string]interface{}) error {
 	sort.Strings(keys)
 
 	for _, key := range keys {
-		fmt.Fprintf(w, "%s = %s\n", key, formatValue(m[key]))
+		if _, err := fmt.Fprintf(w, "%s = %s\n", key, formatValue(m[key])); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func formatTextList(w io.Writer, list []interface{}) error {
 	for _, item := range list {
-		fmt.Fprintf(w, "%s\n", formatValue(item))
+		if _, err := fmt.Fprintf(w, "%s\n", formatValue(item)); err != nil {
+			return err
+		}
 	}
 	return nil
 }
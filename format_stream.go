@@ -0,0 +1,180 @@
+package clix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormatOutputStream writes the items received from items in the format
+// selected by the --format flag, like FormatOutput does for a slice, but
+// encodes each item as it arrives instead of holding the whole result in
+// memory. It returns once items is closed.
+//
+//   - json writes one indented JSON array, element by element.
+//   - yaml writes one YAML document per item, separated by "---".
+//   - text writes one line per item.
+//
+// After each item the output is flushed if it has a Flush method (such as a
+// *bufio.Writer or an http.ResponseWriter), so readers see results
+// progressively. Table, CSV, TSV and registered formats need every row
+// before they can write anything; for those the items are collected and
+// passed to FormatOutput as one slice.
+//
+// On a write error FormatOutputStream stops writing but keeps receiving and
+// discarding items until the channel is closed, so a producer blocked on a
+// send is never left behind. Producers that can stop early should also watch
+// the command's context, which RunWithSignals cancels on an interrupt:
+//
+//	items := make(chan any)
+//	go func() {
+//		defer close(items)
+//		for row := range rows {
+//			select {
+//			case items <- row:
+//			case <-ctx.Done():
+//				return
+//			}
+//		}
+//	}()
+//	return ctx.App.FormatOutputStream(items)
+func (a *App) FormatOutputStream(items <-chan any) error {
+	// After an error the encoders stop receiving; on success items is
+	// already closed and this returns at once.
+	defer func() {
+		for range items {
+		}
+	}()
+
+	format := strings.ToLower(a.outputFormat())
+	if _, ok := a.formats[format]; ok {
+		return a.FormatOutput(collectItems(items))
+	}
+	w := a.outputWriter()
+	var err error
+	switch format {
+	case FormatJSON:
+		err = streamJSON(w, items)
+	case FormatYAML:
+		err = streamYAML(w, items)
+	case FormatTable, FormatCSV, FormatTSV:
+		return a.FormatOutput(collectItems(items))
+	default:
+		err = streamText(w, items)
+	}
+	return checkBrokenPipe(err)
+}
+
+// collectItems gathers items into a slice of their common type, so tabular
+// formats see e.g. a []Row, falling back to []interface{} for mixed types.
+func collectItems(items <-chan any) interface{} {
+	all := []interface{}{}
+	for item := range items {
+		all = append(all, item)
+	}
+	if len(all) == 0 || all[0] == nil {
+		return all
+	}
+	typ := reflect.TypeOf(all[0])
+	slice := reflect.MakeSlice(reflect.SliceOf(typ), 0, len(all))
+	for _, item := range all {
+		if reflect.TypeOf(item) != typ {
+			return all
+		}
+		slice = reflect.Append(slice, reflect.ValueOf(item))
+	}
+	return slice.Interface()
+}
+
+// flushOutput flushes w if it buffers output.
+func flushOutput(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
+
+// streamJSON writes items as an indented JSON array matching formatJSON's
+// output for the equivalent slice.
+func streamJSON(w io.Writer, items <-chan any) error {
+	n := 0
+	for item := range items {
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if n == 0 {
+			sep = "[\n  "
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		if err := flushOutput(w); err != nil {
+			return err
+		}
+		n++
+	}
+	end := "\n]\n"
+	if n == 0 {
+		end = "[]\n"
+	}
+	if _, err := io.WriteString(w, end); err != nil {
+		return err
+	}
+	return flushOutput(w)
+}
+
+// streamYAML writes each item as its own YAML document.
+func streamYAML(w io.Writer, items <-chan any) error {
+	n := 0
+	for item := range items {
+		// A fresh encoder per item makes yaml.v3 write the document out
+		// straight away; "---" separates it from the previous one.
+		var b strings.Builder
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		if n > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		n++
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+		if err := flushOutput(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamText writes one line per item, as formatText does for a list.
+func streamText(w io.Writer, items <-chan any) error {
+	for item := range items {
+		if _, err := fmt.Fprintf(w, "%s\n", formatValue(item)); err != nil {
+			return err
+		}
+		if err := flushOutput(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
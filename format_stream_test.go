@@ -0,0 +1,187 @@
+package clix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkpointWriter records what had been written each time it is flushed.
+type checkpointWriter struct {
+	bytes.Buffer
+	checkpoints []string
+}
+
+func (w *checkpointWriter) Flush() error {
+	w.checkpoints = append(w.checkpoints, w.String())
+	return nil
+}
+
+type streamItem struct {
+	ID   int    `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+}
+
+func newStreamApp(format string) (*App, *checkpointWriter) {
+	app := NewApp("demo")
+	out := &checkpointWriter{}
+	app.Out = out
+	var value string
+	app.Flags().StringVar(WithFlagName("format"), WithStringValue(&value))
+	value = format
+	return app, out
+}
+
+func sendItems(items ...any) <-chan any {
+	ch := make(chan any)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			ch <- item
+		}
+	}()
+	return ch
+}
+
+func TestFormatOutputStreamJSON(t *testing.T) {
+	items := []any{streamItem{1, "a"}, streamItem{2, "b"}, streamItem{3, "c"}}
+	app, out := newStreamApp(FormatJSON)
+	if err := app.FormatOutputStream(sendItems(items...)); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+
+	var want bytes.Buffer
+	if err := FormatData(&want, items, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want.String() {
+		t.Errorf("stream output differs from FormatData:\n%s\nwant:\n%s", out.String(), want.String())
+	}
+
+	// One flush per item plus the closing bracket, each a growing prefix
+	// holding exactly the items encoded so far.
+	if len(out.checkpoints) != len(items)+1 {
+		t.Fatalf("flushes = %d, want %d", len(out.checkpoints), len(items)+1)
+	}
+	for i, cp := range out.checkpoints[:len(items)] {
+		if got := strings.Count(cp, `"id"`); got != i+1 {
+			t.Errorf("checkpoint %d holds %d items, want %d:\n%s", i, got, i+1, cp)
+		}
+	}
+}
+
+func TestFormatOutputStreamJSONEmpty(t *testing.T) {
+	app, out := newStreamApp(FormatJSON)
+	if err := app.FormatOutputStream(sendItems()); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+	var got []any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("output %q is not an empty JSON array", out.String())
+	}
+}
+
+func TestFormatOutputStreamYAML(t *testing.T) {
+	app, out := newStreamApp(FormatYAML)
+	if err := app.FormatOutputStream(sendItems(streamItem{1, "a"}, streamItem{2, "b"})); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+	if len(out.checkpoints) != 2 || strings.Contains(out.checkpoints[0], "name: b") {
+		t.Fatalf("checkpoints = %q", out.checkpoints)
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(out.String()))
+	var docs []streamItem
+	for {
+		var item streamItem
+		if err := dec.Decode(&item); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("decode: %v\n%s", err, out.String())
+		}
+		docs = append(docs, item)
+	}
+	if want := []streamItem{{1, "a"}, {2, "b"}}; !reflect.DeepEqual(docs, want) {
+		t.Errorf("documents = %+v, want %+v", docs, want)
+	}
+}
+
+func TestFormatOutputStreamText(t *testing.T) {
+	app, out := newStreamApp("")
+	if err := app.FormatOutputStream(sendItems("one", 2, true)); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+	if out.String() != "one\n2\ntrue\n" {
+		t.Errorf("output = %q", out.String())
+	}
+	if len(out.checkpoints) != 3 {
+		t.Errorf("flushes = %d, want 3", len(out.checkpoints))
+	}
+}
+
+func TestFormatOutputStreamCollectsTabularFormats(t *testing.T) {
+	app, out := newStreamApp(FormatCSV)
+	if err := app.FormatOutputStream(sendItems(streamItem{1, "a"}, streamItem{2, "b"})); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+	if !strings.Contains(out.String(), "a") || !strings.Contains(out.String(), "b") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	app, out = newStreamApp("count")
+	app.RegisterFormat("count", func(w io.Writer, v interface{}) error {
+		_, err := io.WriteString(w, strings.Repeat("x", reflect.ValueOf(v).Len()))
+		return err
+	})
+	if err := app.FormatOutputStream(sendItems(1, 2, 3)); err != nil {
+		t.Fatalf("FormatOutputStream: %v", err)
+	}
+	if out.String() != "xxx" {
+		t.Errorf("registered format output = %q, want xxx", out.String())
+	}
+}
+
+func TestFormatOutputStreamReportsBrokenPipe(t *testing.T) {
+	app := NewApp("demo")
+	app.Out = &pipeWriter{err: os.ErrClosed}
+	items := make(chan any, 1)
+	items <- "a"
+	close(items)
+	if err := app.FormatOutputStream(items); !errors.Is(err, ErrBrokenPipe) {
+		t.Fatalf("error = %v, want ErrBrokenPipe", err)
+	}
+}
+
+func TestFormatOutputStreamDrainsAfterError(t *testing.T) {
+	app := NewApp("demo")
+	app.Out = &pipeWriter{err: os.ErrClosed}
+	items := make(chan any)
+	sent := make(chan int, 1)
+	go func() {
+		defer close(items)
+		n := 0
+		for ; n < 3; n++ {
+			items <- n
+		}
+		sent <- n
+	}()
+	if err := app.FormatOutputStream(items); !errors.Is(err, ErrBrokenPipe) {
+		t.Fatalf("error = %v, want ErrBrokenPipe", err)
+	}
+	select {
+	case n := <-sent:
+		if n != 3 {
+			t.Errorf("producer sent %d items, want 3", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("producer still blocked after FormatOutputStream returned")
+	}
+}
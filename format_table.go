@@ -0,0 +1,158 @@
+package clix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// tabularData extracts a header row and cell rows from a slice of records.
+// Records may be maps with string keys, whose headers are the union of all
+// keys in sorted order, or structs (or pointers to structs), whose headers are
+// the exported fields in declaration order, named by their json tag if present.
+func tabularData(format string, data interface{}) ([]string, [][]string, error) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("%s output requires a slice of structs or maps, got %T", format, data)
+	}
+
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	switch {
+	case elem.Kind() == reflect.Struct:
+		return structTable(v, elem)
+	case elem.Kind() == reflect.Map && elem.Key().Kind() == reflect.String,
+		elem.Kind() == reflect.Interface:
+		return mapTable(format, v)
+	default:
+		return nil, nil, fmt.Errorf("%s output requires a slice of structs or maps, got %T", format, data)
+	}
+}
+
+func structTable(v reflect.Value, typ reflect.Type) ([]string, [][]string, error) {
+	var headers []string
+	var fields []int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		headers = append(headers, name)
+		fields = append(fields, i)
+	}
+
+	rows := make([][]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		record := reflect.Indirect(v.Index(i))
+		row := make([]string, len(fields))
+		if record.IsValid() {
+			for j, field := range fields {
+				row[j] = formatValue(record.Field(field).Interface())
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows, nil
+}
+
+func mapTable(format string, v reflect.Value) ([]string, [][]string, error) {
+	records := make([]reflect.Value, 0, v.Len())
+	seen := make(map[string]bool)
+	var headers []string
+	for i := 0; i < v.Len(); i++ {
+		record := v.Index(i)
+		for record.Kind() == reflect.Interface || record.Kind() == reflect.Pointer {
+			record = record.Elem()
+		}
+		if record.Kind() != reflect.Map || record.Type().Key().Kind() != reflect.String {
+			return nil, nil, fmt.Errorf("%s output requires a slice of structs or maps, got element %s", format, record.Kind())
+		}
+		for _, key := range record.MapKeys() {
+			if !seen[key.String()] {
+				seen[key.String()] = true
+				headers = append(headers, key.String())
+			}
+		}
+		records = append(records, record)
+	}
+	sort.Strings(headers)
+
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		row := make([]string, len(headers))
+		for j, header := range headers {
+			if cell := record.MapIndex(reflect.ValueOf(header).Convert(record.Type().Key())); cell.IsValid() {
+				row[j] = formatValue(cell.Interface())
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows, nil
+}
+
+// formatDelimited writes records as CSV or TSV with a header row. Fields
+// containing the delimiter, quotes or newlines are quoted per RFC 4180.
+func formatDelimited(w io.Writer, data interface{}, format string, delimiter rune) error {
+	headers, rows, err := tabularData(format, data)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// formatTable renders records as columns aligned to their widest cell, under
+// an upper-cased header row.
+func formatTable(w io.Writer, data interface{}) error {
+	headers, rows, err := tabularData(FormatTable, data)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, len(headers))
+	for i, h := range headers {
+		header[i] = strings.ToUpper(h)
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	for _, row := range append([][]string{header}, rows...) {
+		var b strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(b.String(), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -66,3 +66,120 @@ func TestFormatData(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatTable(t *testing.T) {
+	t.Run("slice of maps", func(t *testing.T) {
+		var buf bytes.Buffer
+		data := []map[string]interface{}{
+			{"name": "api", "replicas": 3},
+			{"name": "frontend", "status": "ready"},
+		}
+
+		if err := FormatData(&buf, data, FormatTable); err != nil {
+			t.Fatalf("FormatData failed: %v", err)
+		}
+
+		want := "NAME      REPLICAS  STATUS\n" +
+			"api       3\n" +
+			"frontend            ready\n"
+		if buf.String() != want {
+			t.Errorf("unexpected table:\n%s\nwant:\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("slice of structs keeps field order", func(t *testing.T) {
+		type service struct {
+			Name     string
+			Replicas int    `json:"count"`
+			Internal string `json:"-"`
+			secret   string
+		}
+		var buf bytes.Buffer
+		data := []*service{
+			{Name: "api", Replicas: 3, Internal: "x", secret: "y"},
+			{Name: "frontend", Replicas: 12},
+		}
+
+		if err := FormatData(&buf, data, FormatTable); err != nil {
+			t.Fatalf("FormatData failed: %v", err)
+		}
+
+		want := "NAME      COUNT\n" +
+			"api       3\n" +
+			"frontend  12\n"
+		if buf.String() != want {
+			t.Errorf("unexpected table:\n%s\nwant:\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("column order is stable", func(t *testing.T) {
+		data := []map[string]string{{"b": "2", "c": "3", "a": "1"}}
+		first := ""
+		for i := 0; i < 20; i++ {
+			var buf bytes.Buffer
+			if err := FormatData(&buf, data, FormatTable); err != nil {
+				t.Fatalf("FormatData failed: %v", err)
+			}
+			if i == 0 {
+				first = buf.String()
+			} else if buf.String() != first {
+				t.Fatalf("column order changed between runs:\n%s\n%s", first, buf.String())
+			}
+		}
+		if !strings.HasPrefix(first, "A  B  C\n") {
+			t.Errorf("expected sorted headers, got:\n%s", first)
+		}
+	})
+
+	t.Run("rejects non-tabular data", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := FormatData(&buf, map[string]string{"a": "1"}, FormatTable); err == nil {
+			t.Error("expected error for a map")
+		}
+	})
+}
+
+func TestFormatDelimited(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		Notes string `json:"notes"`
+	}
+	data := []user{
+		{Name: "Ada", Email: "ada@example.com", Notes: "plain"},
+		{Name: "Lovelace, Ada", Email: "ada@example.org", Notes: "said \"hi\"\nthen left"},
+	}
+
+	t.Run("csv header and quoting", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := FormatData(&buf, data, FormatCSV); err != nil {
+			t.Fatalf("FormatData failed: %v", err)
+		}
+		want := "name,email,notes\n" +
+			"Ada,ada@example.com,plain\n" +
+			"\"Lovelace, Ada\",ada@example.org,\"said \"\"hi\"\"\nthen left\"\n"
+		if buf.String() != want {
+			t.Errorf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+		}
+	})
+
+	t.Run("tsv leaves commas unquoted", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows := []map[string]string{{"name": "Lovelace, Ada", "tab": "a\tb"}}
+		if err := FormatData(&buf, rows, FormatTSV); err != nil {
+			t.Fatalf("FormatData failed: %v", err)
+		}
+		want := "name\ttab\nLovelace, Ada\t\"a\tb\"\n"
+		if buf.String() != want {
+			t.Errorf("unexpected TSV:\n%q\nwant:\n%q", buf.String(), want)
+		}
+	})
+
+	t.Run("rejects non-tabular data", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := FormatData(&buf, "just a string", FormatCSV)
+		if err == nil || !strings.Contains(err.Error(), "csv output requires a slice of structs or maps") {
+			t.Errorf("expected non-tabular error, got %v", err)
+		}
+	})
+}
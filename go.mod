@@ -3,6 +3,7 @@ module github.com/SCKelemen/clix/v2
 go 1.25.4
 
 require (
+	github.com/pelletier/go-toml/v2 v2.4.3
 	golang.org/x/term v0.41.0
 	gopkg.in/yaml.v3 v3.0.1
 )
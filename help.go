@@ -1,8 +1,10 @@
 package clix
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 )
 
@@ -10,6 +12,40 @@ import (
 type HelpRenderer struct {
 	App     *App
 	Command *Command
+
+	// Width is the column descriptions are wrapped at. Zero uses the width of
+	// the terminal being written to, or 80 when the output is not a terminal.
+	Width int
+
+	// Sort lists flags alphabetically within each section instead of in the
+	// order they were registered. Subcommands are always listed by name
+	// within their section: groups, commands, then one per category.
+	Sort bool
+
+	// Filter limits the subcommand listing to children whose name, aliases or
+	// short description contain it, ignoring case. Sections left without a
+	// match are omitted.
+	Filter string
+
+	out io.Writer // writer passed to Render, for color detection
+}
+
+// styles returns the app styles, or none when color is disabled for the
+// help output.
+func (h HelpRenderer) styles() Styles {
+	if h.out == nil {
+		return h.App.stylesFor(h.App.Out)
+	}
+	return h.App.stylesFor(h.out)
+}
+
+// RenderHelp writes the help for cmd to w, using App.HelpFunc when it is set
+// and HelpRenderer otherwise.
+func (a *App) RenderHelp(ctx context.Context, cmd *Command, w io.Writer) error {
+	if a.HelpFunc != nil {
+		return a.HelpFunc(&Context{Context: ctx, App: a, Command: cmd}, w)
+	}
+	return HelpRenderer{App: a, Command: cmd}.Render(w)
 }
 
 // Render writes the help to the provided writer.
@@ -18,14 +54,16 @@ func (h HelpRenderer) Render(w io.Writer) error {
 	if cmd == nil {
 		return fmt.Errorf("no command provided")
 	}
+	h.Width = h.helpWidth(w)
+	h.out = w
 
-	styles := h.App.Styles
+	styles := h.styles()
 
 	if cmd == h.App.Root {
 		title := strings.ToUpper(h.App.Name)
 		fmt.Fprintf(w, "%s\n", renderText(styles.AppTitle, title))
 		if h.App.Description != "" {
-			desc := renderText(styles.AppDescription, h.App.Description)
+			desc := renderText(styles.AppDescription, wrapParagraphs(h.App.Description, h.Width))
 			fmt.Fprintf(w, "%s\n\n", desc)
 		} else {
 			fmt.Fprintln(w)
@@ -35,7 +73,7 @@ func (h HelpRenderer) Render(w io.Writer) error {
 		if short == "" {
 			short = cmd.Long
 		}
-		fmt.Fprintf(w, "%s\n\n", renderText(styles.CommandTitle, short))
+		fmt.Fprintf(w, "%s\n\n", renderText(styles.CommandTitle, wrapParagraphs(short, h.Width)))
 	}
 
 	usage := cmd.Usage
@@ -45,79 +83,227 @@ func (h HelpRenderer) Render(w io.Writer) error {
 	fmt.Fprintf(w, "%s\n  %s\n\n", renderText(styles.SectionHeading, "USAGE"), renderText(styles.Usage, usage))
 
 	if cmd.Long != "" {
-		long := renderText(styles.CommandTitle, cmd.Long)
+		long := renderText(styles.CommandTitle, wrapParagraphs(cmd.Long, h.Width))
 		fmt.Fprintf(w, "%s\n\n", long)
 	}
 
 	h.renderArguments(w, cmd)
 	h.renderFlags(w, cmd)
+	h.renderGlobalFlags(w, cmd)
 	h.renderChildren(w, cmd)
 
-	if cmd.Example != "" {
-		example := strings.ReplaceAll(cmd.Example, "\n", "\n  ")
-		fmt.Fprintf(w, "%s\n  %s\n", renderText(styles.SectionHeading, "EXAMPLES"), renderText(styles.Example, example))
-	}
+	h.renderExamples(w, cmd)
 
 	return nil
 }
 
+// renderExamples writes the EXAMPLES section. Each line of Command.Example is
+// styled separately and indented under the heading, after stripping the
+// surrounding blank lines and the indentation the lines share, so examples
+// written as indented raw string literals line up like single-line ones.
+func (h HelpRenderer) renderExamples(w io.Writer, cmd *Command) {
+	lines := exampleLines(cmd.Example)
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, renderText(h.styles().SectionHeading, "EXAMPLES"))
+	for _, line := range lines {
+		if line == "" {
+			fmt.Fprintln(w)
+			continue
+		}
+		fmt.Fprintf(w, "  %s\n", renderText(h.styles().Example, line))
+	}
+}
+
+// exampleLines splits an example into lines with leading and trailing blank
+// lines removed and the common leading whitespace trimmed.
+func exampleLines(example string) []string {
+	lines := strings.Split(strings.ReplaceAll(example, "\t", "    "), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = strings.TrimRight(line[indent:], " ")
+	}
+	return lines
+}
+
 func (h HelpRenderer) renderArguments(w io.Writer, cmd *Command) {
-	positionals := cmd.Flags.PositionalFlags()
+	positionals := visibleFlags(cmd.Flags.PositionalFlags())
 	if len(positionals) == 0 {
 		return
 	}
 
 	nameStyle, usageStyle := h.flagStylesFor(cmd == h.App.Root)
 
-	fmt.Fprintln(w, renderText(h.App.Styles.SectionHeading, "ARGUMENTS"))
+	fmt.Fprintln(w, renderText(h.styles().SectionHeading, "ARGUMENTS"))
 	for _, flag := range positionals {
 		label := "<" + flag.Name + ">"
-		renderedName := renderText(nameStyle, label)
 		usage := flag.Usage
 		if flag.Required {
 			usage += " (required)"
 		}
-		usage = renderText(usageStyle, usage)
-		fmt.Fprintf(w, "  %-20s %s\n", renderedName, usage)
+		fmt.Fprint(w, h.formatRow(label, renderText(nameStyle, label), usage, usageStyle))
 	}
 	fmt.Fprintln(w)
 }
 
+// renderFlags lists the command's flags, including the persistent flags it
+// declares or inherits from its groups.
 func (h HelpRenderer) renderFlags(w io.Writer, cmd *Command) {
-	flags := cmd.Flags.Flags()
+	flags := visibleFlags(cmd.parseFlags().Flags())
 	if len(flags) == 0 {
 		return
 	}
 
 	nameStyle, usageStyle := h.flagStylesFor(cmd == h.App.Root)
 
-	fmt.Fprintln(w, renderText(h.App.Styles.SectionHeading, "FLAGS"))
-	for _, flag := range flags {
-		var names []string
-		if flag.Short != "" {
-			names = append(names, "-"+flag.Short)
+	// Uncategorized flags come first under FLAGS, followed by one section per
+	// category in the order each category first appears.
+	for _, group := range groupFlagsByCategory(h.sortFlags(flags)) {
+		heading := "FLAGS"
+		if group.category != "" {
+			heading = strings.ToUpper(group.category)
 		}
-		names = append(names, "--"+flag.Name)
-		renderedNames := renderText(nameStyle, strings.Join(names, ", "))
-		usage := flag.Usage
-		if flag.Required {
-			usage += " (required)"
+		fmt.Fprintln(w, renderText(h.styles().SectionHeading, heading))
+		for _, flag := range group.flags {
+			h.renderFlag(w, flag, nameStyle, usageStyle)
 		}
-		usage = renderText(usageStyle, usage)
-		fmt.Fprintf(w, "  %-20s %s\n", renderedNames, usage)
+		fmt.Fprintln(w)
+	}
+}
+
+// renderGlobalFlags lists the app-level flags under GLOBAL FLAGS in the help
+// of every command but the root, where they already appear under FLAGS.
+// Flags the command defines itself, such as --help, are not repeated.
+func (h HelpRenderer) renderGlobalFlags(w io.Writer, cmd *Command) {
+	root := h.App.Root
+	if cmd == root || root == nil || root.Flags == nil {
+		return
+	}
+
+	own := cmd.parseFlags()
+	var flags []*Flag
+	for _, flag := range visibleFlags(root.Flags.Flags()) {
+		if own != nil && own.lookup(flag.Name) != nil {
+			continue
+		}
+		flags = append(flags, flag)
+	}
+	if len(flags) == 0 {
+		return
+	}
+
+	nameStyle, usageStyle := h.flagStylesFor(true)
+	fmt.Fprintln(w, renderText(h.styles().SectionHeading, "GLOBAL FLAGS"))
+	for _, flag := range h.sortFlags(flags) {
+		h.renderFlag(w, flag, nameStyle, usageStyle)
 	}
 	fmt.Fprintln(w)
 }
 
+// renderFlag writes one flag line: its names followed by its usage.
+func (h HelpRenderer) renderFlag(w io.Writer, flag *Flag, nameStyle, usageStyle TextStyle) {
+	var names []string
+	if flag.Short != "" {
+		names = append(names, "-"+flag.Short)
+	}
+	long := "--" + flag.Name
+	if len(flag.Aliases) > 0 {
+		long += " (--" + strings.Join(flag.Aliases, ", --") + ")"
+	}
+	names = append(names, long)
+	label := strings.Join(names, ", ")
+	usage := flag.Usage
+	if flag.Required {
+		usage += " (required)"
+	}
+	fmt.Fprint(w, h.formatRow(label, renderText(nameStyle, label), usage, usageStyle))
+}
+
+// flagGroup is a run of flags rendered under one help heading.
+type flagGroup struct {
+	category string
+	flags    []*Flag
+}
+
+// groupFlagsByCategory partitions flags by Category, preserving registration
+// order within each group. The uncategorized group, if any, is first.
+func groupFlagsByCategory(flags []*Flag) []flagGroup {
+	groups := []flagGroup{{}}
+	index := map[string]int{"": 0}
+	for _, flag := range flags {
+		i, ok := index[flag.Category]
+		if !ok {
+			i = len(groups)
+			index[flag.Category] = i
+			groups = append(groups, flagGroup{category: flag.Category})
+		}
+		groups[i].flags = append(groups[i].flags, flag)
+	}
+	if len(groups[0].flags) == 0 {
+		groups = groups[1:]
+	}
+	return groups
+}
+
+// sortFlags returns flags ordered by name when Sort is set, and flags
+// unchanged otherwise.
+func (h HelpRenderer) sortFlags(flags []*Flag) []*Flag {
+	if !h.Sort {
+		return flags
+	}
+	sorted := append([]*Flag(nil), flags...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// visibleFlags filters out flags marked Hidden.
+func visibleFlags(flags []*Flag) []*Flag {
+	visible := make([]*Flag, 0, len(flags))
+	for _, flag := range flags {
+		if !flag.Hidden {
+			visible = append(visible, flag)
+		}
+	}
+	return visible
+}
+
 func (h HelpRenderer) buildUsageLine(cmd *Command) string {
 	var b strings.Builder
 	b.WriteString(cmd.Path())
 	b.WriteString(" [flags]")
-	for _, f := range cmd.Flags.PositionalFlags() {
+	for _, f := range visibleFlags(cmd.Flags.PositionalFlags()) {
+		name := f.Name
+		if f.Variadic {
+			name += "..."
+		}
 		if f.Required {
-			fmt.Fprintf(&b, " <%s>", f.Name)
+			fmt.Fprintf(&b, " <%s>", name)
 		} else {
-			fmt.Fprintf(&b, " [%s]", f.Name)
+			fmt.Fprintf(&b, " [%s]", name)
 		}
 	}
 	return b.String()
@@ -125,79 +311,122 @@ func (h HelpRenderer) buildUsageLine(cmd *Command) string {
 
 func (h HelpRenderer) flagStylesFor(isGlobal bool) (name TextStyle, usage TextStyle) {
 	if isGlobal {
-		if h.App.Styles.AppFlagName != nil {
-			name = h.App.Styles.AppFlagName
+		if h.styles().AppFlagName != nil {
+			name = h.styles().AppFlagName
 		} else {
-			name = h.App.Styles.FlagName
+			name = h.styles().FlagName
 		}
-		if h.App.Styles.AppFlagUsage != nil {
-			usage = h.App.Styles.AppFlagUsage
+		if h.styles().AppFlagUsage != nil {
+			usage = h.styles().AppFlagUsage
 		} else {
-			usage = h.App.Styles.FlagUsage
+			usage = h.styles().FlagUsage
 		}
 		return
 	}
 
-	if h.App.Styles.CommandFlagName != nil {
-		name = h.App.Styles.CommandFlagName
+	if h.styles().CommandFlagName != nil {
+		name = h.styles().CommandFlagName
 	} else {
-		name = h.App.Styles.FlagName
+		name = h.styles().FlagName
 	}
 
-	if h.App.Styles.CommandFlagUsage != nil {
-		usage = h.App.Styles.CommandFlagUsage
+	if h.styles().CommandFlagUsage != nil {
+		usage = h.styles().CommandFlagUsage
 	} else {
-		usage = h.App.Styles.FlagUsage
+		usage = h.styles().FlagUsage
 	}
 
 	return
 }
 
-// renderChildren renders both groups and commands, showing them in separate sections.
+// renderChildren renders both groups and commands, showing them in separate
+// sections. Children with a Category are listed afterwards under one heading
+// per category, sorted by name.
 func (h HelpRenderer) renderChildren(w io.Writer, cmd *Command) {
 	visible := cmd.VisibleChildren()
 	if len(visible) == 0 {
 		return
 	}
+	if h.Filter != "" {
+		visible = filterChildren(visible, h.Filter)
+		if len(visible) == 0 {
+			fmt.Fprintf(w, "No commands match %q.\n\n", h.Filter)
+			return
+		}
+	}
 
-	// Separate into groups and commands
+	// Separate into groups, commands and categorized children
 	var groups, commands []*Command
+	categorized := make(map[string][]*Command)
 	for _, child := range visible {
-		if child.IsGroup() {
+		switch {
+		case child.Category != "":
+			categorized[child.Category] = append(categorized[child.Category], child)
+		case child.IsGroup():
 			groups = append(groups, child)
-		} else {
+		default:
 			// Include all non-groups (both leaf commands and commands without Run handlers)
 			commands = append(commands, child)
 		}
 	}
 
 	// Render groups first
-	if len(groups) > 0 {
-		fmt.Fprintln(w, renderText(h.App.Styles.SectionHeading, "GROUPS"))
-		for _, group := range groups {
-			desc := group.Short
-			if desc == "" {
-				desc = group.Long
+	h.renderChildSection(w, "GROUPS", groups)
+	h.renderChildSection(w, "COMMANDS", commands)
+
+	categories := make([]string, 0, len(categorized))
+	for category := range categorized {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		h.renderChildSection(w, strings.ToUpper(category), categorized[category])
+	}
+}
+
+// filterChildren returns the children whose name, aliases or short
+// description contain filter, ignoring case.
+func filterChildren(children []*Command, filter string) []*Command {
+	filter = strings.ToLower(filter)
+	var matched []*Command
+	for _, child := range children {
+		fields := append([]string{child.Name, child.Short}, child.Aliases...)
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), filter) {
+				matched = append(matched, child)
+				break
 			}
-			name := renderText(h.App.Styles.ChildName, group.Name)
-			desc = renderText(h.App.Styles.ChildDesc, desc)
-			fmt.Fprintf(w, "  %-20s %s\n", name, desc)
 		}
-		fmt.Fprintln(w)
 	}
+	return matched
+}
 
-	// Render commands
-	if len(commands) > 0 {
-		fmt.Fprintln(w, renderText(h.App.Styles.SectionHeading, "COMMANDS"))
-		for _, child := range commands {
-			desc := child.Short
-			if desc == "" {
-				desc = child.Long
-			}
-			name := renderText(h.App.Styles.ChildName, child.Name)
-			desc = renderText(h.App.Styles.ChildDesc, desc)
-			fmt.Fprintf(w, "  %-20s %s\n", name, desc)
+// renderChildSection lists children under heading, if there are any.
+func (h HelpRenderer) renderChildSection(w io.Writer, heading string, children []*Command) {
+	if len(children) == 0 {
+		return
+	}
+	fmt.Fprintln(w, renderText(h.styles().SectionHeading, heading))
+	for _, child := range children {
+		fmt.Fprint(w, h.childRow(child))
+	}
+	fmt.Fprintln(w)
+}
+
+// childRow renders a child's name and short description, marking deprecated
+// commands with a styled "(deprecated)" suffix.
+func (h HelpRenderer) childRow(child *Command) string {
+	desc := child.Short
+	if desc == "" {
+		desc = child.Long
+	}
+	row := h.formatRow(child.Name, renderText(h.styles().ChildName, child.Name), desc, h.styles().ChildDesc)
+	if child.Deprecated != "" {
+		marker := renderText(h.styles().Deprecated, "(deprecated)")
+		if strings.TrimSpace(desc) != "" {
+			marker = " " + marker
 		}
-		fmt.Fprintln(w)
+		row = strings.TrimSuffix(row, "\n") + marker + "\n"
 	}
+	return row
 }
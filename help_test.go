@@ -0,0 +1,430 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHelpOmitsHiddenFlags(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	cmd.Short = "Deploy the app"
+
+	var region, debugToken string
+	cmd.Flags.StringVar(StringVarOptions{
+		FlagOptions: FlagOptions{Name: "region", Usage: "Target region"},
+		Value:       &region,
+	})
+	cmd.Flags.StringVar(
+		WithFlagName("debug-token"),
+		WithFlagUsage("Internal debugging token"),
+		WithFlagHidden(),
+		WithStringValue(&debugToken),
+	)
+	app.Root.AddCommand(cmd)
+
+	if _, err := cmd.Flags.Parse([]string{"--debug-token", "secret"}); err != nil {
+		t.Fatalf("expected hidden flag to parse: %v", err)
+	}
+	if debugToken != "secret" {
+		t.Fatalf("expected hidden flag value to be set, got %q", debugToken)
+	}
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+	if !strings.Contains(help, "--region") {
+		t.Errorf("expected visible flag in help, got:\n%s", help)
+	}
+	if strings.Contains(help, "debug-token") || strings.Contains(help, "Internal debugging token") {
+		t.Errorf("expected hidden flag to be omitted from help, got:\n%s", help)
+	}
+}
+
+func TestHelpShowsFlagAliases(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("paint")
+	var color string
+	cmd.Flags.StringVar(
+		WithFlagName("color"),
+		WithFlagShort("c"),
+		WithFlagAliases("colour"),
+		WithFlagUsage("Paint color"),
+		WithStringValue(&color),
+	)
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if help := out.String(); !strings.Contains(help, "-c, --color (--colour)") {
+		t.Errorf("expected canonical name with aliases, got:\n%s", help)
+	}
+}
+
+func TestHelpGroupsFlagsByCategory(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	var region, zone, token, verbose string
+	cmd.Flags.StringVar(WithFlagName("region"), WithFlagCategory("Location"), WithStringValue(&region))
+	cmd.Flags.StringVar(WithFlagName("token"), WithFlagCategory("Auth"), WithStringValue(&token))
+	cmd.Flags.StringVar(WithFlagName("zone"), WithFlagCategory("Location"), WithStringValue(&zone))
+	cmd.Flags.StringVar(WithFlagName("verbosity"), WithStringValue(&verbose))
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+
+	order := []string{"FLAGS", "--verbosity", "LOCATION", "--region", "--zone", "AUTH", "--token"}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(help, want)
+		if i < 0 {
+			t.Fatalf("expected %q in help, got:\n%s", want, help)
+		}
+		if i <= last {
+			t.Fatalf("expected %q after previous entries, got:\n%s", want, help)
+		}
+		last = i
+	}
+}
+
+func TestHiddenCommandsRunButStayOutOfHelp(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	var out bytes.Buffer
+	app.Out = &out
+
+	var ran bool
+	debug := NewCommand("debug-dump")
+	debug.Short = "Dump internal state"
+	debug.Hidden = true
+	debug.Run = func(ctx *Context) error {
+		ran = true
+		return nil
+	}
+	app.Root.AddCommand(debug)
+
+	secret := NewCommand("leak")
+	secret.Hidden = true
+	secret.Run = func(ctx *Context) error { return nil }
+	internal := NewGroup("internal", "Internal tools", secret)
+	app.Root.AddCommand(internal)
+
+	status := NewCommand("status")
+	status.Short = "Show status"
+	status.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(status)
+
+	if err := app.Run(context.Background(), []string{"debug-dump"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected hidden command to run")
+	}
+	if !internal.IsHidden() {
+		t.Error("expected group with only hidden children to be hidden")
+	}
+
+	out.Reset()
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+	if !strings.Contains(help, "status") {
+		t.Errorf("expected visible command in help, got:\n%s", help)
+	}
+	for _, hidden := range []string{"debug-dump", "internal"} {
+		if strings.Contains(help, hidden) {
+			t.Errorf("expected %q to be omitted from help, got:\n%s", hidden, help)
+		}
+	}
+}
+
+func TestHelpRendersExamplesAfterFlags(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	cmd.Short = "Deploy the app"
+	var region string
+	cmd.Flags.StringVar(WithFlagName("region"), WithFlagUsage("Target region"), WithStringValue(&region))
+	cmd.Example = `
+		# Deploy to the default region
+		demo deploy
+
+		# Deploy to a specific region
+		demo deploy --region eu-west1
+	`
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+
+	flags := strings.Index(help, "--region")
+	heading := strings.Index(help, "EXAMPLES\n")
+	if flags < 0 || heading < 0 || heading < flags {
+		t.Fatalf("expected EXAMPLES section after flags, got:\n%s", help)
+	}
+	want := "EXAMPLES\n" +
+		"  # Deploy to the default region\n" +
+		"  demo deploy\n" +
+		"\n" +
+		"  # Deploy to a specific region\n" +
+		"  demo deploy --region eu-west1\n"
+	if !strings.HasSuffix(help, want) {
+		t.Errorf("expected consistently indented examples, got:\n%s", help)
+	}
+}
+
+func TestHelpStylesExampleLines(t *testing.T) {
+	app := NewApp("demo")
+	app.Styles.Example = StyleFunc(func(strs ...string) string {
+		return "<" + strings.Join(strs, "") + ">"
+	})
+	app.Root.Example = "demo one\ndemo two"
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if help := out.String(); !strings.Contains(help, "EXAMPLES\n  <demo one>\n  <demo two>\n") {
+		t.Errorf("expected each example line styled, got:\n%s", help)
+	}
+}
+
+func TestHelpOmitsEmptyExamples(t *testing.T) {
+	app := NewApp("demo")
+	app.Root.Example = "\n  \n"
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if help := out.String(); strings.Contains(help, "EXAMPLES") {
+		t.Errorf("expected no EXAMPLES section, got:\n%s", help)
+	}
+}
+
+func TestHelpGroupsCommandsByCategory(t *testing.T) {
+	app := NewApp("demo")
+	status := NewCommand("status")
+	status.Short = "Show status"
+	status.Run = func(ctx *Context) error { return nil }
+	instances := NewCommand("instances", WithCommandShort("Manage VM instances"), WithCommandCategory("Compute"))
+	instances.Run = func(ctx *Context) error { return nil }
+	buckets := NewCommand("buckets", WithCommandShort("Manage buckets"), WithCommandCategory("Storage"))
+	buckets.Run = func(ctx *Context) error { return nil }
+	disks := NewCommand("disks", WithCommandShort("Manage disks"), WithCommandCategory("Compute"))
+	disks.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(buckets)
+	app.Root.AddCommand(status)
+	app.Root.AddCommand(instances)
+	app.Root.AddCommand(disks)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+
+	order := []string{"COMMANDS", "status", "COMPUTE", "disks", "instances", "STORAGE", "buckets"}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(help, want)
+		if i < 0 {
+			t.Fatalf("expected %q in help, got:\n%s", want, help)
+		}
+		if i <= last {
+			t.Fatalf("expected %q after previous entries, got:\n%s", want, help)
+		}
+		last = i
+	}
+	if strings.Count(help, "  buckets ") != 1 {
+		t.Errorf("expected categorized command to be listed once, got:\n%s", help)
+	}
+}
+
+func TestHelpShowsGlobalFlagsSeparately(t *testing.T) {
+	app := NewApp("demo")
+	var format, region string
+	app.Flags().StringVar(WithFlagName("format"), WithFlagUsage("Output format"), WithStringValue(&format))
+	cmd := NewCommand("deploy")
+	cmd.Flags.StringVar(WithFlagName("region"), WithFlagUsage("Target region"), WithStringValue(&region))
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+
+	flags := strings.Index(help, "FLAGS\n")
+	global := strings.Index(help, "GLOBAL FLAGS\n")
+	if flags < 0 || global < 0 || global < flags {
+		t.Fatalf("expected FLAGS followed by GLOBAL FLAGS, got:\n%s", help)
+	}
+	if i := strings.Index(help, "--region"); i < flags || i > global {
+		t.Errorf("expected --region under FLAGS, got:\n%s", help)
+	}
+	if i := strings.Index(help, "--format"); i < global {
+		t.Errorf("expected --format under GLOBAL FLAGS, got:\n%s", help)
+	}
+	if strings.Count(help, "--help") != 1 {
+		t.Errorf("expected --help listed once, got:\n%s", help)
+	}
+
+	out.Reset()
+	if err := (HelpRenderer{App: app, Command: app.Root}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if help := out.String(); strings.Contains(help, "GLOBAL FLAGS") || !strings.Contains(help, "--format") {
+		t.Errorf("expected root help to list its flags under FLAGS only, got:\n%s", help)
+	}
+}
+
+func TestHelpFuncReplacesDefaultHelp(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	var out bytes.Buffer
+	app.Out = &out
+
+	cmd := NewCommand("deploy")
+	cmd.Short = "Deploy the app"
+	cmd.Run = func(ctx *Context) error { return nil }
+	app.Root.AddCommand(cmd)
+
+	app.HelpFunc = func(ctx *Context, w io.Writer) error {
+		_, err := fmt.Fprintf(w, "custom help for %s\n", ctx.Command.Path())
+		return err
+	}
+
+	for _, args := range [][]string{{"deploy", "--help"}, {"--help", "deploy"}, {"deploy", "-h"}} {
+		out.Reset()
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("run %v failed: %v", args, err)
+		}
+		if got := out.String(); got != "custom help for demo deploy\n" {
+			t.Errorf("run %v: expected custom help only, got:\n%s", args, got)
+		}
+	}
+
+	out.Reset()
+	if err := app.Run(context.Background(), []string{}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if got := out.String(); got != "custom help for demo\n" {
+		t.Errorf("expected custom root help, got:\n%s", got)
+	}
+}
+
+func TestHelpFuncUnsetUsesDefault(t *testing.T) {
+	app := NewApp("demo")
+	app.configLoaded = true
+	var out bytes.Buffer
+	app.Out = &out
+
+	if err := app.Run(context.Background(), []string{"--help"}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if help := out.String(); !strings.Contains(help, "USAGE") {
+		t.Errorf("expected default help, got:\n%s", help)
+	}
+}
+
+func TestHelpSortOrdersFlagsWithinSections(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	var zone, region, token, account string
+	cmd.Flags.StringVar(WithFlagName("zone"), WithStringValue(&zone))
+	cmd.Flags.StringVar(WithFlagName("region"), WithStringValue(&region))
+	cmd.Flags.StringVar(WithFlagName("token"), WithFlagCategory("Auth"), WithStringValue(&token))
+	cmd.Flags.StringVar(WithFlagName("account"), WithFlagCategory("Auth"), WithStringValue(&account))
+	app.Root.AddCommand(cmd)
+
+	render := func(sorted bool) string {
+		var out bytes.Buffer
+		if err := (HelpRenderer{App: app, Command: cmd, Sort: sorted}).Render(&out); err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		return out.String()
+	}
+
+	assertOrder := func(t *testing.T, help string, names ...string) {
+		t.Helper()
+		last := -1
+		for _, name := range names {
+			i := strings.Index(help, name)
+			if i < 0 || i < last {
+				t.Fatalf("expected %q in order, got:\n%s", names, help)
+			}
+			last = i
+		}
+	}
+
+	assertOrder(t, render(false), "--zone", "--region", "AUTH", "--token", "--account")
+	// Sorting stays within each section: categories keep their headings.
+	assertOrder(t, render(true), "--region", "--zone", "AUTH", "--account", "--token")
+}
+
+func TestHelpFilterNarrowsSubcommands(t *testing.T) {
+	app := NewApp("cloud")
+	compute := NewGroup("compute", "Manage virtual machines", NewCommand("list"))
+	storage := NewCommand("storage")
+	storage.Short = "Manage buckets"
+	storage.Run = func(*Context) error { return nil }
+	sql := NewCommand("sql")
+	sql.Short = "Managed databases"
+	sql.Aliases = []string{"db"}
+	sql.Category = "Data"
+	sql.Run = func(*Context) error { return nil }
+	iam := NewCommand("iam")
+	iam.Short = "Identity and access"
+	iam.Run = func(*Context) error { return nil }
+	for _, child := range []*Command{compute, storage, sql, iam} {
+		app.Root.AddCommand(child)
+	}
+
+	render := func(filter string) string {
+		var out bytes.Buffer
+		if err := (HelpRenderer{App: app, Command: app.Root, Filter: filter}).Render(&out); err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		return out.String()
+	}
+
+	help := render("MANAGE")
+	for _, want := range []string{"compute", "storage", "sql"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q to match the filter, got:\n%s", want, help)
+		}
+	}
+	if strings.Contains(help, "iam") {
+		t.Errorf("expected iam to be filtered out, got:\n%s", help)
+	}
+
+	help = render("db")
+	if !strings.Contains(help, "DATA") || !strings.Contains(help, "sql") {
+		t.Errorf("expected alias match under its category, got:\n%s", help)
+	}
+	for _, section := range []string{"GROUPS", "COMMANDS"} {
+		if strings.Contains(help, section) {
+			t.Errorf("expected empty %s section to be omitted, got:\n%s", section, help)
+		}
+	}
+
+	if help := render("nothing"); !strings.Contains(help, `No commands match "nothing".`) {
+		t.Errorf("expected no-match notice, got:\n%s", help)
+	}
+}
@@ -0,0 +1,107 @@
+package clix
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	// defaultHelpWidth is the wrap width used when the output is not a terminal.
+	defaultHelpWidth = 80
+
+	// helpNameColumn is the width of the name column in flag, argument and
+	// command listings; descriptions start after it ("  " + name + " ").
+	helpNameColumn = 20
+
+	// minHelpDescWidth keeps descriptions readable on very narrow terminals.
+	minHelpDescWidth = 20
+)
+
+// helpWidth returns the width help output is wrapped to: Width when set,
+// otherwise the width of the terminal w writes to, falling back to 80.
+func (h HelpRenderer) helpWidth(w io.Writer) int {
+	if h.Width > 0 {
+		return h.Width
+	}
+	if f, ok := w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	return defaultHelpWidth
+}
+
+// formatRow renders a listing row: the name padded to the name column and
+// the description wrapped to the remaining width, with continuation lines
+// indented to the description column. Each description line is styled
+// separately so styles never span the indentation.
+func (h HelpRenderer) formatRow(name, renderedName, desc string, style TextStyle) string {
+	column := 2 + helpNameColumn + 1
+	first := column
+	if n := len([]rune(name)); n > helpNameColumn {
+		first = 2 + n + 1
+	}
+	lines := wrapText(desc, h.descWidth(first), h.descWidth(column))
+	for i, line := range lines {
+		lines[i] = renderText(style, line)
+	}
+	padding := helpNameColumn - len([]rune(name))
+	if padding < 0 {
+		padding = 0
+	}
+	row := "  " + renderedName + strings.Repeat(" ", padding) + " " + strings.Join(lines, "\n"+strings.Repeat(" ", column))
+	return row + "\n"
+}
+
+// descWidth is the room left for a description starting at column.
+func (h HelpRenderer) descWidth(column int) int {
+	if width := h.Width - column; width > minHelpDescWidth {
+		return width
+	}
+	return minHelpDescWidth
+}
+
+// wrapParagraphs wraps each line of text to width, keeping explicit line
+// breaks. Continuation lines repeat the leading indentation of their line.
+func wrapParagraphs(text string, width int) string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(trimmed)]
+		avail := width - len(indent)
+		if avail < minHelpDescWidth {
+			avail = minHelpDescWidth
+		}
+		for _, wrapped := range wrapText(trimmed, avail, avail) {
+			out = append(out, indent+wrapped)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapText breaks text into lines at word boundaries. The first line holds at
+// most first characters and the rest at most rest. Words longer than a line
+// are kept whole rather than split.
+func wrapText(text string, first, rest int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{text}
+	}
+
+	var lines []string
+	line := words[0]
+	limit := first
+	for _, word := range words[1:] {
+		if len([]rune(line))+1+len([]rune(word)) > limit {
+			lines = append(lines, line)
+			line = word
+			limit = rest
+			continue
+		}
+		line += " " + word
+	}
+	return append(lines, line)
+}
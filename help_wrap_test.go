@@ -0,0 +1,116 @@
+package clix
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		first, rest int
+		want        []string
+	}{
+		{"fits", "short text", 20, 20, []string{"short text"}},
+		{"wraps at word boundary", "one two three four", 9, 9, []string{"one two", "three", "four"}},
+		{"narrower first line", "one two three four", 3, 14, []string{"one", "two three four"}},
+		{"keeps long words whole", "a supercalifragilistic word", 10, 10, []string{"a", "supercalifragilistic", "word"}},
+		{"empty", "", 10, 10, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.first, tt.rest); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapText(%q, %d, %d) = %q, want %q", tt.text, tt.first, tt.rest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelpWrapsDescriptionsToWidth(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	cmd.Short = "Deploy the application"
+	var region string
+	cmd.Flags.StringVar(
+		WithFlagName("region"),
+		WithFlagUsage("Region to deploy the application to when no default region is configured"),
+		WithStringValue(&region),
+	)
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd, Width: 50}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	help := out.String()
+
+	want := "" +
+		"  --region             Region to deploy the\n" +
+		"                       application to when no\n" +
+		"                       default region is\n" +
+		"                       configured\n"
+	if !strings.Contains(help, want) {
+		t.Errorf("expected wrapped flag usage with hanging indent, got:\n%s", help)
+	}
+	for _, line := range strings.Split(help, "\n") {
+		if len(line) > 50 {
+			t.Errorf("line exceeds width 50: %q", line)
+		}
+	}
+}
+
+func TestHelpWrapsAfterLongNames(t *testing.T) {
+	app := NewApp("demo")
+	cmd := NewCommand("deploy")
+	var token string
+	cmd.Flags.StringVar(
+		WithFlagName("service-account-token"),
+		WithFlagUsage("Token used to authenticate the deployment"),
+		WithStringValue(&token),
+	)
+	app.Root.AddCommand(cmd)
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: cmd, Width: 50}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := "" +
+		"  --service-account-token Token used to\n" +
+		"                       authenticate the deployment\n"
+	if help := out.String(); !strings.Contains(help, want) {
+		t.Errorf("expected continuation aligned to the description column, got:\n%s", help)
+	}
+}
+
+func TestHelpWrapsLongDescription(t *testing.T) {
+	app := NewApp("demo")
+	app.Root.Long = "This command does a great many things that need explaining.\n\n  Indented notes keep their indentation when wrapped."
+
+	var out bytes.Buffer
+	if err := (HelpRenderer{App: app, Command: app.Root, Width: 30}).Render(&out); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := "" +
+		"This command does a great many\n" +
+		"things that need explaining.\n" +
+		"\n" +
+		"  Indented notes keep their\n" +
+		"  indentation when wrapped.\n"
+	if help := out.String(); !strings.Contains(help, want) {
+		t.Errorf("expected wrapped long description, got:\n%s", help)
+	}
+}
+
+func TestHelpWidthDefaultsTo80(t *testing.T) {
+	var out bytes.Buffer
+	if got := (HelpRenderer{}).helpWidth(&out); got != defaultHelpWidth {
+		t.Errorf("helpWidth() = %d, want %d", got, defaultHelpWidth)
+	}
+	if got := (HelpRenderer{Width: 120}).helpWidth(&out); got != 120 {
+		t.Errorf("helpWidth() with Width = %d, want 120", got)
+	}
+}
@@ -0,0 +1,70 @@
+package clix
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// InteractiveMode controls whether the app may prompt for input.
+type InteractiveMode int
+
+const (
+	// InteractiveAuto prompts only when the prompter reads from a terminal.
+	// A prompter implementing InputSource whose input is a file that is not a
+	// terminal (a pipe, a redirect or /dev/null in CI) is treated as
+	// non-interactive. Other readers and custom prompters are assumed to
+	// supply input on their own.
+	InteractiveAuto InteractiveMode = iota
+	// InteractiveAlways prompts regardless of where input comes from.
+	InteractiveAlways
+	// InteractiveNever never prompts; missing values are reported as errors.
+	InteractiveNever
+)
+
+// InputSource is implemented by prompters that read user input from an
+// io.Reader, so that InteractiveAuto can tell whether it is a terminal.
+// TextPrompter, ext/prompt's TerminalPrompter and ext/prompt/bubble's
+// Prompter implement it.
+type InputSource interface {
+	Input() io.Reader
+}
+
+// ErrNonInteractive is returned, wrapped, when a value is needed but the app
+// may not prompt for it. Test for it with errors.Is.
+var ErrNonInteractive = errors.New("cannot prompt in non-interactive mode")
+
+// IsInteractive reports whether the app may prompt for input, according to
+// App.Interactive. Extensions and handlers that prompt should check it first
+// and fail fast with ErrNonInteractive:
+//
+//	if !ctx.App.IsInteractive() {
+//		return fmt.Errorf("%w: confirm deletion with --yes", clix.ErrNonInteractive)
+//	}
+func (a *App) IsInteractive() bool {
+	switch a.Interactive {
+	case InteractiveAlways:
+		return true
+	case InteractiveNever:
+		return false
+	}
+	p, ok := a.Prompter.(InputSource)
+	if !ok {
+		return true
+	}
+	f, ok := p.Input().(*os.File)
+	return !ok || term.IsTerminal(int(f.Fd()))
+}
+
+// WithAppInteractive sets when the app may prompt for input.
+func WithAppInteractive(mode InteractiveMode) AppOption {
+	return appInteractiveOption(mode)
+}
+
+type appInteractiveOption InteractiveMode
+
+func (o appInteractiveOption) ApplyApp(app *App) {
+	app.Interactive = InteractiveMode(o)
+}
@@ -0,0 +1,80 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func openDevNull(t *testing.T) *os.File {
+	t.Helper()
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestInteractiveNeverFailsWithoutPrompting(t *testing.T) {
+	app := newTestApp(t, "demo", WithAppInteractive(InteractiveNever))
+	cmd := NewCommand("greet", WithCommandRun(func(*Context) error { return nil }))
+	cmd.Flags.StringVar(WithFlagName("name"), WithFlagRequired(), WithFlagPositional())
+	app.Root.AddCommand(cmd)
+	prompted := false
+	app.Prompter = prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		prompted = true
+		return "alice", nil
+	})
+
+	err := app.Run(context.Background(), []string{"greet"})
+	if err == nil || err.Error() != `cannot prompt in non-interactive mode: missing value for "name"` {
+		t.Fatalf("error = %v", err)
+	}
+	if !errors.Is(err, ErrNonInteractive) {
+		t.Error("error does not wrap ErrNonInteractive")
+	}
+	if prompted {
+		t.Error("prompter was called in never mode")
+	}
+}
+
+func TestInteractiveAutoDetectsNonTerminal(t *testing.T) {
+	app := newTestApp(t, "demo")
+	cmd := NewCommand("greet", WithCommandRun(func(*Context) error { return nil }))
+	cmd.Flags.StringVar(WithFlagName("name"), WithFlagRequired(), WithFlagPositional())
+	app.Root.AddCommand(cmd)
+	app.Prompter = TextPrompter{In: openDevNull(t), Out: app.Out}
+	if app.IsInteractive() {
+		t.Fatal("IsInteractive() = true for /dev/null input")
+	}
+	if err := app.Run(context.Background(), []string{"greet"}); !errors.Is(err, ErrNonInteractive) {
+		t.Fatalf("error = %v, want ErrNonInteractive", err)
+	}
+
+	// In-memory readers are scripted input and still count as interactive.
+	app.Prompter = TextPrompter{In: strings.NewReader("alice\n"), Out: app.Out}
+	if !app.IsInteractive() {
+		t.Error("IsInteractive() = false for an in-memory reader")
+	}
+}
+
+func TestInteractiveAlwaysPrompts(t *testing.T) {
+	app := newTestApp(t, "demo")
+	cmd := NewCommand("greet", WithCommandRun(func(*Context) error { return nil }))
+	cmd.Flags.StringVar(WithFlagName("name"), WithFlagRequired(), WithFlagPositional())
+	app.Root.AddCommand(cmd)
+	app.Interactive = InteractiveAlways
+	app.Prompter = TextPrompter{In: openDevNull(t), Out: app.Out}
+	if !app.IsInteractive() {
+		t.Fatal("IsInteractive() = false in always mode")
+	}
+
+	// The prompt is attempted and fails on EOF rather than being refused.
+	err := app.Clone().Run(context.Background(), []string{"greet"})
+	if err == nil || errors.Is(err, ErrNonInteractive) {
+		t.Fatalf("error = %v, want the prompter's EOF error", err)
+	}
+}
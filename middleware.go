@@ -0,0 +1,44 @@
+package clix
+
+// Middleware wraps a command handler. A middleware can run code before and
+// after calling next, replace the error it returns, or short-circuit the
+// command entirely by not calling next.
+//
+// Example:
+//
+//	app.Use(func(next clix.Handler) clix.Handler {
+//		return func(ctx *clix.Context) error {
+//			start := time.Now()
+//			err := next(ctx)
+//			log.Printf("%s took %s", ctx.Command.Path(), time.Since(start))
+//			return err
+//		}
+//	})
+type Middleware func(next Handler) Handler
+
+// Use registers middleware that wraps the Run handler of every command.
+// App middleware is outermost and runs in registration order.
+func (a *App) Use(mw ...Middleware) {
+	a.middleware = append(a.middleware, mw...)
+}
+
+// Use registers middleware that wraps the Run handler of this command and all
+// of its descendants. Middleware on an ancestor wraps middleware on its
+// descendants; within a command, middleware runs in registration order.
+func (c *Command) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// wrapHandler composes the app middleware and the middleware of cmd's lineage
+// around run, outermost first: app, then root down to cmd.
+func (a *App) wrapHandler(cmd *Command, run Handler) Handler {
+	var chain []Middleware
+	chain = append(chain, a.middleware...)
+	for _, c := range cmd.lineage() {
+		chain = append(chain, c.middleware...)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		run = chain[i](run)
+	}
+	return run
+}
@@ -0,0 +1,113 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	trace := func(calls *[]string, name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx *Context) error {
+				*calls = append(*calls, name+":before")
+				err := next(ctx)
+				*calls = append(*calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	newApp := func(calls *[]string, run Handler) *App {
+		app := NewApp("demo")
+		app.configLoaded = true
+		leaf := NewCommand("leaf")
+		leaf.Run = run
+		group := NewGroup("group", "A group", leaf)
+		app.Root.AddCommand(group)
+
+		app.Use(trace(calls, "app1"), trace(calls, "app2"))
+		app.Root.Use(trace(calls, "root"))
+		group.Use(trace(calls, "group"))
+		leaf.Use(trace(calls, "leaf"))
+		return app
+	}
+
+	t.Run("ordering", func(t *testing.T) {
+		var calls []string
+		app := newApp(&calls, func(ctx *Context) error {
+			calls = append(calls, "run")
+			return nil
+		})
+		if err := app.Run(context.Background(), []string{"group", "leaf"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		want := []string{
+			"app1:before", "app2:before", "root:before", "group:before", "leaf:before",
+			"run",
+			"leaf:after", "group:after", "root:after", "app2:after", "app1:after",
+		}
+		if strings.Join(calls, ",") != strings.Join(want, ",") {
+			t.Errorf("unexpected order:\n got  %v\n want %v", calls, want)
+		}
+	})
+
+	t.Run("short-circuit", func(t *testing.T) {
+		var calls []string
+		app := newApp(&calls, func(ctx *Context) error {
+			calls = append(calls, "run")
+			return nil
+		})
+		denied := errors.New("not authorized")
+		app.Use(func(next Handler) Handler {
+			return func(ctx *Context) error { return denied }
+		})
+		err := app.Run(context.Background(), []string{"group", "leaf"})
+		if !errors.Is(err, denied) {
+			t.Fatalf("expected short-circuit error, got %v", err)
+		}
+		for _, call := range calls {
+			if call == "run" || strings.HasPrefix(call, "root") {
+				t.Errorf("expected inner handlers not to run, got %v", calls)
+				break
+			}
+		}
+	})
+
+	t.Run("errors propagate", func(t *testing.T) {
+		var calls []string
+		boom := errors.New("boom")
+		var seen error
+		app := newApp(&calls, func(ctx *Context) error { return boom })
+		app.Use(func(next Handler) Handler {
+			return func(ctx *Context) error {
+				seen = next(ctx)
+				return seen
+			}
+		})
+		if err := app.Run(context.Background(), []string{"group", "leaf"}); !errors.Is(err, boom) {
+			t.Fatalf("expected handler error, got %v", err)
+		}
+		if !errors.Is(seen, boom) {
+			t.Errorf("expected middleware to observe handler error, got %v", seen)
+		}
+	})
+
+	t.Run("sibling middleware does not apply", func(t *testing.T) {
+		var calls []string
+		app := NewApp("demo")
+		app.configLoaded = true
+		a := NewCommand("a", WithCommandRun(func(ctx *Context) error { return nil }))
+		b := NewCommand("b", WithCommandRun(func(ctx *Context) error { return nil }))
+		a.Use(trace(&calls, "a"))
+		app.Root.AddCommand(a)
+		app.Root.AddCommand(b)
+		if err := app.Run(context.Background(), []string{"b"}); err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("expected no middleware calls, got %v", calls)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package clix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// ErrBrokenPipe is returned, wrapping the write error, by Context.Print,
+// Context.Printf, Context.Println and App.FormatOutput when the output has
+// been closed by its reader, as when piping to head. App.Run treats it as a
+// clean exit: a handler that returns it succeeds.
+var ErrBrokenPipe = errors.New("broken pipe")
+
+// checkBrokenPipe wraps err with ErrBrokenPipe if it reports EPIPE or a
+// closed file.
+func checkBrokenPipe(err error) error {
+	if err != nil && (errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)) {
+		return fmt.Errorf("%w: %w", ErrBrokenPipe, err)
+	}
+	return err
+}
+
+// outputFlagName is the root flag that redirects command output to a file.
+// ext/format registers it as --output / -o.
+const outputFlagName = "output"
+
+// openOutput creates (or truncates) the file named by the --output flag so
+// FormatOutput and Context.OutputWriter write to it for the rest of the run.
+// It does nothing when the flag is absent, empty or "-".
+func (a *App) openOutput() error {
+	path, _ := a.Flags().String(outputFlagName)
+	if path == "" || path == "-" {
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	a.output = file
+	return nil
+}
+
+// closeOutput closes the file opened by openOutput, if any.
+func (a *App) closeOutput() error {
+	if a.output == nil {
+		return nil
+	}
+	err := a.output.Close()
+	a.output = nil
+	return err
+}
+
+// outputWriter returns the --output file while a command runs, or a.Out.
+func (a *App) outputWriter() io.Writer {
+	if a.output != nil {
+		return a.output
+	}
+	return a.Out
+}
+
+// OutputWriter returns the writer commands should send their results to: the
+// file named by --output (see ext/format) while the command runs, or app.Out
+// otherwise. App.Run closes the file after the command returns.
+//
+// Example:
+//
+//	cmd.Run = func(ctx *clix.Context) error {
+//		fmt.Fprintln(ctx.OutputWriter(), "done")
+//		return nil
+//	}
+func (ctx *Context) OutputWriter() io.Writer {
+	if ctx.App == nil {
+		return os.Stdout
+	}
+	return ctx.App.outputWriter()
+}
+
+// Print writes to OutputWriter like fmt.Fprint. A closed pipe is reported as
+// ErrBrokenPipe, so handlers can return the error unchanged:
+//
+//	for _, item := range items {
+//		if err := ctx.Println(item.Name); err != nil {
+//			return err // a closed pipe ends the command cleanly
+//		}
+//	}
+func (ctx *Context) Print(a ...any) error {
+	_, err := fmt.Fprint(ctx.OutputWriter(), a...)
+	return checkBrokenPipe(err)
+}
+
+// Printf writes to OutputWriter like fmt.Fprintf. See Print.
+func (ctx *Context) Printf(format string, a ...any) error {
+	_, err := fmt.Fprintf(ctx.OutputWriter(), format, a...)
+	return checkBrokenPipe(err)
+}
+
+// Println writes to OutputWriter like fmt.Fprintln. See Print.
+func (ctx *Context) Println(a ...any) error {
+	_, err := fmt.Fprintln(ctx.OutputWriter(), a...)
+	return checkBrokenPipe(err)
+}
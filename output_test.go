@@ -0,0 +1,86 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+// pipeWriter accepts limit writes and then fails like stdout piped to a
+// reader that exited.
+type pipeWriter struct {
+	limit  int
+	writes int
+	err    error
+}
+
+func (w *pipeWriter) Write(p []byte) (int, error) {
+	if w.writes >= w.limit {
+		return 0, &os.PathError{Op: "write", Path: "/dev/stdout", Err: w.err}
+	}
+	w.writes++
+	return len(p), nil
+}
+
+func TestBrokenPipeIsCleanExit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	code := -1
+	origExit := forceExit
+	forceExit = func(c int) { code = c }
+	t.Cleanup(func() { forceExit = origExit })
+
+	out := &pipeWriter{limit: 2, err: syscall.EPIPE}
+	var stderr bytes.Buffer
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.Out = out
+	app.Err = &stderr
+
+	var printErr error
+	cmd := NewCommand("list")
+	cmd.Run = func(ctx *Context) error {
+		for i := 0; ; i++ {
+			if err := ctx.Printf("item %d\n", i); err != nil {
+				printErr = err
+				return err
+			}
+		}
+	}
+	app.Root.AddCommand(cmd)
+
+	app.Main([]string{"list"})
+
+	if !errors.Is(printErr, ErrBrokenPipe) || !errors.Is(printErr, syscall.EPIPE) {
+		t.Errorf("Printf error = %v, want ErrBrokenPipe wrapping EPIPE", printErr)
+	}
+	if code != 0 {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing", stderr.String())
+	}
+}
+
+func TestFormatOutputReportsBrokenPipe(t *testing.T) {
+	app := NewApp("demo")
+	app.Out = &pipeWriter{err: os.ErrClosed}
+	if err := app.FormatOutput(map[string]string{"a": "b"}); !errors.Is(err, ErrBrokenPipe) {
+		t.Fatalf("FormatOutput error = %v, want ErrBrokenPipe", err)
+	}
+}
+
+func TestOtherWriteErrorsAreNotBrokenPipe(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.Out = &pipeWriter{err: syscall.ENOSPC}
+	app.Root.Run = func(ctx *Context) error { return ctx.Println("hello") }
+
+	err := app.Run(context.Background(), []string{})
+	if !errors.Is(err, syscall.ENOSPC) || errors.Is(err, ErrBrokenPipe) {
+		t.Fatalf("Run error = %v, want ENOSPC without ErrBrokenPipe", err)
+	}
+}
@@ -0,0 +1,93 @@
+package clix
+
+// persistentSets returns the persistent flag sets visible to the command: its
+// own followed by those of its ancestors, nearest first. The root command is
+// skipped because its persistent set is its Flags, the app flags, which are
+// already global (see shareRootFlags).
+func (c *Command) persistentSets() []*FlagSet {
+	var sets []*FlagSet
+	for cmd := c; cmd != nil && cmd.parent != nil; cmd = cmd.parent {
+		if cmd.PersistentFlags != nil && len(cmd.PersistentFlags.flags) > 0 {
+			sets = append(sets, cmd.PersistentFlags)
+		}
+	}
+	return sets
+}
+
+// shareRootFlags makes the root command's PersistentFlags the same set as its
+// Flags. Flags already registered on the persistent set move to Flags, so
+// app.Root.PersistentFlags and app.Flags() are interchangeable on the root.
+func (c *Command) shareRootFlags() {
+	if c.PersistentFlags == c.Flags {
+		return
+	}
+	if c.PersistentFlags != nil {
+		for _, flag := range c.PersistentFlags.flags {
+			c.Flags.addFlag(flag)
+		}
+	}
+	c.PersistentFlags = c.Flags
+}
+
+// parseFlags returns the flag set App.Run parses a command's arguments with:
+// the command's own flags plus every persistent flag it inherits. The merged
+// set shares its *Flag values with the originals, so parsed state lands on the
+// flags where they were declared. A command flag shadows an inherited flag of
+// the same name, and a nearer group shadows a farther one.
+func (c *Command) parseFlags() *FlagSet {
+	return c.Flags.inherit(c.persistentSets()...)
+}
+
+// inherit returns fs extended with the flags of sets, which only take names
+// and short names fs and earlier sets leave free. The result parses with the
+// strictness and interspersing of fs. fs itself is returned when there is
+// nothing to add.
+func (fs *FlagSet) inherit(sets ...*FlagSet) *FlagSet {
+	var extra []*FlagSet
+	for _, set := range sets {
+		if set != nil && set != fs && len(set.flags) > 0 {
+			extra = append(extra, set)
+		}
+	}
+	if len(extra) == 0 {
+		return fs
+	}
+	merged := &FlagSet{
+		name:         fs.name,
+		flags:        append([]*Flag(nil), fs.flags...),
+		index:        make(map[string]*Flag, len(fs.index)),
+		strict:       fs.strict,
+		interspersed: fs.interspersed,
+	}
+	for key, flag := range fs.index {
+		merged.index[key] = flag
+	}
+	for _, set := range extra {
+		for _, flag := range set.flags {
+			if _, shadowed := merged.index["--"+flag.Name]; shadowed {
+				continue
+			}
+			merged.flags = append(merged.flags, flag)
+			for key, f := range set.index {
+				if _, taken := merged.index[key]; f == flag && !taken {
+					merged.index[key] = flag
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// commandFlagSets returns the flag sets consulted before the app flags when
+// resolving a value: the command's own flags, then its persistent flags and
+// those of its ancestors, nearest first.
+func (ctx *Context) commandFlagSets() []*FlagSet {
+	if ctx.Command == nil {
+		return nil
+	}
+	var sets []*FlagSet
+	if ctx.Command.Flags != nil {
+		sets = append(sets, ctx.Command.Flags)
+	}
+	return append(sets, ctx.Command.persistentSets()...)
+}
@@ -0,0 +1,152 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func newPersistentApp(project *string, got *string) *App {
+	app := NewApp("tool")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+
+	deploy := NewCommand("deploy")
+	deploy.Short = "Deploy the project"
+	deploy.Run = func(ctx *Context) error {
+		*got, _ = ctx.String("project")
+		return nil
+	}
+	group := NewGroup("proj", "Manage projects", deploy)
+	group.PersistentFlags.StringVar(
+		WithFlagName("project"),
+		WithFlagShort("p"),
+		WithFlagUsage("Project to operate on"),
+		WithFlagEnvVar("TOOL_PROJECT"),
+		WithStringDefault("default-project"),
+		WithStringValue(project),
+	)
+	app.Root.AddCommand(group)
+	return app
+}
+
+func TestPersistentFlagSetFromLeaf(t *testing.T) {
+	for _, args := range [][]string{
+		{"proj", "deploy", "--project", "api"},
+		{"proj", "deploy", "-p", "api"},
+		{"proj", "deploy", "--project=api"},
+	} {
+		var project, got string
+		app := newPersistentApp(&project, &got)
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if got != "api" {
+			t.Errorf("Run(%q): ctx.String = %q, want api", args, got)
+		}
+		if project != "api" {
+			t.Errorf("Run(%q): bound value = %q, want api", args, project)
+		}
+	}
+}
+
+func TestPersistentFlagPrecedence(t *testing.T) {
+	var project, got string
+	app := newPersistentApp(&project, &got)
+	if err := app.Run(context.Background(), []string{"proj", "deploy"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got != "default-project" {
+		t.Errorf("default: got %q, want default-project", got)
+	}
+
+	t.Setenv("TOOL_PROJECT", "from-env")
+	if err := app.Run(context.Background(), []string{"proj", "deploy"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got != "from-env" {
+		t.Errorf("env: got %q, want from-env", got)
+	}
+
+	// An app flag of the same name ranks below the inherited flag.
+	var global string
+	app.Flags().StringVar(WithFlagName("project"), WithStringValue(&global))
+	if err := app.Run(context.Background(), []string{"--project", "root", "proj", "deploy", "--project", "leaf"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got != "leaf" {
+		t.Errorf("cli: got %q, want leaf", got)
+	}
+}
+
+func TestPersistentFlagShadowedByCommandFlag(t *testing.T) {
+	var project, got, own string
+	app := newPersistentApp(&project, &got)
+	deploy := app.Root.Children[0].Children[0]
+	deploy.Flags.StringVar(WithFlagName("project"), WithStringValue(&own))
+
+	if err := app.Run(context.Background(), []string{"proj", "deploy", "--project", "mine"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if own != "mine" || got != "mine" {
+		t.Errorf("own = %q, ctx = %q, want mine", own, got)
+	}
+	if project != "default-project" {
+		t.Errorf("inherited flag = %q, want it left at its default", project)
+	}
+}
+
+func TestPersistentFlagInLeafHelp(t *testing.T) {
+	var project, got string
+	app := newPersistentApp(&project, &got)
+	if err := app.Run(context.Background(), []string{"proj", "deploy", "--help"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	out := app.Out.(*bytes.Buffer).String()
+	if !strings.Contains(out, "--project") || !strings.Contains(out, "Project to operate on") {
+		t.Errorf("help does not list the inherited flag:\n%s", out)
+	}
+}
+
+func TestRootPersistentFlagsAreAppFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--region", "eu", "proj", "deploy"},
+		{"proj", "deploy", "--region", "eu"},
+	} {
+		var project, got string
+		app := newPersistentApp(&project, &got)
+		var region string
+		app.Root.PersistentFlags.StringVar(WithFlagName("region"), WithStringValue(&region))
+		if app.Root.PersistentFlags != app.Flags() {
+			t.Fatal("root PersistentFlags is not the app flag set")
+		}
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if region != "eu" {
+			t.Errorf("Run(%q): region = %q, want eu", args, region)
+		}
+	}
+}
+
+func TestRootPersistentFlagsMoveToAppFlags(t *testing.T) {
+	root := NewCommand("tool")
+	var region string
+	root.PersistentFlags.StringVar(WithFlagName("region"), WithStringValue(&region))
+	root.Run = func(*Context) error { return nil }
+	app := NewApp("tool", WithAppRoot(root))
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+
+	if err := app.Run(context.Background(), []string{"--region", "eu"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if region != "eu" {
+		t.Errorf("region = %q, want eu", region)
+	}
+	if app.Flags().lookup("region") == nil {
+		t.Error("expected --region among the app flags")
+	}
+}
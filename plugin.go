@@ -0,0 +1,66 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// EnableExternalPlugins makes the app run external executables for command
+// names it does not know, the way git runs git-foo for "git foo". When the
+// root command has no Run handler and the first argument matches no built-in
+// command, Run looks for an executable named prefix-<name> on PATH and runs it
+// with the arguments after <name>, connected to In, Out and Err. Those
+// arguments are passed exactly as given: app flags such as --help or --format
+// after the name are the plugin's to handle, while those before it belong to
+// the app. Its exit status becomes an ExitError. An empty prefix uses the app
+// name.
+//
+// Plugins are off by default: any executable on PATH with a matching name can
+// then run as part of the app, so only enable them for tools meant to be
+// extended this way.
+//
+// Example:
+//
+//	app.EnableExternalPlugins("")
+//	// "myapp deploy --fast" runs "myapp-deploy --fast" when myapp has no
+//	// deploy command.
+func (a *App) EnableExternalPlugins(prefix string) {
+	if prefix == "" {
+		prefix = a.Name
+	}
+	a.pluginPrefix = prefix
+}
+
+// lookupPlugin returns the path of the plugin executable for rest[0], if
+// plugins are enabled and cmd is the root command without a handler.
+func (a *App) lookupPlugin(cmd *Command, rest []string) (string, bool) {
+	if a.pluginPrefix == "" || cmd != a.Root || cmd.Run != nil || len(rest) == 0 {
+		return "", false
+	}
+	name := rest[0]
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(a.pluginPrefix + "-" + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// runPlugin runs the plugin at path with args, forwarding the app's streams.
+func (a *App) runPlugin(ctx context.Context, path string, args []string) error {
+	plugin := exec.CommandContext(ctx, path, args...)
+	plugin.Stdin = a.In
+	plugin.Stdout = a.Out
+	plugin.Stderr = a.Err
+	err := plugin.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// The plugin reports its own errors; only its status is passed on.
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
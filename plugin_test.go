@@ -0,0 +1,110 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writePlugin(t *testing.T, name, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a POSIX shell")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+func newPluginApp() *App {
+	app := NewApp("tool")
+	app.configLoaded = true
+	app.In = &bytes.Buffer{}
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	status := NewCommand("status")
+	status.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(status)
+	return app
+}
+
+func TestExternalPluginForwardsArgsAndExitCode(t *testing.T) {
+	writePlugin(t, "tool-deploy", `echo "args: $*"; echo "to stderr" >&2; exit 3`)
+
+	app := newPluginApp()
+	app.EnableExternalPlugins("")
+
+	err := app.Run(context.Background(), []string{"deploy", "--fast", "prod"})
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("error = %v, want ExitError with code 3", err)
+	}
+	if got := app.Out.(*bytes.Buffer).String(); got != "args: --fast prod\n" {
+		t.Errorf("stdout = %q", got)
+	}
+	if got := app.Err.(*bytes.Buffer).String(); got != "to stderr\n" {
+		t.Errorf("stderr = %q", got)
+	}
+}
+
+func TestExternalPluginSuccessAndStdin(t *testing.T) {
+	writePlugin(t, "ext-greet", `read name; echo "hello $name"`)
+
+	app := newPluginApp()
+	app.In = bytes.NewBufferString("ada\n")
+	app.EnableExternalPlugins("ext")
+
+	if err := app.Run(context.Background(), []string{"greet"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := app.Out.(*bytes.Buffer).String(); got != "hello ada\n" {
+		t.Errorf("stdout = %q", got)
+	}
+}
+
+func TestExternalPluginsOffByDefault(t *testing.T) {
+	writePlugin(t, "tool-deploy", "exit 0")
+
+	app := newPluginApp()
+	if err := app.Run(context.Background(), []string{"deploy"}); !errors.Is(err, ErrUnknownCommand) {
+		t.Fatalf("error = %v, want ErrUnknownCommand", err)
+	}
+
+	// Built-in commands win over plugins of the same name.
+	writePlugin(t, "tool-status", "exit 7")
+	app.EnableExternalPlugins("")
+	if err := app.Run(context.Background(), []string{"status"}); err != nil {
+		t.Fatalf("Run status: %v", err)
+	}
+}
+
+func TestExternalPluginReceivesAppFlagsAfterItsName(t *testing.T) {
+	writePlugin(t, "tool-foo", `echo "args: $*"`)
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"foo", "-h"}, "args: -h\n"},
+		{[]string{"foo", "bar", "--format", "json"}, "args: bar --format json\n"},
+		{[]string{"--format", "json", "foo", "bar"}, "args: bar\n"},
+	} {
+		app := newPluginApp()
+		var format string
+		app.Flags().StringVar(StringVarOptions{FlagOptions: FlagOptions{Name: "format"}, Value: &format})
+		app.EnableExternalPlugins("")
+
+		if err := app.Run(context.Background(), tc.args); err != nil {
+			t.Fatalf("Run(%q): %v", tc.args, err)
+		}
+		if got := app.Out.(*bytes.Buffer).String(); got != tc.want {
+			t.Errorf("Run(%q) stdout = %q, want %q", tc.args, got, tc.want)
+		}
+	}
+}
@@ -1,12 +1,12 @@
 package clix
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 // Prompter encapsulates interactive prompting.
@@ -92,6 +92,11 @@ type PromptRequest struct {
 	// Returns "y" or "n" (or "yes"/"no").
 	Confirm bool
 
+	// Abort adds a third answer to confirm prompts: "a" or "abort" makes the
+	// prompt return ErrAborted, so callers can tell "stop everything" apart
+	// from a plain "no".
+	Abort bool
+
 	// ContinueText is the text shown for the continue button in select prompts.
 	ContinueText string
 
@@ -101,6 +106,37 @@ type PromptRequest struct {
 
 	// KeyMap configures keyboard shortcuts for the prompt.
 	KeyMap PromptKeyMap
+
+	// Timeout bounds how long a prompt waits for the user. When it elapses,
+	// the prompt answers as if the user had accepted Default: a text prompt
+	// returns it if Validate accepts it, a confirm prompt answers yes or no
+	// accordingly. Without a default the prompt returns ErrPromptTimeout.
+	// Zero means wait indefinitely.
+	Timeout time.Duration
+
+	// Multiline accepts several lines of input, for values such as commit
+	// messages. Input ends at a line equal to MultilineEnd or at EOF (Ctrl-D),
+	// and the lines are returned joined by "\n".
+	Multiline bool
+
+	// MultilineEnd is the line that finishes a multi-line prompt.
+	// Defaults to ".".
+	MultilineEnd string
+
+	// PageSize is the number of options an interactive select prompt shows at
+	// once; the list scrolls as the selection moves past either edge. Zero
+	// fits the list to the terminal height.
+	PageSize int
+
+	// History records each submitted answer to a text prompt. Interactive
+	// prompters let the user recall earlier entries with the Up and Down arrows.
+	History *PromptHistory
+
+	// Suggest returns completion candidates for the current input of a text
+	// prompt, such as matching file paths or known values. Interactive
+	// prompters show the first match as ghost text; Tab completes to the
+	// candidates' common prefix, and further presses cycle through them.
+	Suggest func(input string) []string
 }
 
 // Apply implements PromptOption so PromptRequest can be used directly.
@@ -129,6 +165,9 @@ func (r PromptRequest) Apply(cfg *PromptConfig) {
 	if r.Confirm {
 		cfg.Confirm = true
 	}
+	if r.Abort {
+		cfg.Abort = true
+	}
 	if r.ContinueText != "" {
 		cfg.ContinueText = r.ContinueText
 	}
@@ -138,6 +177,24 @@ func (r PromptRequest) Apply(cfg *PromptConfig) {
 	if r.KeyMap.isConfigured() {
 		cfg.KeyMap = r.KeyMap
 	}
+	if r.Timeout > 0 {
+		cfg.Timeout = r.Timeout
+	}
+	if r.Multiline {
+		cfg.Multiline = true
+	}
+	if r.MultilineEnd != "" {
+		cfg.MultilineEnd = r.MultilineEnd
+	}
+	if r.PageSize > 0 {
+		cfg.PageSize = r.PageSize
+	}
+	if r.History != nil {
+		cfg.History = r.History
+	}
+	if r.Suggest != nil {
+		cfg.Suggest = r.Suggest
+	}
 }
 
 // PromptConfig holds all prompt configuration internally.
@@ -151,11 +208,39 @@ type PromptConfig struct {
 	Options              []SelectOption
 	MultiSelect          bool
 	Confirm              bool
+	Abort                bool
 	ContinueText         string
 	CommandHandler       PromptCommandHandler
 	KeyMap               PromptKeyMap
+	Timeout              time.Duration
+	Multiline            bool
+	MultilineEnd         string
+	PageSize             int
+	History              *PromptHistory
+	Suggest              func(input string) []string
 }
 
+// DefaultMultilineEnd is the line that finishes a multi-line prompt when
+// PromptRequest.MultilineEnd is empty.
+const DefaultMultilineEnd = "."
+
+// MultilineHint explains how to finish a multi-line prompt whose input ends at
+// the line end.
+func MultilineHint(end string) string {
+	if end == "" {
+		end = DefaultMultilineEnd
+	}
+	return fmt.Sprintf("(finish with a line containing only %q, or Ctrl-D)", end)
+}
+
+// ErrPromptTimeout is returned when a prompt's Timeout elapses before the
+// user submits a value and the prompt has no Default to fall back to.
+var ErrPromptTimeout = errors.New("prompt timed out")
+
+// ErrAborted is returned by confirm prompts with Abort enabled when the user
+// answers "a" or "abort".
+var ErrAborted = errors.New("prompt aborted")
+
 // PromptCommandType identifies a special key command intercepted by interactive prompts.
 type PromptCommandType int
 
@@ -400,6 +485,87 @@ func WithConfirm() PromptOption {
 	})
 }
 
+// WithAbort lets a confirm prompt be aborted with "a" or "abort", which
+// returns ErrAborted (functional option).
+//
+// Example:
+//
+//	_, err := prompter.Prompt(ctx, clix.WithLabel("Overwrite?"), clix.WithConfirm(), clix.WithAbort())
+//	if errors.Is(err, clix.ErrAborted) {
+//		return nil // stop without changing anything
+//	}
+func WithAbort() PromptOption {
+	return TextPromptOption(func(cfg *PromptConfig) {
+		cfg.Abort = true
+	})
+}
+
+// WithTimeout bounds how long a prompt waits for input (functional option).
+// When the timeout elapses the prompt answers with its default, or returns
+// ErrPromptTimeout if it has none. See PromptRequest.Timeout.
+//
+// Example:
+//
+//	result, err := prompter.Prompt(ctx,
+//		clix.WithLabel("Region"),
+//		clix.WithDefault("us-east-1"),
+//		clix.WithTimeout(30*time.Second),
+//	)
+func WithTimeout(timeout time.Duration) PromptOption {
+	return TextPromptOption(func(cfg *PromptConfig) {
+		cfg.Timeout = timeout
+	})
+}
+
+// WithMultiline accepts several lines of input, finished by a line containing
+// only "." or by EOF (functional option). See PromptRequest.Multiline.
+//
+// Example:
+//
+//	message, err := prompter.Prompt(ctx,
+//		clix.WithLabel("Commit message"),
+//		clix.WithMultiline(),
+//	)
+func WithMultiline() PromptOption {
+	return TextPromptOption(func(cfg *PromptConfig) {
+		cfg.Multiline = true
+	})
+}
+
+// WithHistory records submitted answers in history and, in interactive
+// prompters, lets the user recall them with the Up and Down arrows
+// (functional option). See PromptRequest.History.
+//
+// Example:
+//
+//	history := clix.NewPromptHistory(50)
+//	query, err := prompter.Prompt(ctx,
+//		clix.WithLabel("Query"),
+//		clix.WithHistory(history),
+//	)
+func WithHistory(history *PromptHistory) PromptOption {
+	return TextPromptOption(func(cfg *PromptConfig) {
+		cfg.History = history
+	})
+}
+
+// WithSuggest completes text prompts against the candidates suggest returns
+// for the current input (functional option). See PromptRequest.Suggest.
+//
+// Example:
+//
+//	region, err := prompter.Prompt(ctx,
+//		clix.WithLabel("Region"),
+//		clix.WithSuggest(func(input string) []string {
+//			return []string{"europe-west1", "europe-west4", "us-central1"}
+//		}),
+//	)
+func WithSuggest(suggest func(input string) []string) PromptOption {
+	return TextPromptOption(func(cfg *PromptConfig) {
+		cfg.Suggest = suggest
+	})
+}
+
 // SelectOption represents a choice in a select or multi-select prompt.
 //
 // Example:
@@ -544,9 +710,18 @@ type TextPrompter struct {
 	Out io.Writer
 }
 
+// Input returns In, for InteractiveAuto.
+func (p TextPrompter) Input() io.Reader {
+	return p.In
+}
+
 // Prompt displays a text prompt and reads the user's response.
 // Accepts both struct-based PromptRequest and functional options for flexibility.
 // Advanced prompt options (Select, MultiSelect) are rejected - use the prompt extension for those.
+//
+// Prompt returns ctx.Err() as soon as ctx is canceled, even while waiting for
+// input. The pending read on In cannot be interrupted; the line it eventually
+// receives goes to the next prompt on In (see ReadPromptLine).
 func (p TextPrompter) Prompt(ctx context.Context, opts ...PromptOption) (string, error) {
 	if p.In == nil || p.Out == nil {
 		return "", errors.New("prompter missing IO")
@@ -575,12 +750,82 @@ func (p TextPrompter) Prompt(ctx context.Context, opts ...PromptOption) (string,
 		return "", errors.New("select prompts require the prompt extension (clix/ext/prompt)")
 	}
 
+	if cfg.Multiline {
+		return p.promptMultiline(ctx, cfg)
+	}
+
 	return p.promptText(ctx, cfg)
 }
 
+// promptMultiline reads lines until the end marker or EOF and joins them.
+func (p TextPrompter) promptMultiline(ctx context.Context, cfg *PromptConfig) (string, error) {
+	deadline := promptDeadline(cfg.Timeout)
+	end := cfg.MultilineEnd
+	if end == "" {
+		end = DefaultMultilineEnd
+	}
+
+	for {
+		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
+		label := renderText(cfg.Theme.LabelStyle, cfg.Label)
+		fmt.Fprintf(p.Out, "%s%s", prefix, label)
+		if cfg.Default != "" {
+			def := renderText(cfg.Theme.DefaultStyle, cfg.Default)
+			fmt.Fprintf(p.Out, " [%s]", def)
+		}
+		hint := MultilineHint(end)
+		if cfg.Theme.Hint != "" {
+			hint = cfg.Theme.Hint + " " + hint
+		}
+		fmt.Fprintf(p.Out, " %s:\n", renderText(cfg.Theme.HintStyle, hint))
+
+		var lines []string
+		eof := false
+		for !eof {
+			line, err := readLine(ctx, p.In, deadline)
+			if errors.Is(err, ErrPromptTimeout) {
+				return timeoutDefault(cfg)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return "", err
+			}
+			eof = err != nil
+			line = strings.TrimRight(line, "\r\n")
+			if line == end {
+				break
+			}
+			if !eof || line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		value := strings.Join(lines, "\n")
+		if strings.TrimSpace(value) == "" {
+			value = cfg.Default
+		}
+
+		if cfg.Validate != nil {
+			if err := cfg.Validate(value); err != nil {
+				errPrefix := renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error)
+				errMsg := err.Error()
+				if errMsg != "" {
+					errMsg = renderText(cfg.Theme.ErrorStyle, errMsg)
+				}
+				fmt.Fprintf(p.Out, "%s%s\n", errPrefix, errMsg)
+				if eof {
+					return "", err
+				}
+				continue
+			}
+		}
+
+		return value, nil
+	}
+}
+
 // promptText handles regular text input prompts.
 func (p TextPrompter) promptText(ctx context.Context, cfg *PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
@@ -599,7 +844,11 @@ func (p TextPrompter) promptText(ctx context.Context, cfg *PromptConfig) (string
 
 		fmt.Fprint(p.Out, ": ")
 
-		line, err := reader.ReadString('\n')
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			return timeoutDefault(cfg)
+		}
 		if err != nil {
 			return "", err
 		}
@@ -621,6 +870,7 @@ func (p TextPrompter) promptText(ctx context.Context, cfg *PromptConfig) (string
 			}
 		}
 
+		cfg.History.Add(value)
 		return value, nil
 	}
 }
@@ -628,8 +878,6 @@ func (p TextPrompter) promptText(ctx context.Context, cfg *PromptConfig) (string
 // promptConfirm handles yes/no confirmation prompts.
 // This works with TextPrompter since it's just a text prompt with validation.
 func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (string, error) {
-	reader := bufio.NewReader(p.In)
-
 	// Determine default (Y/n or y/N)
 	defaultYes := true
 	defaultText := "Y"
@@ -637,6 +885,7 @@ func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (str
 		defaultYes = false
 		defaultText = "N"
 	}
+	deadline := promptDeadline(cfg.Timeout)
 
 	for {
 		prefix := renderText(cfg.Theme.PrefixStyle, cfg.Theme.Prefix)
@@ -644,10 +893,14 @@ func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (str
 		fmt.Fprintf(p.Out, "%s%s", prefix, label)
 
 		// Show default in prompt
+		abortChoice := ""
+		if cfg.Abort {
+			abortChoice = "/a"
+		}
 		if defaultYes {
-			fmt.Fprintf(p.Out, " (%s/n)", defaultText)
+			fmt.Fprintf(p.Out, " (%s/n%s)", defaultText, abortChoice)
 		} else {
-			fmt.Fprintf(p.Out, " (y/%s)", defaultText)
+			fmt.Fprintf(p.Out, " (y/%s%s)", defaultText, abortChoice)
 		}
 
 		// Show hint if provided (may include "back" instruction from survey)
@@ -658,8 +911,14 @@ func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (str
 
 		fmt.Fprint(p.Out, ": ")
 
-		line, err := reader.ReadString('\n')
-		if err != nil {
+		line, err := readLine(ctx, p.In, deadline)
+		if errors.Is(err, ErrPromptTimeout) {
+			fmt.Fprintln(p.Out)
+			if cfg.Default == "" {
+				return "", err
+			}
+			line = ""
+		} else if err != nil {
 			return "", err
 		}
 
@@ -680,6 +939,9 @@ func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (str
 		if lowerValue == "n" || lowerValue == "no" {
 			return "n", nil
 		}
+		if cfg.Abort && (lowerValue == "a" || lowerValue == "abort") {
+			return "", ErrAborted
+		}
 
 		// Allow "back" to pass through for undo functionality (survey will handle it)
 		if lowerValue == "back" {
@@ -689,9 +951,35 @@ func (p TextPrompter) promptConfirm(ctx context.Context, cfg *PromptConfig) (str
 		// Invalid input
 		errPrefix := renderText(cfg.Theme.ErrorStyle, cfg.Theme.Error)
 		errMsg := "please enter 'y' or 'n'"
+		if cfg.Abort {
+			errMsg = "please enter 'y', 'n' or 'a'"
+		}
 		if cfg.Theme.ErrorStyle != nil {
 			errMsg = renderText(cfg.Theme.ErrorStyle, errMsg)
 		}
 		fmt.Fprintf(p.Out, "%s%s\n", errPrefix, errMsg)
 	}
 }
+
+// promptDeadline returns when a prompt with the given timeout expires, or the
+// zero time for no timeout.
+func promptDeadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
+// timeoutDefault is the result of a text prompt whose Timeout elapsed: its
+// Default, provided Validate accepts it, or ErrPromptTimeout.
+func timeoutDefault(cfg *PromptConfig) (string, error) {
+	if cfg.Default == "" {
+		return "", ErrPromptTimeout
+	}
+	if cfg.Validate != nil {
+		if err := cfg.Validate(cfg.Default); err != nil {
+			return "", fmt.Errorf("%w: default %q: %w", ErrPromptTimeout, cfg.Default, err)
+		}
+	}
+	return cfg.Default, nil
+}
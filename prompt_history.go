@@ -0,0 +1,80 @@
+package clix
+
+// PromptHistory keeps the most recent answers to a text prompt so interactive
+// prompters can offer them again. It is a fixed-size ring buffer: once full,
+// each new entry replaces the oldest one. Reuse the same history across calls
+// to give a prompt shell-like recall.
+//
+// Example:
+//
+//	history := clix.NewPromptHistory(50)
+//	for {
+//		query, err := prompter.Prompt(ctx, clix.PromptRequest{
+//			Label:   "Query",
+//			History: history,
+//		})
+//		...
+//	}
+type PromptHistory struct {
+	entries []string
+	start   int
+	size    int
+}
+
+// DefaultPromptHistorySize is the capacity used by NewPromptHistory when it is
+// given a non-positive size.
+const DefaultPromptHistorySize = 100
+
+// NewPromptHistory returns an empty history holding up to size entries.
+func NewPromptHistory(size int) *PromptHistory {
+	if size <= 0 {
+		size = DefaultPromptHistorySize
+	}
+	return &PromptHistory{entries: make([]string, size)}
+}
+
+// Add records value as the newest entry. Empty values and repeats of the
+// newest entry are ignored.
+func (h *PromptHistory) Add(value string) {
+	if h == nil || value == "" {
+		return
+	}
+	if len(h.entries) == 0 {
+		h.entries = make([]string, DefaultPromptHistorySize)
+	}
+	if h.size > 0 && h.at(h.size-1) == value {
+		return
+	}
+	if h.size < len(h.entries) {
+		h.entries[(h.start+h.size)%len(h.entries)] = value
+		h.size++
+		return
+	}
+	h.entries[h.start] = value
+	h.start = (h.start + 1) % len(h.entries)
+}
+
+// Len returns the number of entries recorded.
+func (h *PromptHistory) Len() int {
+	if h == nil {
+		return 0
+	}
+	return h.size
+}
+
+// Entries returns the recorded entries, oldest first.
+func (h *PromptHistory) Entries() []string {
+	if h == nil {
+		return nil
+	}
+	entries := make([]string, h.size)
+	for i := range entries {
+		entries[i] = h.at(i)
+	}
+	return entries
+}
+
+// at returns the i-th oldest entry.
+func (h *PromptHistory) at(i int) string {
+	return h.entries[(h.start+i)%len(h.entries)]
+}
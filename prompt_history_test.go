@@ -0,0 +1,41 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPromptHistory(t *testing.T) {
+	t.Run("drops the oldest entry when full", func(t *testing.T) {
+		history := NewPromptHistory(3)
+		for _, v := range []string{"a", "b", "c", "d"} {
+			history.Add(v)
+		}
+		if got, want := history.Entries(), []string{"b", "c", "d"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("ignores empty values and repeats", func(t *testing.T) {
+		history := NewPromptHistory(3)
+		history.Add("a")
+		history.Add("")
+		history.Add("a")
+		if history.Len() != 1 {
+			t.Fatalf("expected one entry, got %q", history.Entries())
+		}
+	})
+
+	t.Run("text prompter appends submissions", func(t *testing.T) {
+		history := NewPromptHistory(3)
+		prompter := TextPrompter{In: bytes.NewBufferString("us-west-2\n"), Out: &bytes.Buffer{}}
+		if _, err := prompter.Prompt(context.Background(), WithLabel("Region"), WithHistory(history)); err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if got, want := history.Entries(), []string{"us-west-2"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// pendingInputs holds the promptInput of every reader that has a read in
+// flight or bytes nobody has consumed yet, keyed by that reader. A prompt that
+// times out or is canceled leaves its read here, so the next prompt on the
+// same reader receives the input instead of an orphaned goroutine eating it.
+var pendingInputs sync.Map
+
+// promptInput buffers a prompt's reads from in. At most one read of in is in
+// flight at a time, and its result always lands in buf.
+type promptInput struct {
+	in      io.Reader
+	buf     []byte
+	err     error           // error from in, returned once buf is drained
+	pending chan inputChunk // result of the read in flight, nil when idle
+}
+
+type inputChunk struct {
+	data []byte
+	err  error
+}
+
+// acquireInput returns the buffered state for in, taking it out of
+// pendingInputs so that it has a single user until release.
+func acquireInput(in io.Reader) *promptInput {
+	if comparableReader(in) {
+		if v, ok := pendingInputs.LoadAndDelete(in); ok {
+			return v.(*promptInput)
+		}
+	}
+	return &promptInput{in: in}
+}
+
+// release puts the state back for the next prompt when it still holds
+// something, and drops it otherwise.
+func (p *promptInput) release() {
+	if (len(p.buf) > 0 || p.err != nil || p.pending != nil) && comparableReader(p.in) {
+		pendingInputs.Store(p.in, p)
+	}
+}
+
+// comparableReader reports whether in can be used as a map key.
+func comparableReader(in io.Reader) bool {
+	return in != nil && reflect.TypeOf(in).Comparable()
+}
+
+// fill waits until buf holds at least one byte. It returns ctx.Err() once ctx
+// is done, ErrPromptTimeout once deadline (if non-zero) passes, or the error
+// from in when nothing is left to return. Without a deadline or a cancelable
+// ctx it reads in directly rather than starting a goroutine.
+func (p *promptInput) fill(ctx context.Context, deadline time.Time) error {
+	if len(p.buf) > 0 {
+		return nil
+	}
+	if p.err != nil {
+		err := p.err
+		p.err = nil
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if p.pending == nil {
+		if deadline.IsZero() && ctx.Done() == nil {
+			p.store(readChunk(p.in))
+			return p.fill(ctx, deadline)
+		}
+		pending := make(chan inputChunk, 1)
+		p.pending = pending
+		go func(in io.Reader) {
+			pending <- readChunk(in)
+		}(p.in)
+	}
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case chunk := <-p.pending:
+		p.pending = nil
+		p.store(chunk)
+		return p.fill(ctx, deadline)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-expired:
+		return ErrPromptTimeout
+	}
+}
+
+func (p *promptInput) store(chunk inputChunk) {
+	p.buf = append(p.buf, chunk.data...)
+	p.err = chunk.err
+	if len(chunk.data) == 0 && chunk.err == nil {
+		// A reader returning (0, nil) is discouraged but legal; treat it as
+		// no progress rather than spinning.
+		p.err = io.ErrNoProgress
+	}
+}
+
+func readChunk(in io.Reader) inputChunk {
+	data := make([]byte, 4096)
+	n, err := in.Read(data)
+	return inputChunk{data: data[:n], err: err}
+}
+
+// readLine reads up to and including the next newline. Like
+// bufio.Reader.ReadString, it returns what it has together with the error
+// when in fails first; a timeout or cancel keeps the partial line buffered.
+func (p *promptInput) readLine(ctx context.Context, deadline time.Time) (string, error) {
+	var line []byte
+	for {
+		if i := bytes.IndexByte(p.buf, '\n'); i >= 0 {
+			line = append(line, p.buf[:i+1]...)
+			p.buf = p.buf[i+1:]
+			return string(line), nil
+		}
+		if err := p.fill(ctx, deadline); err != nil {
+			if err == ctx.Err() || err == ErrPromptTimeout {
+				p.buf = append(line, p.buf...)
+				return "", err
+			}
+			return string(line), err
+		}
+		if bytes.IndexByte(p.buf, '\n') < 0 {
+			line = append(line, p.buf...)
+			p.buf = nil
+		}
+	}
+}
+
+// readLine reads one line from in, returning early with ctx.Err() when ctx is
+// done or ErrPromptTimeout once deadline (if non-zero) passes.
+func readLine(ctx context.Context, in io.Reader, deadline time.Time) (string, error) {
+	input := acquireInput(in)
+	defer input.release()
+	return input.readLine(ctx, deadline)
+}
+
+// ReadPromptLine reads one line, including its newline, from in on behalf of
+// a prompt. It returns ctx.Err() once ctx is done and ErrPromptTimeout once
+// deadline (if non-zero) passes. A blocking read cannot be interrupted, so a
+// read that outlives the prompt keeps running; whatever it receives is
+// returned by the next ReadPromptLine or PromptReader read of the same in
+// rather than being lost.
+//
+// Prompters outside this package use it, together with PromptReader, so that
+// timeouts and cancellation behave the same as in TextPrompter. Concurrent
+// prompts on one reader are not supported.
+func ReadPromptLine(ctx context.Context, in io.Reader, deadline time.Time) (string, error) {
+	return readLine(ctx, in, deadline)
+}
+
+// PromptReader returns an io.Reader over in for reading keypresses or other
+// raw input with the same timeout, cancellation and hand-over behaviour as
+// ReadPromptLine.
+func PromptReader(ctx context.Context, in io.Reader, deadline time.Time) io.Reader {
+	return promptReader{ctx: ctx, in: in, deadline: deadline}
+}
+
+type promptReader struct {
+	ctx      context.Context
+	in       io.Reader
+	deadline time.Time
+}
+
+func (r promptReader) Read(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	input := acquireInput(r.in)
+	defer input.release()
+	if err := input.fill(r.ctx, r.deadline); err != nil {
+		return 0, err
+	}
+	n := copy(b, input.buf)
+	input.buf = input.buf[n:]
+	return n, nil
+}
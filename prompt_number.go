@@ -0,0 +1,76 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PromptInt prompts for a whole number. Input that does not parse is rejected
+// with a message and the prompter asks again, exactly as for a failed
+// Validate. Default and Validate from opts are honored; Validate sees the raw
+// input only after it parsed successfully.
+//
+// Example:
+//
+//	replicas, err := clix.PromptInt(ctx, app.Prompter, clix.PromptRequest{
+//		Label:   "Replicas",
+//		Default: "3",
+//	})
+func PromptInt(ctx context.Context, p Prompter, opts ...PromptOption) (int, error) {
+	value, err := promptNumber(ctx, p, opts, func(s string) error {
+		if _, err := strconv.Atoi(s); err != nil {
+			return errors.New("please enter a whole number")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// PromptFloat prompts for a number, which may have a fractional part. It
+// behaves like PromptInt otherwise.
+func PromptFloat(ctx context.Context, p Prompter, opts ...PromptOption) (float64, error) {
+	value, err := promptNumber(ctx, p, opts, func(s string) error {
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return errors.New("please enter a number")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// promptNumber prompts with parse run ahead of any caller-supplied Validate.
+func promptNumber(ctx context.Context, p Prompter, opts []PromptOption, parse func(string) error) (string, error) {
+	if p == nil {
+		return "", errors.New("no prompter configured")
+	}
+
+	cfg := &PromptConfig{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	validate := cfg.Validate
+
+	opts = append(opts[:len(opts):len(opts)], WithValidate(func(s string) error {
+		if err := parse(strings.TrimSpace(s)); err != nil {
+			return err
+		}
+		if validate != nil {
+			return validate(s)
+		}
+		return nil
+	}))
+
+	value, err := p.Prompt(ctx, opts...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(value), nil
+}
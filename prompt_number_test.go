@@ -0,0 +1,111 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPromptInt(t *testing.T) {
+	t.Run("valid entry", func(t *testing.T) {
+		prompter := TextPrompter{In: bytes.NewBufferString("42\n"), Out: &bytes.Buffer{}}
+		value, err := PromptInt(context.Background(), prompter, WithLabel("Replicas"))
+		if err != nil {
+			t.Fatalf("PromptInt returned error: %v", err)
+		}
+		if value != 42 {
+			t.Fatalf("expected 42, got %d", value)
+		}
+	})
+
+	t.Run("accepts default", func(t *testing.T) {
+		prompter := TextPrompter{In: bytes.NewBufferString("\n"), Out: &bytes.Buffer{}}
+		value, err := PromptInt(context.Background(), prompter, PromptRequest{Label: "Replicas", Default: "3"})
+		if err != nil {
+			t.Fatalf("PromptInt returned error: %v", err)
+		}
+		if value != 3 {
+			t.Fatalf("expected default 3, got %d", value)
+		}
+	})
+
+	t.Run("re-prompts after non-numeric entry", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := TextPrompter{In: bytes.NewBufferString("three\n3.5\n7\n"), Out: out}
+		value, err := PromptInt(context.Background(), prompter, WithLabel("Replicas"))
+		if err != nil {
+			t.Fatalf("PromptInt returned error: %v", err)
+		}
+		if value != 7 {
+			t.Fatalf("expected 7, got %d", value)
+		}
+		if got := strings.Count(out.String(), "please enter a whole number"); got != 2 {
+			t.Errorf("expected two parse errors, got %d in %q", got, out.String())
+		}
+	})
+
+	t.Run("runs Validate after parsing", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := TextPrompter{In: bytes.NewBufferString("0\n5\n"), Out: out}
+		value, err := PromptInt(context.Background(), prompter, PromptRequest{
+			Label: "Replicas",
+			Validate: func(s string) error {
+				if n, _ := strconv.Atoi(s); n < 1 {
+					return errors.New("must be at least 1")
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("PromptInt returned error: %v", err)
+		}
+		if value != 5 {
+			t.Fatalf("expected 5, got %d", value)
+		}
+		if !strings.Contains(out.String(), "must be at least 1") {
+			t.Errorf("expected Validate message, got %q", out.String())
+		}
+	})
+}
+
+func TestPromptFloat(t *testing.T) {
+	t.Run("valid entry", func(t *testing.T) {
+		prompter := TextPrompter{In: bytes.NewBufferString("0.75\n"), Out: &bytes.Buffer{}}
+		value, err := PromptFloat(context.Background(), prompter, WithLabel("Ratio"))
+		if err != nil {
+			t.Fatalf("PromptFloat returned error: %v", err)
+		}
+		if value != 0.75 {
+			t.Fatalf("expected 0.75, got %v", value)
+		}
+	})
+
+	t.Run("accepts default", func(t *testing.T) {
+		prompter := TextPrompter{In: bytes.NewBufferString("\n"), Out: &bytes.Buffer{}}
+		value, err := PromptFloat(context.Background(), prompter, WithLabel("Ratio"), WithDefault("1.5"))
+		if err != nil {
+			t.Fatalf("PromptFloat returned error: %v", err)
+		}
+		if value != 1.5 {
+			t.Fatalf("expected default 1.5, got %v", value)
+		}
+	})
+
+	t.Run("re-prompts after non-numeric entry", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := TextPrompter{In: bytes.NewBufferString("half\n0.5\n"), Out: out}
+		value, err := PromptFloat(context.Background(), prompter, WithLabel("Ratio"))
+		if err != nil {
+			t.Fatalf("PromptFloat returned error: %v", err)
+		}
+		if value != 0.5 {
+			t.Fatalf("expected 0.5, got %v", value)
+		}
+		if !strings.Contains(out.String(), "please enter a number") {
+			t.Errorf("expected parse error message, got %q", out.String())
+		}
+	})
+}
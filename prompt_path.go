@@ -0,0 +1,68 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SuggestPaths is a Suggest provider that completes file system paths. It
+// lists the entries of the directory named by input that start with its last
+// element, appending a separator to directories so Tab can descend into
+// them. Hidden entries are only offered once the input starts with a dot.
+//
+// Pair it with the path validators from ext/validation:
+//
+//	path, err := prompter.Prompt(ctx, clix.PromptRequest{
+//		Label:    "Config file",
+//		Suggest:  clix.SuggestPaths,
+//		Validate: validation.IsFile,
+//	})
+func SuggestPaths(input string) []string {
+	dir, base := filepath.Split(input)
+	entries, err := os.ReadDir(dirOrDot(dir))
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		path := dir + name
+		if isDirEntry(dir, entry) {
+			path += string(filepath.Separator)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// WithPathSuggest completes text prompts against file system paths
+// (functional option). See SuggestPaths.
+func WithPathSuggest() PromptOption {
+	return WithSuggest(SuggestPaths)
+}
+
+func dirOrDot(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// isDirEntry reports whether entry is a directory, following symlinks.
+func isDirEntry(dir string, entry os.DirEntry) bool {
+	if entry.IsDir() {
+		return true
+	}
+	if entry.Type()&os.ModeSymlink == 0 {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(dirOrDot(dir), entry.Name()))
+	return err == nil && info.IsDir()
+}
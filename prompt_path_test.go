@@ -0,0 +1,50 @@
+package clix
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSuggestPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"config.yaml", "config.json", ".hidden", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "configs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	prefix := dir + string(filepath.Separator)
+	sep := string(filepath.Separator)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"prefix", prefix + "conf", []string{prefix + "config.json", prefix + "config.yaml", prefix + "configs" + sep}},
+		{"directory lists visible entries", prefix, []string{prefix + "config.json", prefix + "config.yaml", prefix + "configs" + sep, prefix + "notes.txt"}},
+		{"dot shows hidden", prefix + ".", []string{prefix + ".hidden"}},
+		{"no match", prefix + "zzz", nil},
+		{"missing directory", prefix + "missing" + sep, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestPaths(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SuggestPaths(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPathSuggest(t *testing.T) {
+	cfg := &PromptConfig{}
+	WithPathSuggest().Apply(cfg)
+	if cfg.Suggest == nil {
+		t.Fatal("expected WithPathSuggest to set Suggest")
+	}
+}
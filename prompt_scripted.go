@@ -0,0 +1,135 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrUnexpectedPrompt is returned by ScriptedPrompter when a prompt does not
+// match the next scripted response or the script has run out.
+var ErrUnexpectedPrompt = errors.New("unexpected prompt")
+
+// ScriptedResponse is one canned answer for ScriptedPrompter.
+type ScriptedResponse struct {
+	// Label is the prompt label this response answers. An empty Label
+	// answers whatever prompt comes next.
+	Label string
+
+	// Value is returned from Prompt. An empty Value accepts the prompt's
+	// Default, as pressing Enter would.
+	Value string
+
+	// Err, when set, is returned from Prompt instead of Value, for example
+	// ErrAborted or context.Canceled.
+	Err error
+}
+
+// Answer returns a ScriptedResponse answering the prompt labelled label with value.
+func Answer(label, value string) ScriptedResponse {
+	return ScriptedResponse{Label: label, Value: value}
+}
+
+// ScriptedPrompter is a Prompter for tests that answers prompts from an
+// ordered script instead of a terminal, and records every prompt it was asked:
+//
+//	p := clix.NewScriptedPrompter(
+//		clix.Answer("Name", "Alice"),
+//		clix.Answer("Proceed?", "y"),
+//	)
+//	app.Prompter = p
+//	err := app.Run(ctx, []string{"init"})
+//	// p.Requests() holds both prompts; p.Remaining() is 0.
+//
+// Responses are consumed in order. A prompt whose label differs from the next
+// response's Label, or any prompt after the script is exhausted, fails with
+// ErrUnexpectedPrompt. A response rejected by the prompt's Validate is treated
+// like a user retrying: the next response must answer the same prompt.
+type ScriptedPrompter struct {
+	mu        sync.Mutex
+	responses []ScriptedResponse
+	next      int
+	requests  []PromptConfig
+	out       bytes.Buffer
+}
+
+var _ Prompter = (*ScriptedPrompter)(nil)
+
+// NewScriptedPrompter returns a ScriptedPrompter that answers with responses.
+func NewScriptedPrompter(responses ...ScriptedResponse) *ScriptedPrompter {
+	return &ScriptedPrompter{responses: responses}
+}
+
+// Prompt answers the prompt with the next scripted response.
+func (p *ScriptedPrompter) Prompt(ctx context.Context, opts ...PromptOption) (string, error) {
+	cfg := &PromptConfig{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, *cfg)
+
+	var rejected error
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if p.next >= len(p.responses) {
+			if rejected != nil {
+				return "", fmt.Errorf("%w %q: script exhausted, last response rejected: %v", ErrUnexpectedPrompt, cfg.Label, rejected)
+			}
+			return "", fmt.Errorf("%w %q: script exhausted after %d responses", ErrUnexpectedPrompt, cfg.Label, len(p.responses))
+		}
+		resp := p.responses[p.next]
+		if resp.Label != "" && resp.Label != cfg.Label {
+			return "", fmt.Errorf("%w %q: response %d expects %q", ErrUnexpectedPrompt, cfg.Label, p.next+1, resp.Label)
+		}
+		p.next++
+		if resp.Err != nil {
+			return "", resp.Err
+		}
+
+		value := resp.Value
+		if value == "" {
+			value = cfg.Default
+		}
+		if cfg.Validate != nil {
+			if err := cfg.Validate(value); err != nil {
+				rejected = err
+				continue
+			}
+		}
+		return value, nil
+	}
+}
+
+// Requests returns the prompts asked so far, in order, as configured by
+// their options.
+func (p *ScriptedPrompter) Requests() []PromptConfig {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PromptConfig(nil), p.requests...)
+}
+
+// Out returns a writer for output that accompanies the prompts, such as a
+// survey's summary, so tests can inspect it through Output.
+func (p *ScriptedPrompter) Out() io.Writer {
+	return &p.out
+}
+
+// Output returns everything written to Out.
+func (p *ScriptedPrompter) Output() string {
+	return p.out.String()
+}
+
+// Remaining reports how many scripted responses have not been used yet.
+func (p *ScriptedPrompter) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.responses) - p.next
+}
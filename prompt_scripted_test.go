@@ -0,0 +1,100 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScriptedPrompterAnswersInOrder(t *testing.T) {
+	p := NewScriptedPrompter(
+		Answer("Name", "Alice"),
+		ScriptedResponse{Value: ""},
+		ScriptedResponse{Label: "Proceed?", Err: ErrAborted},
+	)
+	ctx := context.Background()
+
+	if got, err := p.Prompt(ctx, WithLabel("Name")); err != nil || got != "Alice" {
+		t.Fatalf("Name = %q, %v; want Alice", got, err)
+	}
+	// An unlabelled, empty response accepts the default.
+	if got, err := p.Prompt(ctx, WithLabel("Region"), WithDefault("eu")); err != nil || got != "eu" {
+		t.Fatalf("Region = %q, %v; want eu", got, err)
+	}
+	if _, err := p.Prompt(ctx, WithLabel("Proceed?"), WithConfirm()); !errors.Is(err, ErrAborted) {
+		t.Fatalf("Proceed? error = %v, want ErrAborted", err)
+	}
+
+	reqs := p.Requests()
+	if len(reqs) != 3 || reqs[1].Label != "Region" || !reqs[2].Confirm {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	if p.Remaining() != 0 {
+		t.Errorf("Remaining = %d, want 0", p.Remaining())
+	}
+}
+
+func TestScriptedPrompterUnexpectedPrompt(t *testing.T) {
+	ctx := context.Background()
+
+	p := NewScriptedPrompter(Answer("Name", "Alice"))
+	if _, err := p.Prompt(ctx, WithLabel("Email")); !errors.Is(err, ErrUnexpectedPrompt) {
+		t.Fatalf("label mismatch error = %v, want ErrUnexpectedPrompt", err)
+	}
+	if p.Remaining() != 1 {
+		t.Errorf("a mismatched prompt consumed a response")
+	}
+
+	p = NewScriptedPrompter()
+	_, err := p.Prompt(ctx, WithLabel("Name"))
+	if !errors.Is(err, ErrUnexpectedPrompt) || !strings.Contains(err.Error(), "exhausted") {
+		t.Fatalf("exhausted error = %v", err)
+	}
+}
+
+func TestScriptedPrompterRetriesRejectedValue(t *testing.T) {
+	notEmpty := func(s string) error {
+		if s == "" {
+			return errors.New("required")
+		}
+		return nil
+	}
+	p := NewScriptedPrompter(Answer("Name", ""), Answer("Name", "Bob"))
+	got, err := p.Prompt(context.Background(), WithLabel("Name"), WithValidate(notEmpty))
+	if err != nil || got != "Bob" {
+		t.Fatalf("Prompt = %q, %v; want Bob", got, err)
+	}
+
+	p = NewScriptedPrompter(Answer("Name", ""))
+	_, err = p.Prompt(context.Background(), WithLabel("Name"), WithValidate(notEmpty))
+	if !errors.Is(err, ErrUnexpectedPrompt) || !strings.Contains(err.Error(), "required") {
+		t.Fatalf("error = %v, want the rejection reported", err)
+	}
+}
+
+func TestScriptedPrompterDrivesRequiredFlags(t *testing.T) {
+	var project string
+	cmd := NewCommand("init")
+	cmd.Flags.StringVar(WithFlagName("project"), WithFlagRequired(), WithStringValue(&project))
+	cmd.Run = func(*Context) error { return nil }
+
+	app := NewApp("tool")
+	app.configLoaded = true
+	app.Out = &bytes.Buffer{}
+	app.Err = &bytes.Buffer{}
+	app.Root.AddCommand(cmd)
+	p := NewScriptedPrompter(Answer("Project", "demo"))
+	app.Prompter = p
+
+	if err := app.Run(context.Background(), []string{"init"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if project != "demo" {
+		t.Errorf("project = %q, want demo", project)
+	}
+	if p.Remaining() != 0 || len(p.Requests()) != 1 {
+		t.Errorf("remaining = %d, requests = %d", p.Remaining(), len(p.Requests()))
+	}
+}
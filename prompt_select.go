@@ -0,0 +1,95 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Selector is implemented by prompters that can return the full option chosen
+// in a select prompt rather than only its Value. ext/prompt's
+// TerminalPrompter implements it.
+type Selector interface {
+	SelectOne(ctx context.Context, opts ...PromptOption) (SelectOption, error)
+}
+
+// SelectOne runs a select prompt with p and returns the chosen option,
+// including its Label and Description. Prompters that implement Selector
+// are used directly; for others, the Value returned by Prompt is matched
+// back to the first option with that Value. A value that matches no option
+// (for example free-form input accepted by Validate) is returned as an
+// option whose Label and Value are both the input.
+//
+// Example:
+//
+//	region, err := clix.SelectOne(ctx, ctx.App.Prompter, clix.PromptRequest{
+//		Label:   "Region",
+//		Options: regions,
+//	})
+//	fmt.Fprintf(ctx.App.Out, "Deploying to %s (%s)\n", region.Label, region.Description)
+func SelectOne(ctx context.Context, p Prompter, opts ...PromptOption) (SelectOption, error) {
+	if s, ok := p.(Selector); ok {
+		return s.SelectOne(ctx, opts...)
+	}
+
+	cfg := &PromptConfig{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	if len(cfg.Options) == 0 {
+		return SelectOption{}, errors.New("select prompt requires options")
+	}
+
+	value, err := p.Prompt(ctx, opts...)
+	if err != nil {
+		return SelectOption{}, err
+	}
+	for _, option := range cfg.Options {
+		if option.Value == value {
+			return option, nil
+		}
+	}
+	return SelectOption{Label: value, Value: value}, nil
+}
+
+// MultiSelector is implemented by prompters that return the values chosen in
+// a multi-select prompt as a slice. ext/prompt's TerminalPrompter implements it.
+type MultiSelector interface {
+	PromptMulti(ctx context.Context, opts ...PromptOption) ([]string, error)
+}
+
+// PromptMulti runs a multi-select prompt with p and returns the selected
+// values in option order. Unlike the comma-joined string returned by Prompt,
+// values that contain commas stay intact. Prompters that implement
+// MultiSelector are used directly; for others, the string returned by
+// Prompt is split on commas.
+//
+// Example:
+//
+//	tags, err := clix.PromptMulti(ctx, ctx.App.Prompter, clix.PromptRequest{
+//		Label:   "Tags",
+//		Options: tagOptions,
+//	})
+func PromptMulti(ctx context.Context, p Prompter, opts ...PromptOption) ([]string, error) {
+	if s, ok := p.(MultiSelector); ok {
+		return s.PromptMulti(ctx, opts...)
+	}
+
+	cfg := &PromptConfig{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	if len(cfg.Options) == 0 {
+		return nil, errors.New("multi-select prompt requires options")
+	}
+
+	answer, err := p.Prompt(ctx, append(append([]PromptOption(nil), opts...), PromptRequest{MultiSelect: true})...)
+	if err != nil || answer == "" {
+		return nil, err
+	}
+	values := strings.Split(answer, ",")
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return values, nil
+}
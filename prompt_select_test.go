@@ -0,0 +1,90 @@
+package clix
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSelectOneFallsBackToPromptValue(t *testing.T) {
+	options := []SelectOption{
+		{Label: "US East", Value: "us-east-1", Description: "Virginia"},
+		{Label: "EU West", Value: "eu-west-1", Description: "Ireland"},
+	}
+	tests := []struct {
+		name   string
+		answer string
+		want   SelectOption
+	}{
+		{name: "known value", answer: "eu-west-1", want: options[1]},
+		{name: "free-form value", answer: "ap-south-1", want: SelectOption{Label: "ap-south-1", Value: "ap-south-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+				return tt.answer, nil
+			})
+			got, err := SelectOne(context.Background(), p, PromptRequest{Label: "Region", Options: options})
+			if err != nil {
+				t.Fatalf("SelectOne: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SelectOne = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectOneRequiresOptions(t *testing.T) {
+	p := prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		t.Fatal("prompter called without options")
+		return "", nil
+	})
+	if _, err := SelectOne(context.Background(), p, WithLabel("Region")); err == nil {
+		t.Fatal("expected an error for a select prompt without options")
+	}
+}
+
+func TestPromptMultiFallbackSplitsPromptAnswer(t *testing.T) {
+	var sawMulti bool
+	p := prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		cfg := &PromptConfig{}
+		for _, opt := range opts {
+			opt.Apply(cfg)
+		}
+		sawMulti = cfg.MultiSelect
+		return "a, b", nil
+	})
+	got, err := PromptMulti(context.Background(), p, PromptRequest{
+		Label:   "Letters",
+		Options: []SelectOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+	})
+	if err != nil {
+		t.Fatalf("PromptMulti: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("PromptMulti = %q, want [a b]", got)
+	}
+	if !sawMulti {
+		t.Error("prompter was not asked for a multi-select prompt")
+	}
+}
+
+func TestPromptMultiLeavesCallerOptionsAlone(t *testing.T) {
+	p := prompterFunc(func(ctx context.Context, opts ...PromptOption) (string, error) {
+		return "a", nil
+	})
+	opts := make([]PromptOption, 1, 2)
+	opts[0] = PromptRequest{
+		Label:   "Letters",
+		Options: []SelectOption{{Label: "A", Value: "a"}},
+	}
+	spare := opts[:2]
+	spare[1] = WithLabel("untouched")
+
+	if _, err := PromptMulti(context.Background(), p, opts...); err != nil {
+		t.Fatalf("PromptMulti: %v", err)
+	}
+	if _, ok := spare[1].(PromptRequest); ok {
+		t.Error("PromptMulti wrote into the caller's options slice")
+	}
+}
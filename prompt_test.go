@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTextPrompterReadsInput(t *testing.T) {
@@ -161,3 +163,264 @@ func TestTextPrompterSupportsConfirm(t *testing.T) {
 		t.Errorf("output should show default, got: %s", output)
 	}
 }
+
+func TestTextPrompterTimeout(t *testing.T) {
+	t.Run("returns default when timeout elapses", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:   "Region",
+			Default: "us-east-1",
+			Timeout: 20 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "us-east-1" {
+			t.Fatalf("expected default after timeout, got %q", value)
+		}
+	})
+
+	t.Run("errors without a default", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		_, err := prompter.Prompt(context.Background(), WithLabel("Region"), WithTimeout(20*time.Millisecond))
+		if !errors.Is(err, ErrPromptTimeout) {
+			t.Fatalf("expected ErrPromptTimeout, got %v", err)
+		}
+	})
+
+	t.Run("default must pass Validate", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		_, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:    "Port",
+			Default:  "http",
+			Timeout:  20 * time.Millisecond,
+			Validate: func(v string) error { return errors.New("not a number") },
+		})
+		if !errors.Is(err, ErrPromptTimeout) || !strings.Contains(err.Error(), "not a number") {
+			t.Fatalf("expected ErrPromptTimeout with the validation error, got %v", err)
+		}
+	})
+
+	t.Run("confirm answers its default", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:   "Continue?",
+			Confirm: true,
+			Default: "n",
+			Timeout: 20 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "n" {
+			t.Fatalf("expected the default answer after timeout, got %q", value)
+		}
+	})
+
+	t.Run("confirm without a default errors", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		_, err := prompter.Prompt(context.Background(), WithLabel("Delete?"), WithConfirm(), WithTimeout(20*time.Millisecond))
+		if !errors.Is(err, ErrPromptTimeout) {
+			t.Fatalf("expected ErrPromptTimeout, got %v", err)
+		}
+	})
+
+	t.Run("next prompt receives input after a timeout", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		if _, err := prompter.Prompt(context.Background(), WithLabel("First"), WithTimeout(20*time.Millisecond)); !errors.Is(err, ErrPromptTimeout) {
+			t.Fatalf("expected ErrPromptTimeout, got %v", err)
+		}
+
+		go w.Write([]byte("second\n"))
+		value, err := prompter.Prompt(context.Background(), WithLabel("Second"), WithTimeout(time.Second))
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "second" {
+			t.Fatalf("expected the second prompt to get %q, got %q", "second", value)
+		}
+	})
+
+	t.Run("returns input submitted in time", func(t *testing.T) {
+		prompter := TextPrompter{In: bytes.NewBufferString("eu-west-1\n"), Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:   "Region",
+			Default: "us-east-1",
+			Timeout: time.Second,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "eu-west-1" {
+			t.Fatalf("expected submitted value, got %q", value)
+		}
+	})
+}
+
+func TestTextPrompterContextCancel(t *testing.T) {
+	for name, req := range map[string]PromptRequest{
+		"text":    {Label: "Name"},
+		"confirm": {Label: "Continue?", Confirm: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			in, w := io.Pipe()
+			defer w.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+
+			prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+			start := time.Now()
+			_, err := prompter.Prompt(ctx, req)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("Prompt took %s to return after cancellation", elapsed)
+			}
+		})
+	}
+
+	t.Run("next prompt receives input after cancel", func(t *testing.T) {
+		in, w := io.Pipe()
+		defer w.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		if _, err := prompter.Prompt(ctx, WithLabel("First")); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+
+		go w.Write([]byte("second\n"))
+		value, err := prompter.Prompt(context.Background(), WithLabel("Second"))
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if value != "second" {
+			t.Fatalf("expected the second prompt to get %q, got %q", "second", value)
+		}
+	})
+
+	t.Run("already canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		prompter := TextPrompter{In: bytes.NewBufferString("ignored\n"), Out: &bytes.Buffer{}}
+		if _, err := prompter.Prompt(ctx, WithLabel("Name")); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestTextPrompterMultiline(t *testing.T) {
+	t.Run("ends at sentinel line", func(t *testing.T) {
+		in := bytes.NewBufferString("Fix parser\n\nHandles empty input.\n.\nnot read\n")
+		out := &bytes.Buffer{}
+
+		prompter := TextPrompter{In: in, Out: out}
+		value, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:     "Message",
+			Multiline: true,
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if want := "Fix parser\n\nHandles empty input."; value != want {
+			t.Fatalf("expected %q, got %q", want, value)
+		}
+		if !strings.Contains(out.String(), `finish with a line containing only "."`) {
+			t.Errorf("expected hint explaining how to finish, got %q", out.String())
+		}
+	})
+
+	t.Run("ends at EOF", func(t *testing.T) {
+		in := bytes.NewBufferString("first\nsecond\nthird")
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), WithLabel("Notes"), WithMultiline())
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if want := "first\nsecond\nthird"; value != want {
+			t.Fatalf("expected %q, got %q", want, value)
+		}
+	})
+
+	t.Run("custom end marker", func(t *testing.T) {
+		in := bytes.NewBufferString("a\n.\nb\nEOF\n")
+
+		prompter := TextPrompter{In: in, Out: &bytes.Buffer{}}
+		value, err := prompter.Prompt(context.Background(), PromptRequest{
+			Label:        "Body",
+			Multiline:    true,
+			MultilineEnd: "EOF",
+		})
+		if err != nil {
+			t.Fatalf("Prompt returned error: %v", err)
+		}
+		if want := "a\n.\nb"; value != want {
+			t.Fatalf("expected %q, got %q", want, value)
+		}
+	})
+}
+
+func TestTextPrompterConfirmAbort(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "y\n", want: "y"},
+		{input: "no\n", want: "n"},
+		{input: "a\n", wantErr: ErrAborted},
+		{input: "ABORT\n", wantErr: ErrAborted},
+	}
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			prompter := TextPrompter{In: bytes.NewBufferString(tt.input), Out: out}
+			value, err := prompter.Prompt(context.Background(), PromptRequest{Label: "Overwrite?", Confirm: true, Abort: true})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if value != tt.want {
+				t.Errorf("value = %q, want %q", value, tt.want)
+			}
+			if !strings.Contains(out.String(), "(Y/n/a)") {
+				t.Errorf("output should offer abort, got: %s", out.String())
+			}
+		})
+	}
+
+	t.Run("abort disabled", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		prompter := TextPrompter{In: bytes.NewBufferString("a\nn\n"), Out: out}
+		value, err := prompter.Prompt(context.Background(), WithLabel("Overwrite?"), WithConfirm())
+		if err != nil || value != "n" {
+			t.Fatalf("Prompt = %q, %v; want \"a\" rejected and then n", value, err)
+		}
+		if !strings.Contains(out.String(), "please enter 'y' or 'n'") {
+			t.Errorf("expected a retry message, got: %s", out.String())
+		}
+	})
+}
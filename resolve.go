@@ -0,0 +1,69 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCommand is returned, wrapped, when the command line names a
+// command that does not exist. Test for it with errors.Is.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Resolve routes args the way Run does, without prompting or running any
+// hooks. Configuration is loaded, since argument rewriters (see RewriteArgs)
+// may depend on it. It returns the command that would run and the arguments
+// left after its flags are parsed, ready for custom dispatch or for tests of
+// a command tree:
+//
+//	cmd, args, err := app.Resolve([]string{"repo", "clone", "--depth=1", "cli/cli"})
+//	// cmd.Path() == "gh repo clone", args == ["cli/cli"]
+//
+// Flags are parsed into the app's flag sets as in Run, so bound variables hold
+// the values given in args afterwards. A name that matches no subcommand of a
+// group yields an error wrapping ErrUnknownCommand.
+func (a *App) Resolve(args []string) (*Command, []string, error) {
+	if a.Root == nil {
+		return nil, nil, errors.New("clix: no root command configured")
+	}
+	a.ensureRootPrepared()
+	if err := a.ApplyExtensions(); err != nil {
+		return nil, nil, err
+	}
+	a.resetFlags(a.Root)
+
+	flags := a.Flags()
+	remaining, err := flags.parseLeading(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := a.loadConfig(context.Background(), flags); err != nil {
+		return nil, nil, err
+	}
+	if remaining, err = a.rewriteArgs(remaining); err != nil {
+		return nil, nil, err
+	}
+	cmd, _, rest, err := a.resolveCommandLine(remaining)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := unknownSubcommand(cmd, rest); err != nil {
+		return nil, nil, err
+	}
+	positionals, err := cmd.parseFlags().inherit(flags).Parse(rest)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return cmd, positionals, nil
+}
+
+// unknownSubcommand reports rest[0] as an unknown command when cmd is a
+// group without a Run handler of its own. Commands with a handler receive
+// leftover words as arguments instead.
+func unknownSubcommand(cmd *Command, rest []string) error {
+	if len(rest) == 0 || len(cmd.Children) == 0 || cmd.Run != nil || strings.HasPrefix(rest[0], "-") {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s", ErrUnknownCommand, cmd.Path(), rest[0])
+}
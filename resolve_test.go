@@ -0,0 +1,56 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newResolveApp(depth *int) *App {
+	app := NewApp("gh")
+	clone := NewCommand("clone")
+	clone.Flags.IntVar(WithFlagName("depth"), WithIntegerValue(depth))
+	clone.Run = func(*Context) error { return nil }
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", clone))
+	return app
+}
+
+func TestResolveNestedCommand(t *testing.T) {
+	var depth int
+	app := newResolveApp(&depth)
+
+	cmd, args, err := app.Resolve([]string{"repo", "clone", "--depth=1", "cli/cli", "target"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if cmd.Path() != "gh repo clone" {
+		t.Errorf("command = %q, want gh repo clone", cmd.Path())
+	}
+	if want := []string{"cli/cli", "target"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+	if depth != 1 {
+		t.Errorf("depth = %d, want 1", depth)
+	}
+}
+
+func TestResolveUnknownCommand(t *testing.T) {
+	var depth int
+	app := newResolveApp(&depth)
+
+	for _, args := range [][]string{{"repo", "fork"}, {"issues"}} {
+		if _, _, err := app.Resolve(args); !errors.Is(err, ErrUnknownCommand) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownCommand", args, err)
+		}
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	err := app.Run(context.Background(), []string{"repo", "fork"})
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Fatalf("Run error = %v, want ErrUnknownCommand", err)
+	}
+	if err.Error() != "unknown command: gh repo fork" {
+		t.Errorf("message = %q", err.Error())
+	}
+}
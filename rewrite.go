@@ -0,0 +1,43 @@
+package clix
+
+import "context"
+
+// ArgsRewriter transforms the command line before it is routed to a command.
+// It receives the arguments left after app-level flags are parsed, with
+// configuration already loaded, and returns the arguments to route instead.
+type ArgsRewriter func(args []string) ([]string, error)
+
+// RewriteArgs registers rewriters that Run and Resolve apply to the command
+// line before looking up the command, in registration order. Extensions use
+// it to implement user-defined aliases and similar shorthands:
+//
+//	app.RewriteArgs(func(args []string) ([]string, error) {
+//		if len(args) > 0 && args[0] == "co" {
+//			return append([]string{"checkout"}, args[1:]...), nil
+//		}
+//		return args, nil
+//	})
+func (a *App) RewriteArgs(rewriters ...ArgsRewriter) {
+	a.rewriters = append(a.rewriters, rewriters...)
+}
+
+// rewriteArgs applies the registered rewriters to args.
+func (a *App) rewriteArgs(args []string) ([]string, error) {
+	for _, rewrite := range a.rewriters {
+		var err error
+		if args, err = rewrite(args); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// loadConfig loads the config file named by a --config flag among the
+// parsed root flags, or the default one.
+func (a *App) loadConfig(ctx context.Context, flags *FlagSet) error {
+	a.configFlagPath = ""
+	if flag := flags.lookup(configFlagName); flag != nil && flag.set {
+		a.configFlagPath = flag.Value.String()
+	}
+	return a.ensureConfigLoaded(ctx)
+}
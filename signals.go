@@ -0,0 +1,66 @@
+package clix
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Signal delivery and process exit are swapped out in tests.
+var (
+	signalNotify = signal.Notify
+	signalStop   = signal.Stop
+	forceExit    = os.Exit
+)
+
+// RunWithSignals is like Run, but cancels the context passed to the command
+// when the process receives one of sigs (os.Interrupt and SIGTERM when none are
+// given). Handlers observe the cancellation through ctx.Done() and can shut
+// down cleanly. A second signal exits the process immediately with status
+// 128 plus the signal number, for handlers that do not stop in time.
+//
+// Example:
+//
+//	if err := app.RunWithSignals(context.Background(), nil); err != nil {
+//		fmt.Fprintln(os.Stderr, err)
+//		os.Exit(1)
+//	}
+func (a *App) RunWithSignals(ctx context.Context, args []string, sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan os.Signal, 2)
+	signalNotify(received, sigs...)
+	defer signalStop(received)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-received:
+			cancel()
+		case <-done:
+			return
+		}
+		select {
+		case sig := <-received:
+			forceExit(exitCodeForSignal(sig))
+		case <-done:
+		}
+	}()
+
+	return a.Run(ctx, args)
+}
+
+// exitCodeForSignal follows the shell convention of 128 plus the signal number.
+func exitCodeForSignal(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
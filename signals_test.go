@@ -0,0 +1,97 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeSignals replaces signal delivery for the duration of a test and returns
+// a function that sends a signal to the registered channel.
+func fakeSignals(t *testing.T) (send func(os.Signal), exits <-chan int) {
+	t.Helper()
+	registered := make(chan chan<- os.Signal, 1)
+	exitCodes := make(chan int, 1)
+
+	origNotify, origStop, origExit := signalNotify, signalStop, forceExit
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) { registered <- c }
+	signalStop = func(c chan<- os.Signal) {}
+	forceExit = func(code int) { exitCodes <- code }
+	t.Cleanup(func() {
+		signalNotify, signalStop, forceExit = origNotify, origStop, origExit
+	})
+
+	var c chan<- os.Signal
+	return func(sig os.Signal) {
+		if c == nil {
+			c = <-registered
+		}
+		c <- sig
+	}, exitCodes
+}
+
+func TestRunWithSignals(t *testing.T) {
+	t.Run("signal cancels the command context", func(t *testing.T) {
+		send, _ := fakeSignals(t)
+
+		app := NewApp("test")
+		app.configLoaded = true
+		started := make(chan struct{})
+		app.Root.Run = func(ctx *Context) error {
+			close(started)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return errors.New("context was not canceled")
+			}
+		}
+
+		result := make(chan error, 1)
+		go func() { result <- app.RunWithSignals(context.Background(), []string{}) }()
+
+		<-started
+		send(os.Interrupt)
+
+		if err := <-result; !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("second signal forces exit", func(t *testing.T) {
+		send, exits := fakeSignals(t)
+
+		app := NewApp("test")
+		app.configLoaded = true
+		release := make(chan struct{})
+		started := make(chan struct{})
+		app.Root.Run = func(ctx *Context) error {
+			close(started)
+			<-release
+			return nil
+		}
+
+		result := make(chan error, 1)
+		go func() { result <- app.RunWithSignals(context.Background(), []string{}, syscall.SIGTERM) }()
+
+		<-started
+		send(syscall.SIGTERM)
+		send(syscall.SIGTERM)
+
+		select {
+		case code := <-exits:
+			if code != 128+int(syscall.SIGTERM) {
+				t.Errorf("expected exit code %d, got %d", 128+int(syscall.SIGTERM), code)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected forced exit after second signal")
+		}
+		close(release)
+		if err := <-result; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package clix
+
+import "io"
+
+// stdinArg is the conventional argument meaning "read from standard input".
+const stdinArg = "-"
+
+// StdinArg reports whether the positional argument at index was "-", the
+// Unix convention for standard input, and returns App.In to read it from.
+// index counts the positional arguments as typed, after flags are removed,
+// so for "myapp process --verbose -" the "-" is at index 0:
+//
+//	cmd.Run = func(ctx *clix.Context) error {
+//		if in, ok := ctx.StdinArg(0); ok {
+//			return process(in)
+//		}
+//		return processFile(path)
+//	}
+func (ctx *Context) StdinArg(index int) (io.Reader, bool) {
+	if index < 0 || index >= len(ctx.args) || ctx.args[index] != stdinArg {
+		return nil, false
+	}
+	return ctx.App.In, true
+}
+
+// StdinFlag reports whether the flag named key resolved to "-", as in
+// "--input -", and returns App.In to read it from.
+func (ctx *Context) StdinFlag(key string) (io.Reader, bool) {
+	value, ok := ctx.String(key)
+	if !ok || value != stdinArg {
+		return nil, false
+	}
+	return ctx.App.In, true
+}
@@ -0,0 +1,105 @@
+package clix
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStdinArg(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	newApp := func(in io.Reader) (*App, *Command) {
+		app := NewApp("demo")
+		app.configLoaded = true
+		app.In = in
+		app.Out = &bytes.Buffer{}
+		app.Err = &bytes.Buffer{}
+
+		var source, dest string
+		var verbose bool
+		cmd := NewCommand("process")
+		cmd.Flags.StringVar(WithFlagName("source"), WithFlagPositional(), WithStringValue(&source))
+		cmd.Flags.StringVar(WithFlagName("dest"), WithFlagPositional(), WithStringValue(&dest))
+		cmd.Flags.BoolVar(WithFlagName("verbose"), WithBoolValue(&verbose))
+		app.Root.AddCommand(cmd)
+		return app, cmd
+	}
+
+	t.Run("dash is read from App.In", func(t *testing.T) {
+		in := strings.NewReader("piped data")
+		app, cmd := newApp(in)
+		var got string
+		cmd.Run = func(ctx *Context) error {
+			r, ok := ctx.StdinArg(0)
+			if !ok {
+				t.Fatal("expected argument 0 to be stdin")
+			}
+			if r != in {
+				t.Fatalf("expected App.In, got %#v", r)
+			}
+			data, err := io.ReadAll(r)
+			got = string(data)
+			return err
+		}
+		if err := app.Run(context.Background(), []string{"process", "--verbose", "-", "out.txt"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if got != "piped data" {
+			t.Errorf("read %q, want %q", got, "piped data")
+		}
+	})
+
+	t.Run("other positions and plain values are not stdin", func(t *testing.T) {
+		app, cmd := newApp(strings.NewReader(""))
+		cmd.Run = func(ctx *Context) error {
+			for _, index := range []int{-1, 0, 2} {
+				if _, ok := ctx.StdinArg(index); ok {
+					t.Errorf("expected argument %d not to be stdin", index)
+				}
+			}
+			if _, ok := ctx.StdinArg(1); !ok {
+				t.Error("expected argument 1 to be stdin")
+			}
+			return nil
+		}
+		if err := app.Run(context.Background(), []string{"process", "in.txt", "-"}); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	})
+}
+
+func TestStdinFlag(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	in := strings.NewReader("config from stdin")
+	app := NewApp("demo")
+	app.configLoaded = true
+	app.In = in
+	app.Out = &bytes.Buffer{}
+
+	var input, output string
+	cmd := NewCommand("apply")
+	cmd.Flags.StringVar(WithFlagName("input"), WithStringValue(&input))
+	cmd.Flags.StringVar(WithFlagName("output"), WithStringValue(&output))
+	cmd.Run = func(ctx *Context) error {
+		r, ok := ctx.StdinFlag("input")
+		if !ok || r != in {
+			t.Errorf("expected --input - to return App.In, got %v, %v", r, ok)
+		}
+		if _, ok := ctx.StdinFlag("output"); ok {
+			t.Error("expected --output to not be stdin")
+		}
+		if _, ok := ctx.StdinFlag("missing"); ok {
+			t.Error("expected unknown flag to not be stdin")
+		}
+		return nil
+	}
+	app.Root.AddCommand(cmd)
+
+	if err := app.Run(context.Background(), []string{"apply", "--input", "-", "--output", "out.txt"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// timeoutFlagName is the root flag registered by EnableTimeoutFlag.
+const timeoutFlagName = "timeout"
+
+// EnableTimeoutFlag registers a global --timeout duration flag (e.g.
+// --timeout 30s) that bounds how long a command may run. The hooks and
+// handler receive a *Context whose deadline is the timeout, so work that
+// observes ctx.Done() is canceled, and App.Run then returns an error wrapping
+// context.DeadlineExceeded prefixed with the command path. Zero or unset
+// means no timeout. The value can also come from the environment or config
+// like any app flag.
+func (a *App) EnableTimeoutFlag() {
+	a.timeoutFlag = true
+	flags := a.Flags()
+	if flags.lookup(timeoutFlagName) != nil {
+		return
+	}
+	var timeout time.Duration
+	flags.DurationVar(DurationVarOptions{
+		FlagOptions: FlagOptions{
+			Name:  timeoutFlagName,
+			Usage: "Abort the command after this long (e.g. 30s, 5m)",
+		},
+		Value: &timeout,
+	})
+}
+
+// WithAppTimeoutFlag calls EnableTimeoutFlag on the app.
+func WithAppTimeoutFlag() AppOption {
+	return appTimeoutFlagOption{}
+}
+
+type appTimeoutFlagOption struct{}
+
+func (appTimeoutFlagOption) ApplyApp(app *App) {
+	app.EnableTimeoutFlag()
+}
+
+// applyTimeout bounds runCtx by the --timeout value. It reports whether a
+// deadline was set; the returned func must be called either way and restores
+// the unbounded context for what runs after the command.
+func (a *App) applyTimeout(runCtx *Context) (context.CancelFunc, bool) {
+	if !a.timeoutFlag {
+		return func() {}, false
+	}
+	timeout, ok := runCtx.Duration(timeoutFlagName)
+	if !ok || timeout <= 0 {
+		return func() {}, false
+	}
+	parent := runCtx.Context
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	runCtx.Context = ctx
+	return func() {
+		cancel()
+		runCtx.Context = parent
+	}, true
+}
+
+// timeoutError prefixes err with the command path when the command stopped
+// because its --timeout elapsed.
+func timeoutError(runCtx *Context, cmd *Command, err error) error {
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) || !errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", cmd.Path(), err)
+}
@@ -0,0 +1,89 @@
+package clix
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func sleepHandler(d time.Duration) Handler {
+	return func(ctx *Context) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestTimeoutFlagCancelsHandler(t *testing.T) {
+	app := newTestApp(t, "demo", WithAppTimeoutFlag())
+	sync := NewCommand("sync", WithCommandRun(sleepHandler(5*time.Second)))
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", sync))
+	start := time.Now()
+	err := app.Run(context.Background(), []string{"repo", "sync", "--timeout", "20ms"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if err.Error() != "demo repo sync: context deadline exceeded" {
+		t.Errorf("message = %q", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("handler ran for %v after the timeout", elapsed)
+	}
+}
+
+func TestTimeoutFlagUnset(t *testing.T) {
+	var deadline bool
+	app := newTestApp(t, "demo", WithAppTimeoutFlag())
+	sync := NewCommand("sync", WithCommandRun(func(ctx *Context) error {
+		_, deadline = ctx.Deadline()
+		return sleepHandler(10 * time.Millisecond)(ctx)
+	}))
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", sync))
+	for _, args := range [][]string{{"repo", "sync"}, {"repo", "sync", "--timeout", "0"}} {
+		if err := app.Run(context.Background(), args); err != nil {
+			t.Fatalf("Run(%q): %v", args, err)
+		}
+		if deadline {
+			t.Errorf("Run(%q): handler context has a deadline", args)
+		}
+	}
+}
+
+func TestTimeoutFlagFromEnv(t *testing.T) {
+	t.Setenv("DEMO_TIMEOUT", "20ms")
+	app := newTestApp(t, "demo", WithAppTimeoutFlag())
+	sync := NewCommand("sync", WithCommandRun(sleepHandler(5*time.Second)))
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", sync))
+	if err := app.Run(context.Background(), []string{"repo", "sync"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestTimeoutFlagOtherErrorsUnchanged(t *testing.T) {
+	want := errors.New("boom")
+	app := newTestApp(t, "demo", WithAppTimeoutFlag())
+	sync := NewCommand("sync", WithCommandRun(func(*Context) error { return want }))
+	app.Root.AddCommand(NewGroup("repo", "Manage repositories", sync))
+	if err := app.Run(context.Background(), []string{"repo", "sync", "--timeout", "1m"}); err != want {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func TestTimeoutFlagRequiresOptIn(t *testing.T) {
+	app := newTestApp(t, "demo")
+	var timeout time.Duration
+	app.Flags().DurationVar(WithFlagName("timeout"), WithDurationValue(&timeout))
+	app.Root.Run = func(ctx *Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("a user --timeout flag bounded the handler")
+		}
+		return nil
+	}
+	if err := app.Run(context.Background(), []string{"--timeout", "1ms"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package clix
+
+import (
+	"fmt"
+	"io"
+)
+
+// UsageError wraps an error caused by invalid command-line input, such as an
+// unknown flag, a bad value or the wrong number of arguments, as opposed to a
+// failure inside a command's Run handler. App.Main prints the command's usage
+// after a UsageError unless the command sets SilenceUsage. Callers of Run can
+// detect it with errors.As to do the same.
+type UsageError struct {
+	// Command is the command whose input was rejected.
+	Command *Command
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// usageError wraps err in a UsageError for cmd.
+func usageError(cmd *Command, err error) error {
+	return &UsageError{Command: cmd, Err: err}
+}
+
+// silenced reports whether pick is true for cmd or any of its ancestors.
+func silenced(cmd *Command, pick func(*Command) bool) bool {
+	for c := cmd; c != nil; c = c.parent {
+		if pick(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// printUsage writes the command's usage line and a pointer to its help.
+func (a *App) printUsage(w io.Writer, cmd *Command) {
+	usage := cmd.Usage
+	if usage == "" {
+		usage = HelpRenderer{App: a}.buildUsageLine(cmd)
+	}
+	fmt.Fprintf(w, "Usage: %s\nRun '%s --help' for more information.\n", usage, cmd.Path())
+}